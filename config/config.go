@@ -10,12 +10,13 @@ import (
 
 // Config represents an app config.
 type Config struct {
-	App            App
-	Telegram       Telegram
-	PostgreSQL     PostgreSQL
-	CurrencyBeacon CurrencyBeacon
-	Gemini         Gemini
-	Logger         Logger
+	App               App
+	Telegram          Telegram
+	PostgreSQL        PostgreSQL
+	CurrencyBeacon    CurrencyBeacon
+	CurrencyExchanger CurrencyExchanger
+	Gemini            Gemini
+	Logger            Logger
 }
 
 // App represents an app configuration.
@@ -23,6 +24,9 @@ type App struct {
 	OperationCreationInterval               time.Duration `env:"FB_APP_OPERATION_CREATION_INTERVAL" env-default:"5m"`
 	ExtendingScheduledOperationsInterval    time.Duration `env:"FB_APP_EXTENDING_SCHEDULED_OPERATIONS_INTERVAL" env-default:"1h"`
 	NotifyAboutSubscriptionPaymentsInterval time.Duration `env:"FB_APP_NOTIFY_ABOUT_SUBSCRIPTION_PAYMENTS_INTERVAL" env-default:"1m"`
+	ExchangeRatesCacheTTL                   time.Duration `env:"FB_APP_EXCHANGE_RATES_CACHE_TTL" env-default:"1h"`
+	CurrenciesRefreshInterval               time.Duration `env:"FB_APP_CURRENCIES_REFRESH_INTERVAL" env-default:"24h"`
+	SendDigestsInterval                     time.Duration `env:"FB_APP_SEND_DIGESTS_INTERVAL" env-default:"10m"`
 }
 
 // Telegram represents a telegram bot configuration.
@@ -50,6 +54,18 @@ type CurrencyBeacon struct {
 	APIEndpoint string `env:"FB_CURRENCY_BEACON_API_ENDPOINT" env-default:"https://api.currencybeacon.com"`
 }
 
+// CurrencyExchanger represents a config for the chain of currency exchange rates providers.
+type CurrencyExchanger struct {
+	// Providers contains names of providers in the order they're tried.
+	// Available providers: currency_beacon, ecb, manual_rates.
+	Providers []string `env:"FB_CURRENCY_EXCHANGER_PROVIDERS" env-separator:"," env-default:"currency_beacon,ecb"`
+	// CatalogProvider contains name of the provider used to refresh the list of currencies.
+	// Available providers: currency_beacon, manual_rates.
+	CatalogProvider string `env:"FB_CURRENCY_EXCHANGER_CATALOG_PROVIDER" env-default:"currency_beacon"`
+	ECBRatesURL     string `env:"FB_CURRENCY_EXCHANGER_ECB_RATES_URL" env-default:"https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"`
+	ManualRatesFile string `env:"FB_CURRENCY_EXCHANGER_MANUAL_RATES_FILE"`
+}
+
 // Gemini represents a config for Gemini API.
 type Gemini struct {
 	APIKey string `env:"FB_GEMINI_API_KEY"`
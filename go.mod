@@ -19,6 +19,7 @@ require (
 	github.com/shopspring/decimal v1.3.1
 	github.com/stretchr/testify v1.9.0
 	github.com/valyala/fasthttp v1.45.0
+	golang.org/x/image v0.25.0
 	golang.org/x/text v0.31.0
 	google.golang.org/api v0.186.0
 	gopkg.in/natefinch/lumberjack.v2 v2.2.1
@@ -3,6 +3,7 @@ package currencybeacon
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/VladPetriv/finance_bot/internal/service"
 	"github.com/VladPetriv/finance_bot/pkg/money"
@@ -51,11 +52,22 @@ func (c *currencyBeacon) FetchCurrencies() ([]service.Currency, error) {
 }
 
 func (c *currencyBeacon) GetExchangeRate(baseCurrency, targetCurrency string) (*money.Money, error) {
+	return c.getExchangeRate(fmt.Sprintf("/v1/latest?base=%s&symbols=%s", baseCurrency, targetCurrency), targetCurrency)
+}
+
+func (c *currencyBeacon) GetHistoricalExchangeRate(baseCurrency, targetCurrency string, date time.Time) (*money.Money, error) {
+	return c.getExchangeRate(
+		fmt.Sprintf("/v1/historical?base=%s&symbols=%s&date=%s", baseCurrency, targetCurrency, date.Format(time.DateOnly)),
+		targetCurrency,
+	)
+}
+
+func (c *currencyBeacon) getExchangeRate(url, targetCurrency string) (*money.Money, error) {
 	var result getExchangeRateResponse
 
 	response, err := c.httpClient.R().
 		SetResult(&result).
-		Get(fmt.Sprintf("/v1/latest?base=%s", baseCurrency))
+		Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("send get exchange rate request: %w", err)
 	}
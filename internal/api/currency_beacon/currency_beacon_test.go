@@ -0,0 +1,73 @@
+package currencybeacon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrencyBeacon_GetExchangeRate(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"base": "USD", "rates": {"UAH": 41.25}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	rate, err := New(server.URL, "key").GetExchangeRate("USD", "UAH")
+	assert.NoError(t, err)
+	assert.Equal(t, "41.25", rate.StringFixed())
+
+	_, err = New(server.URL, "key").GetExchangeRate("USD", "PLN")
+	assert.ErrorIs(t, err, service.ErrCurrencyExchangeRateNotFound)
+
+	_, err = New(server.URL, "expired").GetExchangeRate("USD", "UAH")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, service.ErrCurrencyExchangeRateNotFound)
+}
+
+func TestCurrencyBeacon_GetHistoricalExchangeRate(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/historical" || r.URL.Query().Get("date") != "2025-03-01" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"date": "2025-03-01", "base": "USD", "rates": {"UAH": 41.5}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	rate, err := New(server.URL, "key").GetHistoricalExchangeRate("USD", "UAH", time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, "41.50", rate.StringFixed())
+
+	_, err = New(server.URL, "key").GetHistoricalExchangeRate("USD", "PLN", time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, err, service.ErrCurrencyExchangeRateNotFound)
+}
+
+func TestCurrencyBeacon_FetchCurrencies(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"response": [{"name": "US Dollar", "short_code": "USD", "symbol": "$"}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	currencies, err := New(server.URL, "key").FetchCurrencies()
+	assert.NoError(t, err)
+	assert.Equal(t, []service.Currency{{Name: "US Dollar", Code: "USD", Symbol: "$"}}, currencies)
+}
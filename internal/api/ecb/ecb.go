@@ -0,0 +1,91 @@
+package ecb
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/service"
+	"github.com/VladPetriv/finance_bot/pkg/money"
+	"resty.dev/v3"
+)
+
+// referenceCurrency represents the currency against which all ECB rates are quoted.
+const referenceCurrency = "EUR"
+
+type ecb struct {
+	httpClient *resty.Client
+	ratesURL   string
+}
+
+// New creates a new instance of ECB daily reference rates api.
+func New(ratesURL string) *ecb {
+	return &ecb{
+		httpClient: resty.New(),
+		ratesURL:   ratesURL,
+	}
+}
+
+// GetExchangeRate returns the cross rate between base and target currencies calculated through EUR.
+func (e *ecb) GetExchangeRate(baseCurrency, targetCurrency string) (*money.Money, error) {
+	rates, err := e.fetchRates()
+	if err != nil {
+		return nil, fmt.Errorf("fetch rates: %w", err)
+	}
+
+	baseRate, ok := rates[baseCurrency]
+	if !ok {
+		return nil, service.ErrCurrencyExchangeRateNotFound
+	}
+	targetRate, ok := rates[targetCurrency]
+	if !ok {
+		return nil, service.ErrCurrencyExchangeRateNotFound
+	}
+
+	exchangeRate := money.Zero
+	exchangeRate.Inc(targetRate)
+	exchangeRate.Div(baseRate)
+
+	return &exchangeRate, nil
+}
+
+// GetHistoricalExchangeRate always reports the rate as missing, since daily reference rates contain only the latest rates.
+func (e *ecb) GetHistoricalExchangeRate(_, _ string, _ time.Time) (*money.Money, error) {
+	return nil, service.ErrCurrencyExchangeRateNotFound
+}
+
+func (e *ecb) fetchRates() (map[string]money.Money, error) {
+	response, err := e.httpClient.R().Get(e.ratesURL)
+	if err != nil {
+		return nil, fmt.Errorf("send get daily rates request: %w", err)
+	}
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("could not get daily rates(statusCode: %d, body:%s)", response.StatusCode(), response.String())
+	}
+
+	var result dailyRatesResponse
+	err = xml.Unmarshal(response.Bytes(), &result)
+	if err != nil {
+		return nil, fmt.Errorf("parse daily rates: %w", err)
+	}
+	if len(result.Cube.Cube.Rates) == 0 {
+		return nil, fmt.Errorf("daily rates document doesn't contain any rates")
+	}
+
+	rates := make(map[string]money.Money, len(result.Cube.Cube.Rates)+1)
+	rates[referenceCurrency] = money.NewFromInt(1)
+	for _, rate := range result.Cube.Cube.Rates {
+		value, err := money.NewFromString(rate.Rate)
+		if err != nil {
+			return nil, fmt.Errorf("parse rate of %s: %w", rate.Currency, err)
+		}
+		if value.Equal(money.Zero) {
+			continue
+		}
+
+		rates[rate.Currency] = value
+	}
+
+	return rates, nil
+}
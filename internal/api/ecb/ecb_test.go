@@ -0,0 +1,95 @@
+package ecb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VladPetriv/finance_bot/internal/service"
+	"github.com/stretchr/testify/assert"
+)
+
+const dailyRatesDocument = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender>
+		<gesmes:name>European Central Bank</gesmes:name>
+	</gesmes:Sender>
+	<Cube>
+		<Cube time="2025-03-10">
+			<Cube currency="USD" rate="1.0833"/>
+			<Cube currency="PLN" rate="4.1780"/>
+			<Cube currency="GBP" rate="0.8400"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func TestECB_GetExchangeRate(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write([]byte(dailyRatesDocument))
+	}))
+	t.Cleanup(server.Close)
+
+	testCases := [...]struct {
+		desc           string
+		baseCurrency   string
+		targetCurrency string
+		expected       string
+		expectedErr    error
+	}{
+		{
+			desc:           "rate from the reference currency",
+			baseCurrency:   "EUR",
+			targetCurrency: "USD",
+			expected:       "1.08",
+		},
+		{
+			desc:           "rate to the reference currency",
+			baseCurrency:   "GBP",
+			targetCurrency: "EUR",
+			expected:       "1.19",
+		},
+		{
+			desc:           "cross rate calculated through the reference currency",
+			baseCurrency:   "USD",
+			targetCurrency: "PLN",
+			expected:       "3.86",
+		},
+		{
+			desc:           "unknown currency",
+			baseCurrency:   "USD",
+			targetCurrency: "UAH",
+			expectedErr:    service.ErrCurrencyExchangeRateNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := New(server.URL).GetExchangeRate(tc.baseCurrency, tc.targetCurrency)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual.StringFixed())
+		})
+	}
+}
+
+func TestECB_Unavailable(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := New(server.URL).GetExchangeRate("EUR", "USD")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, service.ErrCurrencyExchangeRateNotFound)
+}
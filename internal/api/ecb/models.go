@@ -0,0 +1,17 @@
+package ecb
+
+// dailyRatesResponse represents the daily reference rates document published by ECB.
+// Rates are quoted against EUR, which is not listed in the document itself.
+type dailyRatesResponse struct {
+	Cube struct {
+		Cube struct {
+			Time  string `xml:"time,attr"`
+			Rates []rate `xml:"Cube"`
+		} `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+type rate struct {
+	Currency string `xml:"currency,attr"`
+	Rate     string `xml:"rate,attr"`
+}
@@ -0,0 +1,81 @@
+package exchangerchain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/service"
+	"github.com/VladPetriv/finance_bot/pkg/logger"
+	"github.com/VladPetriv/finance_bot/pkg/money"
+)
+
+// Provider represents a named currency exchanger used in the chain.
+type Provider struct {
+	Name      string
+	Exchanger service.CurrencyExchanger
+}
+
+type exchangerChain struct {
+	logger    *logger.Logger
+	providers []Provider
+}
+
+// New creates a new currency exchanger that tries providers in the given order
+// and returns the result of the first one that succeeded.
+func New(logger *logger.Logger, providers ...Provider) *exchangerChain {
+	return &exchangerChain{
+		logger:    logger,
+		providers: providers,
+	}
+}
+
+func (e *exchangerChain) GetExchangeRate(baseCurrency, targetCurrency string) (*money.Money, error) {
+	return e.getExchangeRate(baseCurrency, targetCurrency, func(exchanger service.CurrencyExchanger) (*money.Money, error) {
+		return exchanger.GetExchangeRate(baseCurrency, targetCurrency)
+	})
+}
+
+func (e *exchangerChain) GetHistoricalExchangeRate(baseCurrency, targetCurrency string, date time.Time) (*money.Money, error) {
+	return e.getExchangeRate(baseCurrency, targetCurrency, func(exchanger service.CurrencyExchanger) (*money.Money, error) {
+		return exchanger.GetHistoricalExchangeRate(baseCurrency, targetCurrency, date)
+	})
+}
+
+// getExchangeRate requests the exchange rate from providers in order and returns the first received one.
+func (e *exchangerChain) getExchangeRate(baseCurrency, targetCurrency string, getRate func(exchanger service.CurrencyExchanger) (*money.Money, error)) (*money.Money, error) {
+	logger := e.logger.With().Str("name", "exchangerChain.getExchangeRate").Logger()
+
+	var errs []error
+	for _, provider := range e.providers {
+		exchangeRate, err := getRate(provider.Exchanger)
+		if err != nil {
+			logger.Warn().Err(err).
+				Str("provider", provider.Name).
+				Str("baseCurrency", baseCurrency).
+				Str("targetCurrency", targetCurrency).
+				Msg("get exchange rate through provider, trying next one")
+			errs = append(errs, fmt.Errorf("%s: %w", provider.Name, err))
+			continue
+		}
+
+		return exchangeRate, nil
+	}
+
+	// Report missing rate as expected error only when none of the providers failed for other reason.
+	if len(errs) == 0 || allExchangeRatesNotFound(errs) {
+		return nil, service.ErrCurrencyExchangeRateNotFound
+	}
+
+	return nil, fmt.Errorf("get exchange rate through all providers: %w", errors.Join(errs...))
+}
+
+func allExchangeRatesNotFound(errs []error) bool {
+	for _, err := range errs {
+		if !errors.Is(err, service.ErrCurrencyExchangeRateNotFound) {
+			return false
+		}
+	}
+
+	return true
+}
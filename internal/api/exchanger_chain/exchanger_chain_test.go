@@ -0,0 +1,103 @@
+package exchangerchain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/service"
+	"github.com/VladPetriv/finance_bot/pkg/logger"
+	"github.com/VladPetriv/finance_bot/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubExchanger struct {
+	rate *money.Money
+	err  error
+}
+
+func (s stubExchanger) GetExchangeRate(_, _ string) (*money.Money, error) {
+	return s.rate, s.err
+}
+
+func (s stubExchanger) GetHistoricalExchangeRate(_, _ string, _ time.Time) (*money.Money, error) {
+	return s.rate, s.err
+}
+
+func TestExchangerChain_GetExchangeRate(t *testing.T) {
+	t.Parallel()
+
+	log := logger.New(logger.LoggergerOptions{LogLevel: "disabled"})
+	rate := money.NewFromFloat(41.25)
+	unavailable := errors.New("service unavailable")
+
+	testCases := [...]struct {
+		desc        string
+		providers   []Provider
+		expected    *money.Money
+		expectedErr error
+	}{
+		{
+			desc: "first provider succeeded",
+			providers: []Provider{
+				{Name: "first", Exchanger: stubExchanger{rate: &rate}},
+				{Name: "second", Exchanger: stubExchanger{err: unavailable}},
+			},
+			expected: &rate,
+		},
+		{
+			desc: "fallback to the next provider",
+			providers: []Provider{
+				{Name: "first", Exchanger: stubExchanger{err: unavailable}},
+				{Name: "second", Exchanger: stubExchanger{err: service.ErrCurrencyExchangeRateNotFound}},
+				{Name: "third", Exchanger: stubExchanger{rate: &rate}},
+			},
+			expected: &rate,
+		},
+		{
+			desc: "rate not found in all providers",
+			providers: []Provider{
+				{Name: "first", Exchanger: stubExchanger{err: service.ErrCurrencyExchangeRateNotFound}},
+				{Name: "second", Exchanger: stubExchanger{err: service.ErrCurrencyExchangeRateNotFound}},
+			},
+			expectedErr: service.ErrCurrencyExchangeRateNotFound,
+		},
+		{
+			desc: "all providers failed",
+			providers: []Provider{
+				{Name: "first", Exchanger: stubExchanger{err: unavailable}},
+				{Name: "second", Exchanger: stubExchanger{err: service.ErrCurrencyExchangeRateNotFound}},
+			},
+			expectedErr: unavailable,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := New(log, tc.providers...).GetExchangeRate("USD", "UAH")
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestExchangerChain_GetHistoricalExchangeRate(t *testing.T) {
+	t.Parallel()
+
+	log := logger.New(logger.LoggergerOptions{LogLevel: "disabled"})
+	rate := money.NewFromFloat(41.25)
+
+	actual, err := New(
+		log,
+		Provider{Name: "first", Exchanger: stubExchanger{err: service.ErrCurrencyExchangeRateNotFound}},
+		Provider{Name: "second", Exchanger: stubExchanger{rate: &rate}},
+	).GetHistoricalExchangeRate("USD", "UAH", time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, &rate, actual)
+}
@@ -0,0 +1,131 @@
+package manualrates
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/service"
+	"github.com/VladPetriv/finance_bot/pkg/money"
+)
+
+type manualRates struct {
+	filePath string
+}
+
+// New creates a new instance of manual rates api, which reads exchange rates from the local file.
+// The file is read on each call, so rates could be updated without restarting the app.
+func New(filePath string) *manualRates {
+	return &manualRates{
+		filePath: filePath,
+	}
+}
+
+// FetchCurrencies returns currencies described in the file.
+// When the file doesn't describe currencies, they're built from codes of the listed rates.
+func (m *manualRates) FetchCurrencies() ([]service.Currency, error) {
+	file, err := m.readFile()
+	if err != nil {
+		return nil, fmt.Errorf("read rates file: %w", err)
+	}
+
+	if len(file.Currencies) != 0 {
+		output := make([]service.Currency, 0, len(file.Currencies))
+		for _, currency := range file.Currencies {
+			output = append(output, service.Currency{
+				Name:   currency.Name,
+				Code:   currency.Code,
+				Symbol: currency.Symbol,
+			})
+		}
+
+		return output, nil
+	}
+
+	rates, err := file.getRates()
+	if err != nil {
+		return nil, fmt.Errorf("get rates: %w", err)
+	}
+
+	output := make([]service.Currency, 0, len(rates))
+	for code := range rates {
+		output = append(output, service.Currency{
+			Name:   code,
+			Code:   code,
+			Symbol: code,
+		})
+	}
+
+	return output, nil
+}
+
+// GetExchangeRate returns the cross rate between base and target currencies calculated through the base currency of the file.
+func (m *manualRates) GetExchangeRate(baseCurrency, targetCurrency string) (*money.Money, error) {
+	file, err := m.readFile()
+	if err != nil {
+		return nil, fmt.Errorf("read rates file: %w", err)
+	}
+
+	rates, err := file.getRates()
+	if err != nil {
+		return nil, fmt.Errorf("get rates: %w", err)
+	}
+
+	baseRate, ok := rates[baseCurrency]
+	if !ok {
+		return nil, service.ErrCurrencyExchangeRateNotFound
+	}
+	targetRate, ok := rates[targetCurrency]
+	if !ok {
+		return nil, service.ErrCurrencyExchangeRateNotFound
+	}
+
+	exchangeRate := money.Zero
+	exchangeRate.Inc(targetRate)
+	exchangeRate.Div(baseRate)
+
+	return &exchangeRate, nil
+}
+
+// GetHistoricalExchangeRate always reports the rate as missing, since the file doesn't keep the history of rates.
+func (m *manualRates) GetHistoricalExchangeRate(_, _ string, _ time.Time) (*money.Money, error) {
+	return nil, service.ErrCurrencyExchangeRateNotFound
+}
+
+func (m *manualRates) readFile() (*ratesFile, error) {
+	data, err := os.ReadFile(m.filePath)
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	var file ratesFile
+	err = json.Unmarshal(data, &file)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal file: %w", err)
+	}
+	if file.Base == "" {
+		return nil, fmt.Errorf("base currency is not specified")
+	}
+
+	return &file, nil
+}
+
+// getRates returns rates of all currencies against the base one, including the base currency itself.
+func (r ratesFile) getRates() (map[string]money.Money, error) {
+	rates := make(map[string]money.Money, len(r.Rates)+1)
+	rates[r.Base] = money.NewFromInt(1)
+	for code, rate := range r.Rates {
+		value, err := money.NewFromString(rate.String())
+		if err != nil {
+			return nil, fmt.Errorf("parse rate of %s: %w", code, err)
+		}
+		if value.Equal(money.Zero) {
+			continue
+		}
+
+		rates[code] = value
+	}
+
+	return rates, nil
+}
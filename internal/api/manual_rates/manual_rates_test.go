@@ -0,0 +1,118 @@
+package manualrates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/VladPetriv/finance_bot/internal/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func createRatesFile(t *testing.T, content string) string {
+	t.Helper()
+
+	filePath := filepath.Join(t.TempDir(), "rates.json")
+	err := os.WriteFile(filePath, []byte(content), 0o600)
+	if err != nil {
+		t.Fatalf("write rates file: %v", err)
+	}
+
+	return filePath
+}
+
+func TestManualRates_GetExchangeRate(t *testing.T) {
+	t.Parallel()
+
+	filePath := createRatesFile(t, `{"base": "USD", "rates": {"EUR": 0.92, "UAH": "41.25"}}`)
+
+	testCases := [...]struct {
+		desc           string
+		baseCurrency   string
+		targetCurrency string
+		expected       string
+		expectedErr    error
+	}{
+		{
+			desc:           "rate from the base currency",
+			baseCurrency:   "USD",
+			targetCurrency: "UAH",
+			expected:       "41.25",
+		},
+		{
+			desc:           "rate to the base currency",
+			baseCurrency:   "EUR",
+			targetCurrency: "USD",
+			expected:       "1.09",
+		},
+		{
+			desc:           "cross rate calculated through the base currency",
+			baseCurrency:   "EUR",
+			targetCurrency: "UAH",
+			expected:       "44.84",
+		},
+		{
+			desc:           "unknown currency",
+			baseCurrency:   "USD",
+			targetCurrency: "PLN",
+			expectedErr:    service.ErrCurrencyExchangeRateNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := New(filePath).GetExchangeRate(tc.baseCurrency, tc.targetCurrency)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual.StringFixed())
+		})
+	}
+}
+
+func TestManualRates_FetchCurrencies(t *testing.T) {
+	t.Parallel()
+
+	testCases := [...]struct {
+		desc     string
+		content  string
+		expected []service.Currency
+	}{
+		{
+			desc:    "currencies described in the file",
+			content: `{"base": "USD", "rates": {"EUR": 0.92}, "currencies": [{"name": "US Dollar", "code": "USD", "symbol": "$"}]}`,
+			expected: []service.Currency{
+				{Name: "US Dollar", Code: "USD", Symbol: "$"},
+			},
+		},
+		{
+			desc:    "currencies built from rates",
+			content: `{"base": "USD", "rates": {"EUR": 0.92}}`,
+			expected: []service.Currency{
+				{Name: "USD", Code: "USD", Symbol: "USD"},
+				{Name: "EUR", Code: "EUR", Symbol: "EUR"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := New(createRatesFile(t, tc.content)).FetchCurrencies()
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestManualRates_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(filepath.Join(t.TempDir(), "missing.json")).GetExchangeRate("USD", "EUR")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, service.ErrCurrencyExchangeRateNotFound)
+}
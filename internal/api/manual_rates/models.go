@@ -0,0 +1,17 @@
+package manualrates
+
+import "encoding/json"
+
+// ratesFile represents the structure of the local file with manual exchange rates.
+// Rates are quoted against the base currency, e.g. 1 base = rate target.
+type ratesFile struct {
+	Base       string                 `json:"base"`
+	Rates      map[string]json.Number `json:"rates"`
+	Currencies []currency             `json:"currencies"`
+}
+
+type currency struct {
+	Name   string `json:"name"`
+	Code   string `json:"code"`
+	Symbol string `json:"symbol"`
+}
@@ -1,6 +1,7 @@
 package telegram
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 
@@ -192,6 +193,42 @@ func (t *telegramMessenger) UpdateMessage(opts service.UpdateMessageOptions) err
 	return nil
 }
 
+func (t *telegramMessenger) SendDocument(opts service.SendDocumentOptions) error {
+	document := telegoutil.Document(
+		telegoutil.ID(int64(opts.ChatID)),
+		telegoutil.File(telegoutil.NameReader(bytes.NewReader(opts.Content), opts.FileName)),
+	).WithCaption(opts.Caption)
+
+	if len(opts.Keyboard) != 0 {
+		document = document.WithReplyMarkup(t.createKeyboard(opts.Keyboard))
+	}
+
+	_, err := t.api.SendDocument(document)
+	if err != nil {
+		return fmt.Errorf("send telegram document: %w", err)
+	}
+
+	return nil
+}
+
+func (t *telegramMessenger) SendPhoto(opts service.SendPhotoOptions) error {
+	photo := telegoutil.Photo(
+		telegoutil.ID(int64(opts.ChatID)),
+		telegoutil.File(telegoutil.NameReader(bytes.NewReader(opts.Content), opts.FileName)),
+	).WithCaption(opts.Caption)
+
+	if len(opts.Keyboard) != 0 {
+		photo = photo.WithReplyMarkup(t.createKeyboard(opts.Keyboard))
+	}
+
+	_, err := t.api.SendPhoto(photo)
+	if err != nil {
+		return fmt.Errorf("send telegram photo: %w", err)
+	}
+
+	return nil
+}
+
 func unescapeMarkdownSymbols(message string) string {
 	message = strings.ReplaceAll(message, "(", `\(`)
 	message = strings.ReplaceAll(message, ")", `\)`)
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
@@ -9,7 +10,10 @@ import (
 
 	"github.com/VladPetriv/finance_bot/config"
 	currencybeacon "github.com/VladPetriv/finance_bot/internal/api/currency_beacon"
+	"github.com/VladPetriv/finance_bot/internal/api/ecb"
+	exchangerchain "github.com/VladPetriv/finance_bot/internal/api/exchanger_chain"
 	"github.com/VladPetriv/finance_bot/internal/api/gemini"
+	manualrates "github.com/VladPetriv/finance_bot/internal/api/manual_rates"
 	"github.com/VladPetriv/finance_bot/internal/api/telegram"
 	"github.com/VladPetriv/finance_bot/internal/migrations"
 	"github.com/VladPetriv/finance_bot/internal/service"
@@ -35,10 +39,21 @@ func Run(ctx context.Context, cfg *config.Config, logger *logger.Logger) {
 		logger.Fatal().Err(err).Msg("create new gemini api")
 	}
 
+	currencyExchangerProviders, err := newCurrencyExchangerProviders(cfg)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("create new currency exchanger providers")
+	}
+
+	currencyCatalog, err := newCurrencyCatalog(cfg)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("create new currency catalog")
+	}
+
 	apis := service.APIs{
 		Messenger:         telegram,
 		Prompter:          gemini,
-		CurrencyExchanger: currencybeacon.New(cfg.CurrencyBeacon.APIEndpoint, cfg.CurrencyBeacon.APIKey),
+		CurrencyExchanger: exchangerchain.New(logger, currencyExchangerProviders...),
+		CurrencyCatalog:   currencyCatalog,
 	}
 
 	postgres, err := database.NewPostgreSQL(database.PostgreSQLOptions{
@@ -72,16 +87,20 @@ func Run(ctx context.Context, cfg *config.Config, logger *logger.Logger) {
 		Operation:           store.NewOperation(postgres),
 		State:               store.NewState(postgres),
 		Currency:            store.NewCurrency(postgres),
+		ExchangeRate:        store.NewExchangeRate(postgres),
+		CategoryRule:        store.NewCategoryRule(postgres),
 	}
 
+	currencyService := service.NewCurrency(cfg, logger, apis, stores)
 	services := service.Services{
 		State: service.NewState(&service.StateOptions{
 			Logger: logger,
 			Stores: stores,
 			APIs:   apis,
 		}),
-		Currency:                  service.NewCurrency(logger, apis, stores),
-		BalanceSubscriptionEngine: service.NewBalanceSubscriptionEngine(cfg, logger, stores, apis),
+		Currency:                  currencyService,
+		BalanceSubscriptionEngine: service.NewBalanceSubscriptionEngine(cfg, logger, stores, apis, currencyService),
+		Digest:                    service.NewDigest(cfg, logger, stores, apis),
 	}
 
 	handlerService := service.NewHandler(&service.HandlerOptions{
@@ -99,15 +118,20 @@ func Run(ctx context.Context, cfg *config.Config, logger *logger.Logger) {
 		Services: services,
 	})
 
+	// Failed initialization is not fatal, the app keeps working with already stored currencies
+	// and tries to initialize them again on the next start.
 	err = services.Currency.InitCurrencies(ctx)
 	if err != nil {
-		logger.Fatal().Err(err).Msg("init currencies")
+		logger.Error().Err(err).Msg("init currencies")
 	}
 
 	go eventService.Listen(ctx)
 	go services.BalanceSubscriptionEngine.CreateOperations(ctx)
 	go services.BalanceSubscriptionEngine.ExtendScheduledOperations(ctx)
 	go services.BalanceSubscriptionEngine.NotifyAboutSubscriptionPayment(ctx)
+	go services.BalanceSubscriptionEngine.NotifyAboutTrialEnd(ctx)
+	go services.Currency.RefreshCurrencies(ctx)
+	go services.Digest.SendDigests(ctx)
 
 	// Setup health check server
 	mux := http.NewServeMux()
@@ -163,3 +187,52 @@ func Run(ctx context.Context, cfg *config.Config, logger *logger.Logger) {
 
 	logger.Info().Msg("application stopped")
 }
+
+// newCurrencyExchangerProviders creates currency exchange rates providers in the configured order.
+func newCurrencyExchangerProviders(cfg *config.Config) ([]exchangerchain.Provider, error) {
+	providers := make([]exchangerchain.Provider, 0, len(cfg.CurrencyExchanger.Providers))
+	for _, name := range cfg.CurrencyExchanger.Providers {
+		var exchanger service.CurrencyExchanger
+		switch name {
+		case "currency_beacon":
+			exchanger = currencybeacon.New(cfg.CurrencyBeacon.APIEndpoint, cfg.CurrencyBeacon.APIKey)
+		case "ecb":
+			exchanger = ecb.New(cfg.CurrencyExchanger.ECBRatesURL)
+		case "manual_rates":
+			if cfg.CurrencyExchanger.ManualRatesFile == "" {
+				return nil, fmt.Errorf("manual rates file is not configured")
+			}
+
+			exchanger = manualrates.New(cfg.CurrencyExchanger.ManualRatesFile)
+		default:
+			return nil, fmt.Errorf("unknown currency exchanger provider: %s", name)
+		}
+
+		providers = append(providers, exchangerchain.Provider{
+			Name:      name,
+			Exchanger: exchanger,
+		})
+	}
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("currency exchanger providers are not configured")
+	}
+
+	return providers, nil
+}
+
+// newCurrencyCatalog creates the currency catalog from the configured provider.
+// ECB is not supported there, since it doesn't provide names and symbols of currencies.
+func newCurrencyCatalog(cfg *config.Config) (service.CurrencyCatalog, error) {
+	switch cfg.CurrencyExchanger.CatalogProvider {
+	case "currency_beacon":
+		return currencybeacon.New(cfg.CurrencyBeacon.APIEndpoint, cfg.CurrencyBeacon.APIKey), nil
+	case "manual_rates":
+		if cfg.CurrencyExchanger.ManualRatesFile == "" {
+			return nil, fmt.Errorf("manual rates file is not configured")
+		}
+
+		return manualrates.New(cfg.CurrencyExchanger.ManualRatesFile), nil
+	default:
+		return nil, fmt.Errorf("unsupported currency catalog provider: %s", cfg.CurrencyExchanger.CatalogProvider)
+	}
+}
@@ -0,0 +1,10 @@
+package migrations
+
+import "database/sql"
+
+func addAmountAfterTrialToBalanceSubscriptionsTable(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE balance_subscriptions ADD COLUMN amount_after_trial VARCHAR(255);
+	`)
+	return err
+}
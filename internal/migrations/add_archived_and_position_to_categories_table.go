@@ -0,0 +1,11 @@
+package migrations
+
+import "database/sql"
+
+func addArchivedAndPositionToCategoriesTable(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE categories ADD COLUMN archived BOOLEAN NOT NULL DEFAULT FALSE;
+		ALTER TABLE categories ADD COLUMN position INTEGER NOT NULL DEFAULT 0;
+	`)
+	return err
+}
@@ -0,0 +1,10 @@
+package migrations
+
+import "database/sql"
+
+func addBaseCurrencyCodeToUserSettings(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE user_settings ADD COLUMN base_currency_code VARCHAR NOT NULL DEFAULT '';
+	`)
+	return err
+}
@@ -0,0 +1,10 @@
+package migrations
+
+import "database/sql"
+
+func addCurrencyIDToBalanceSubscriptionsTable(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE balance_subscriptions ADD COLUMN currency_id VARCHAR(255);
+	`)
+	return err
+}
@@ -0,0 +1,11 @@
+package migrations
+
+import "database/sql"
+
+func addCurrencyToBalanceSubscriptionChangesTable(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE balance_subscription_changes ADD COLUMN previous_currency_id VARCHAR(255) NOT NULL DEFAULT '';
+		ALTER TABLE balance_subscription_changes ADD COLUMN new_currency_id VARCHAR(255) NOT NULL DEFAULT '';
+	`)
+	return err
+}
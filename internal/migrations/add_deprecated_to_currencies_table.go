@@ -0,0 +1,10 @@
+package migrations
+
+import "database/sql"
+
+func addDeprecatedToCurrenciesTable(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE currencies ADD COLUMN deprecated BOOLEAN NOT NULL DEFAULT false;
+	`)
+	return err
+}
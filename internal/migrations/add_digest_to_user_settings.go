@@ -0,0 +1,12 @@
+package migrations
+
+import "database/sql"
+
+func addDigestToUserSettings(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE user_settings ADD COLUMN digest_frequency VARCHAR NOT NULL DEFAULT 'off';
+		ALTER TABLE user_settings ADD COLUMN digest_send_time INTEGER NOT NULL DEFAULT 9;
+		ALTER TABLE user_settings ADD COLUMN last_digest_sent_at TIMESTAMP;
+	`)
+	return err
+}
@@ -0,0 +1,14 @@
+package migrations
+
+import "database/sql"
+
+func addEffectiveDateToCurrencyRatesTable(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE currency_rates ADD COLUMN effective_date DATE;
+		UPDATE currency_rates SET effective_date = created_at::DATE;
+		ALTER TABLE currency_rates ALTER COLUMN effective_date SET NOT NULL;
+		ALTER TABLE currency_rates DROP CONSTRAINT currency_rates_currency_id_target_currency_key;
+		ALTER TABLE currency_rates ADD CONSTRAINT currency_rates_currency_id_target_currency_effective_date_key UNIQUE (currency_id, target_currency, effective_date);
+	`)
+	return err
+}
@@ -0,0 +1,10 @@
+package migrations
+
+import "database/sql"
+
+func addKindToCategoriesTable(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE categories ADD COLUMN kind VARCHAR(255) NOT NULL DEFAULT 'both';
+	`)
+	return err
+}
@@ -0,0 +1,10 @@
+package migrations
+
+import "database/sql"
+
+func addMarketExchangeRateToOperationsTable(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE operations ADD COLUMN market_exchange_rate VARCHAR(255);
+	`)
+	return err
+}
@@ -0,0 +1,10 @@
+package migrations
+
+import "database/sql"
+
+func addNotificationLeadTimeToBalanceSubscriptionsTable(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE balance_subscriptions ADD COLUMN notification_lead_time INTEGER;
+	`)
+	return err
+}
@@ -0,0 +1,11 @@
+package migrations
+
+import "database/sql"
+
+func addOriginalAmountAndCurrencyToOperationsTable(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE operations ADD COLUMN original_amount VARCHAR(255);
+		ALTER TABLE operations ADD COLUMN original_currency VARCHAR(255);
+	`)
+	return err
+}
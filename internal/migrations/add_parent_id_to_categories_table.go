@@ -0,0 +1,10 @@
+package migrations
+
+import "database/sql"
+
+func addParentIDToCategoriesTable(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE categories ADD COLUMN parent_id VARCHAR(255) NOT NULL DEFAULT '';
+	`)
+	return err
+}
@@ -0,0 +1,10 @@
+package migrations
+
+import "database/sql"
+
+func addSnoozedUntilToScheduledOperationsTable(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE scheduled_operations ADD COLUMN snoozed_until TIMESTAMP;
+	`)
+	return err
+}
@@ -0,0 +1,10 @@
+package migrations
+
+import "database/sql"
+
+func addSubscriptionNotificationLeadTimeToUserSettings(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE user_settings ADD COLUMN subscription_notification_lead_time INTEGER NOT NULL DEFAULT 1;
+	`)
+	return err
+}
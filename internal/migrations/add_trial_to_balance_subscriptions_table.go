@@ -0,0 +1,11 @@
+package migrations
+
+import "database/sql"
+
+func addTrialToBalanceSubscriptionsTable(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE balance_subscriptions ADD COLUMN trial_end_at TIMESTAMP;
+		ALTER TABLE balance_subscriptions ADD COLUMN trial_reminder_sent BOOLEAN NOT NULL DEFAULT false;
+	`)
+	return err
+}
@@ -0,0 +1,15 @@
+package migrations
+
+import "database/sql"
+
+func addUserIDAndDecimalPlacesToCurrenciesTable(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE currencies ADD COLUMN user_id VARCHAR(255) NOT NULL DEFAULT '';
+		ALTER TABLE currencies ADD COLUMN decimal_places INTEGER NOT NULL DEFAULT 2;
+
+		ALTER TABLE currencies DROP CONSTRAINT currencies_code_fkey;
+		ALTER TABLE ONLY currencies
+			ADD CONSTRAINT currencies_code_user_id_key UNIQUE (code, user_id);
+	`)
+	return err
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import "database/sql"
+
+func initBalanceSubscriptionChangesTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE balance_subscription_changes (
+			id VARCHAR(255) PRIMARY KEY,
+			subscription_id VARCHAR(255) NOT NULL,
+			previous_amount VARCHAR(255) NOT NULL,
+			new_amount VARCHAR(255) NOT NULL,
+			previous_period VARCHAR(255) NOT NULL,
+			new_period VARCHAR(255) NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW()
+		);
+
+		ALTER TABLE balance_subscription_changes
+	        ADD CONSTRAINT fk_balance_subscription_changes_subscription_id FOREIGN KEY (subscription_id) REFERENCES balance_subscriptions(id) ON DELETE CASCADE;
+	`)
+
+	return err
+}
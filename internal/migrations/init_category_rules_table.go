@@ -0,0 +1,21 @@
+package migrations
+
+import "database/sql"
+
+func initCategoryRulesTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE category_rules (
+			id VARCHAR(255) PRIMARY KEY,
+			user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			category_id VARCHAR(255) NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+			balance_id VARCHAR(255) NOT NULL DEFAULT '',
+			match_type VARCHAR(255) NOT NULL,
+			pattern VARCHAR(255) NOT NULL,
+			min_amount VARCHAR(255) NOT NULL DEFAULT '',
+			max_amount VARCHAR(255) NOT NULL DEFAULT '',
+			created_at TIMESTAMP NOT NULL DEFAULT NOW()
+		);
+	`)
+
+	return err
+}
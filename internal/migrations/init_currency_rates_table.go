@@ -0,0 +1,19 @@
+package migrations
+
+import "database/sql"
+
+func initCurrencyRatesTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE currency_rates (
+			id VARCHAR(255) PRIMARY KEY,
+			currency_id VARCHAR(255) NOT NULL REFERENCES currencies(id) ON DELETE CASCADE,
+			target_currency VARCHAR(255) NOT NULL,
+			rate VARCHAR(255) NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			UNIQUE (currency_id, target_currency)
+		);
+	`)
+
+	return err
+}
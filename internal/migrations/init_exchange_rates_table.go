@@ -0,0 +1,20 @@
+package migrations
+
+import "database/sql"
+
+func initExchangeRatesTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE exchange_rates (
+			id VARCHAR(255) PRIMARY KEY,
+			base_currency VARCHAR(255) NOT NULL,
+			target_currency VARCHAR(255) NOT NULL,
+			date DATE NOT NULL,
+			rate VARCHAR(255) NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			UNIQUE (base_currency, target_currency, date)
+		);
+	`)
+
+	return err
+}
@@ -68,4 +68,92 @@ var Migrations = []any{
 		Name: "Add exchange_rate column to operations table",
 		Func: addExchangeRateToOperationsTable,
 	},
+	&migrator.Migration{
+		Name: "Add subscription_notification_lead_time to user_settings table",
+		Func: addSubscriptionNotificationLeadTimeToUserSettings,
+	},
+	&migrator.Migration{
+		Name: "Add notification_lead_time column to balance_subscriptions table",
+		Func: addNotificationLeadTimeToBalanceSubscriptionsTable,
+	},
+	&migrator.Migration{
+		Name: "Add snoozed_until column to scheduled_operations table",
+		Func: addSnoozedUntilToScheduledOperationsTable,
+	},
+	&migrator.Migration{
+		Name: "Add currency_id column to balance_subscriptions table",
+		Func: addCurrencyIDToBalanceSubscriptionsTable,
+	},
+	&migrator.Migration{
+		Name: "Add original_amount and original_currency columns to operations table",
+		Func: addOriginalAmountAndCurrencyToOperationsTable,
+	},
+	&migrator.MigrationNoTx{
+		Name: "Init balance_subscription_changes table",
+		Func: initBalanceSubscriptionChangesTable,
+	},
+	&migrator.Migration{
+		Name: "Add trial_end_at and trial_reminder_sent columns to balance_subscriptions table",
+		Func: addTrialToBalanceSubscriptionsTable,
+	},
+	&migrator.MigrationNoTx{
+		Name: "Init exchange_rates table",
+		Func: initExchangeRatesTable,
+	},
+	&migrator.Migration{
+		Name: "Add market_exchange_rate column to operations table",
+		Func: addMarketExchangeRateToOperationsTable,
+	},
+	&migrator.Migration{
+		Name: "Add user_id and decimal_places columns to currencies table",
+		Func: addUserIDAndDecimalPlacesToCurrenciesTable,
+	},
+	&migrator.MigrationNoTx{
+		Name: "Init currency_rates table",
+		Func: initCurrencyRatesTable,
+	},
+	&migrator.Migration{
+		Name: "Add base_currency_code column to user_settings table",
+		Func: addBaseCurrencyCodeToUserSettings,
+	},
+	&migrator.Migration{
+		Name: "Update decimal_places of currencies according to their minor units",
+		Func: updateDecimalPlacesOfCurrencies,
+	},
+	&migrator.Migration{
+		Name: "Add deprecated column to currencies table",
+		Func: addDeprecatedToCurrenciesTable,
+	},
+	&migrator.Migration{
+		Name: "Add parent_id column to categories table",
+		Func: addParentIDToCategoriesTable,
+	},
+	&migrator.Migration{
+		Name: "Add kind column to categories table",
+		Func: addKindToCategoriesTable,
+	},
+	&migrator.MigrationNoTx{
+		Name: "Init category_rules table",
+		Func: initCategoryRulesTable,
+	},
+	&migrator.Migration{
+		Name: "Add archived and position columns to categories table",
+		Func: addArchivedAndPositionToCategoriesTable,
+	},
+	&migrator.Migration{
+		Name: "Add digest columns to user_settings table",
+		Func: addDigestToUserSettings,
+	},
+	&migrator.Migration{
+		Name: "Add previous_currency_id and new_currency_id columns to balance_subscription_changes table",
+		Func: addCurrencyToBalanceSubscriptionChangesTable,
+	},
+	&migrator.Migration{
+		Name: "Add amount_after_trial column to balance_subscriptions table",
+		Func: addAmountAfterTrialToBalanceSubscriptionsTable,
+	},
+	&migrator.Migration{
+		Name: "Add effective_date column to currency_rates table",
+		Func: addEffectiveDateToCurrencyRatesTable,
+	},
 }
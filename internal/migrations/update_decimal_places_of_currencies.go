@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/VladPetriv/finance_bot/pkg/money"
+)
+
+func updateDecimalPlacesOfCurrencies(tx *sql.Tx) error {
+	rows, err := tx.Query("SELECT id, code FROM currencies WHERE user_id = '';")
+	if err != nil {
+		return fmt.Errorf("select public currencies: %w", err)
+	}
+
+	decimalPlacesByCurrencyID := make(map[string]int32)
+	for rows.Next() {
+		var id, code string
+		err := rows.Scan(&id, &code)
+		if err != nil {
+			rows.Close()
+			return fmt.Errorf("scan public currency: %w", err)
+		}
+
+		decimalPlaces := money.GetDecimalPlaces(code)
+		if decimalPlaces != money.DefaultDecimalPlaces {
+			decimalPlacesByCurrencyID[id] = decimalPlaces
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate public currencies: %w", err)
+	}
+
+	for id, decimalPlaces := range decimalPlacesByCurrencyID {
+		_, err := tx.Exec("UPDATE currencies SET decimal_places = $1 WHERE id = $2;", decimalPlaces, id)
+		if err != nil {
+			return fmt.Errorf("update decimal places of currency: %w", err)
+		}
+	}
+
+	return nil
+}
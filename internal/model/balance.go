@@ -37,46 +37,66 @@ func (b Balance) GetCurrency() Currency {
 	return b.Currency
 }
 
+// ExchangeRatePlaces represents the number of places after digit used to display exchange rates.
+const ExchangeRatePlaces = 4
+
 // BuildCurrencyConversionMessage creates a formatted message prompting the user
 // for an exchange rate when transferring between different currencies.
-// It includes source/destination balance info and an example conversion using a 4x rate.
-func BuildCurrencyConversionMessage(balanceFrom, balanceTo *Balance) string {
+// It includes source/destination balance info and an example conversion using the market rate,
+// when it's known, otherwise using a 4x rate.
+func BuildCurrencyConversionMessage(balanceFrom, balanceTo *Balance, marketExchangeRate *money.Money) string {
+	exampleRate := money.NewFromInt(4)
+	if marketExchangeRate != nil {
+		exampleRate = *marketExchangeRate
+	}
+
+	balanceFromAmount, _ := money.NewFromString(balanceFrom.Amount)
 	parsedAmount, _ := money.NewFromString(balanceFrom.Amount)
-	parsedAmount.Mul(money.NewFromInt(4))
+	parsedAmount.Mul(exampleRate)
+
+	marketRateMessage := "To accurately convert your money, please provide the current exchange rate:"
+	if marketExchangeRate != nil {
+		marketRateMessage = fmt.Sprintf(
+			"Current market rate: 1 %s = %s %s\nUse it or provide the actual rate of your bank:",
+			balanceFrom.GetCurrency().Symbol,
+			marketExchangeRate.StringRounded(ExchangeRatePlaces),
+			balanceTo.GetCurrency().Symbol,
+		)
+	}
 
 	return fmt.Sprintf(`⚠️ Different Currency Transfer ⚠️
 Source Balance: %s
 Currency: %s
-Amount: %v %s
+Amount: %s
 
 Destination Balance: %s
 Currency: %s
 
-To accurately convert your money, please provide the current exchange rate:
+%s
 
 Formula: 1 %s = X %s
 (How many %s you get for 1 %s)
 
 Example:
-- If 1 %s = 4 %s, enter: 4
-- This means %v %s will be converted to %v %s
+- If 1 %s = %s %s, enter: %s
+- This means %s will be converted to %s
 
 Please enter the current exchange rate:`,
 		balanceFrom.Name,
 		balanceFrom.GetCurrency().Symbol,
-		balanceFrom.Amount,
-		balanceFrom.GetCurrency().Symbol,
+		balanceFromAmount.Format(balanceFrom.GetCurrency().GetMoneyFormat()),
 		balanceTo.Name,
 		balanceTo.GetCurrency().Symbol,
+		marketRateMessage,
 		balanceFrom.GetCurrency().Symbol,
 		balanceTo.GetCurrency().Symbol,
 		balanceTo.GetCurrency().Symbol,
 		balanceFrom.GetCurrency().Symbol,
 		balanceFrom.GetCurrency().Symbol,
+		exampleRate.StringRounded(ExchangeRatePlaces),
 		balanceTo.GetCurrency().Symbol,
-		balanceFrom.Amount,
-		balanceFrom.GetCurrency().Symbol,
-		parsedAmount.StringFixed(),
-		balanceTo.GetCurrency().Symbol,
+		exampleRate.StringRounded(ExchangeRatePlaces),
+		balanceFromAmount.Format(balanceFrom.GetCurrency().GetMoneyFormat()),
+		parsedAmount.Format(balanceTo.GetCurrency().GetMoneyFormat()),
 	)
 }
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// SubscriptionPaymentsCalendarFileName represents the name of the file with exported subscription payments.
+const SubscriptionPaymentsCalendarFileName = "subscription_payments.ics"
+
+// SubscriptionPaymentCalendarEvent represents a scheduled subscription payment that is exported to a calendar.
+type SubscriptionPaymentCalendarEvent struct {
+	ScheduledOperation  ScheduledOperation
+	BalanceSubscription BalanceSubscription
+	BalanceName         string
+	CurrencyCode        string
+}
+
+const (
+	calendarLineBreak       = "\r\n"
+	calendarMaxLineLength   = 75
+	calendarDateFormat      = "20060102"
+	calendarTimestampFormat = "20060102T150405Z"
+	calendarUIDDomain       = "finance-bot"
+)
+
+// BuildSubscriptionPaymentsCalendar returns an iCalendar (RFC 5545) document with an all-day event per payment.
+// UIDs are based on scheduled operation IDs, so re-imported events update the existing ones instead of duplicating them.
+func BuildSubscriptionPaymentsCalendar(events []SubscriptionPaymentCalendarEvent, createdAt time.Time) string {
+	var buffer strings.Builder
+
+	writeCalendarLine(&buffer, "BEGIN:VCALENDAR")
+	writeCalendarLine(&buffer, "VERSION:2.0")
+	writeCalendarLine(&buffer, "PRODID:-//finance_bot//Subscription Payments//EN")
+	writeCalendarLine(&buffer, "CALSCALE:GREGORIAN")
+	writeCalendarLine(&buffer, "METHOD:PUBLISH")
+
+	for _, event := range events {
+		paymentDay := event.ScheduledOperation.CreationDate
+
+		writeCalendarLine(&buffer, "BEGIN:VEVENT")
+		writeCalendarLine(&buffer, fmt.Sprintf("UID:%s@%s", event.ScheduledOperation.ID, calendarUIDDomain))
+		writeCalendarLine(&buffer, "DTSTAMP:"+createdAt.UTC().Format(calendarTimestampFormat))
+		writeCalendarLine(&buffer, "DTSTART;VALUE=DATE:"+paymentDay.Format(calendarDateFormat))
+		writeCalendarLine(&buffer, "DTEND;VALUE=DATE:"+paymentDay.AddDate(0, 0, 1).Format(calendarDateFormat))
+		writeCalendarLine(&buffer, "SUMMARY:"+escapeCalendarText(fmt.Sprintf(
+			"💳 %s: %s %s", event.BalanceSubscription.Name, event.BalanceSubscription.GetChargeAmount(), event.CurrencyCode,
+		)))
+		writeCalendarLine(&buffer, "DESCRIPTION:"+escapeCalendarText(fmt.Sprintf(
+			"Subscription: %s\nAmount: %s %s\nPeriod: %s\nBalance: %s",
+			event.BalanceSubscription.Name, event.BalanceSubscription.GetChargeAmount(), event.CurrencyCode,
+			event.BalanceSubscription.Period, event.BalanceName,
+		)))
+		writeCalendarLine(&buffer, "TRANSP:TRANSPARENT")
+		writeCalendarLine(&buffer, "END:VEVENT")
+	}
+
+	writeCalendarLine(&buffer, "END:VCALENDAR")
+
+	return buffer.String()
+}
+
+// writeCalendarLine writes content line folded to the max line length, as required by RFC 5545.
+// Lines are split on rune boundaries, so multi-byte characters are never broken.
+func writeCalendarLine(buffer *strings.Builder, line string) {
+	lineLength := 0
+	for _, r := range line {
+		runeLength := utf8.RuneLen(r)
+		if lineLength+runeLength > calendarMaxLineLength {
+			buffer.WriteString(calendarLineBreak + " ")
+			// Leading space of the continuation line counts towards its length.
+			lineLength = 1
+		}
+
+		buffer.WriteRune(r)
+		lineLength += runeLength
+	}
+
+	buffer.WriteString(calendarLineBreak)
+}
+
+var calendarTextReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+func escapeCalendarText(text string) string {
+	return calendarTextReplacer.Replace(text)
+}
@@ -0,0 +1,79 @@
+package model_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildSubscriptionPaymentsCalendar(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2025, time.February, 10, 12, 30, 0, 0, time.UTC)
+
+	testCases := [...]struct {
+		desc     string
+		events   []model.SubscriptionPaymentCalendarEvent
+		expected string
+	}{
+		{
+			desc:   "built empty calendar",
+			events: []model.SubscriptionPaymentCalendarEvent{},
+			expected: "BEGIN:VCALENDAR\r\n" +
+				"VERSION:2.0\r\n" +
+				"PRODID:-//finance_bot//Subscription Payments//EN\r\n" +
+				"CALSCALE:GREGORIAN\r\n" +
+				"METHOD:PUBLISH\r\n" +
+				"END:VCALENDAR\r\n",
+		},
+		{
+			desc: "built calendar with escaped and folded lines",
+			events: []model.SubscriptionPaymentCalendarEvent{
+				{
+					ScheduledOperation: model.ScheduledOperation{
+						ID:           "op-1",
+						CreationDate: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+					},
+					BalanceSubscription: model.BalanceSubscription{
+						Name:   "Music, Video; Books",
+						Amount: "9.99",
+						Period: model.SubscriptionPeriodMonthly,
+					},
+					BalanceName:  "Card",
+					CurrencyCode: "USD",
+				},
+			},
+			expected: "BEGIN:VCALENDAR\r\n" +
+				"VERSION:2.0\r\n" +
+				"PRODID:-//finance_bot//Subscription Payments//EN\r\n" +
+				"CALSCALE:GREGORIAN\r\n" +
+				"METHOD:PUBLISH\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"UID:op-1@finance-bot\r\n" +
+				"DTSTAMP:20250210T123000Z\r\n" +
+				"DTSTART;VALUE=DATE:20250301\r\n" +
+				"DTEND;VALUE=DATE:20250302\r\n" +
+				"SUMMARY:💳 Music\\, Video\\; Books: 9.99 USD\r\n" +
+				"DESCRIPTION:Subscription: Music\\, Video\\; Books\\nAmount: 9.99 USD\\nPeriod: \r\n" +
+				" monthly\\nBalance: Card\r\n" +
+				"TRANSP:TRANSPARENT\r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual := model.BuildSubscriptionPaymentsCalendar(tc.events, createdAt)
+			assert.Equal(t, tc.expected, actual)
+
+			for _, line := range strings.Split(strings.TrimSuffix(actual, "\r\n"), "\r\n") {
+				assert.LessOrEqual(t, len(line), 75)
+			}
+		})
+	}
+}
@@ -1,10 +1,75 @@
 package model
 
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
 // Category represents a category model.
 type Category struct {
 	ID     string `db:"id"`
 	UserID string `db:"user_id"`
 	Title  string `db:"title"`
+	// ParentID is set when the category is a sub-category of another one, e.g. "Groceries" inside of "Food".
+	ParentID string `db:"parent_id"`
+	// Kind defines for which operations the category could be used.
+	Kind CategoryKind `db:"kind"`
+	// Archived categories are hidden from choosing, but are kept in operations history and statistics.
+	Archived bool `db:"archived"`
+	// Position defines the order of the category among categories with the same parent.
+	Position int `db:"position"`
+}
+
+// ErrInvalidCategoryPosition happens when the category can't be moved to the given position.
+var ErrInvalidCategoryPosition = errors.New("invalid category position")
+
+// CategoryKind represents the kind of operations the category is used for.
+type CategoryKind string
+
+const (
+	// CategoryKindIncome represents a category used only for incoming operations.
+	CategoryKindIncome CategoryKind = "income"
+	// CategoryKindExpense represents a category used only for spending operations.
+	CategoryKindExpense CategoryKind = "expense"
+	// CategoryKindBoth represents a category used for both incoming and spending operations.
+	CategoryKindBoth CategoryKind = "both"
+)
+
+// GetLabel returns human readable representation of the category kind.
+func (k CategoryKind) GetLabel() string {
+	switch k {
+	case CategoryKindIncome:
+		return "Income"
+	case CategoryKindExpense:
+		return "Expense"
+	default:
+		return "Income & Expense"
+	}
+}
+
+// GetKind returns the category kind, categories without kind are used for both incoming and spending operations.
+func (c Category) GetKind() CategoryKind {
+	if c.Kind == "" {
+		return CategoryKindBoth
+	}
+
+	return c.Kind
+}
+
+// GetCategoryKindFromCommand returns the category kind that corresponds to the bot command.
+func GetCategoryKindFromCommand(command string) (CategoryKind, bool) {
+	switch command {
+	case BotIncomeCategoryKindCommand:
+		return CategoryKindIncome, true
+	case BotExpenseCategoryKindCommand:
+		return CategoryKindExpense, true
+	case BotIncomeAndExpenseCategoryKindCommand:
+		return CategoryKindBoth, true
+	default:
+		return "", false
+	}
 }
 
 // GetID returns the category ID.
@@ -16,3 +81,263 @@ func (c Category) GetID() string {
 func (c Category) GetName() string {
 	return c.Title
 }
+
+// IsSubcategory returns true when the category has a parent category.
+func (c Category) IsSubcategory() bool {
+	return c.ParentID != ""
+}
+
+// IsSuitableForOperationType checks if the category could be used for the operation with the given type.
+// Categories without kind are treated as suitable for both incoming and spending operations.
+func (c Category) IsSuitableForOperationType(operationType OperationType) bool {
+	switch c.Kind {
+	case CategoryKindIncome:
+		return operationType == OperationTypeIncoming
+	case CategoryKindExpense:
+		return operationType == OperationTypeSpending
+	default:
+		return true
+	}
+}
+
+// FilterCategoriesByOperationType returns only categories suitable for the operation with the given type.
+func FilterCategoriesByOperationType(categories []Category, operationType OperationType) []Category {
+	filtered := make([]Category, 0, len(categories))
+	for _, category := range categories {
+		if category.IsSuitableForOperationType(operationType) {
+			filtered = append(filtered, category)
+		}
+	}
+
+	return filtered
+}
+
+// GetOperationTypeFromInputSign returns the operation type when the user input explicitly starts with a sign,
+// e.g. "+500 salary" is incoming and "-40 coffee" is spending operation.
+func GetOperationTypeFromInputSign(input string) (OperationType, bool) {
+	input = strings.TrimSpace(input)
+
+	switch {
+	case strings.HasPrefix(input, "+"):
+		return OperationTypeIncoming, true
+	case strings.HasPrefix(input, "-"):
+		return OperationTypeSpending, true
+	default:
+		return "", false
+	}
+}
+
+// CategoryNode represents a top-level category with its sub-categories.
+type CategoryNode struct {
+	Category
+	Subcategories []Category
+}
+
+// BuildCategoryTree groups categories by their parents keeping the initial order.
+// Sub-categories which parent is not in the list are treated as top-level ones.
+func BuildCategoryTree(categories []Category) []CategoryNode {
+	indexByID := make(map[string]int, len(categories))
+	tree := make([]CategoryNode, 0, len(categories))
+
+	for _, category := range categories {
+		if category.IsSubcategory() && hasCategory(categories, category.ParentID) {
+			continue
+		}
+
+		indexByID[category.ID] = len(tree)
+		tree = append(tree, CategoryNode{Category: category})
+	}
+
+	for _, category := range categories {
+		index, ok := indexByID[category.ParentID]
+		if !category.IsSubcategory() || !ok {
+			continue
+		}
+
+		tree[index].Subcategories = append(tree[index].Subcategories, category)
+	}
+
+	return tree
+}
+
+// GetTopLevelCategories returns categories which are not sub-categories of any category from the list.
+func GetTopLevelCategories(categories []Category) []Category {
+	tree := BuildCategoryTree(categories)
+
+	topLevelCategories := make([]Category, 0, len(tree))
+	for _, node := range tree {
+		topLevelCategories = append(topLevelCategories, node.Category)
+	}
+
+	return topLevelCategories
+}
+
+// GetSubcategories returns sub-categories of the category with the given ID.
+func GetSubcategories(parentID string, categories []Category) []Category {
+	var subcategories []Category
+	for _, category := range categories {
+		if category.ParentID == parentID {
+			subcategories = append(subcategories, category)
+		}
+	}
+
+	return subcategories
+}
+
+// GetCategoryFullTitle returns category title with the title of its parent, e.g. "Food / Groceries".
+func GetCategoryFullTitle(category Category, categories []Category) string {
+	if !category.IsSubcategory() {
+		return category.Title
+	}
+
+	for _, parent := range categories {
+		if parent.ID == category.ParentID {
+			return fmt.Sprintf("%s / %s", parent.Title, category.Title)
+		}
+	}
+
+	return category.Title
+}
+
+// BuildCategoriesListMessage returns numbered list of top-level categories with their sub-categories.
+// Archived categories are listed separately at the end.
+func BuildCategoriesListMessage(categories []Category) string {
+	message := "Categories: \n"
+
+	for i, node := range BuildCategoryTree(GetActiveCategories(categories)) {
+		message += fmt.Sprintf("%d. %s\n", i+1, node.Title)
+
+		for _, subcategory := range node.Subcategories {
+			message += fmt.Sprintf("    • %s\n", subcategory.Title)
+		}
+	}
+
+	archivedCategories := GetArchivedCategories(categories)
+	if len(archivedCategories) == 0 {
+		return message
+	}
+
+	message += "\nArchived: \n"
+	for _, category := range archivedCategories {
+		message += fmt.Sprintf("    • %s\n", GetCategoryFullTitle(category, categories))
+	}
+
+	return message
+}
+
+// GetActiveCategories returns categories which could be chosen by the user.
+// Sub-categories of archived categories are treated as archived too.
+func GetActiveCategories(categories []Category) []Category {
+	archivedIDs := make(map[string]struct{})
+	for _, category := range categories {
+		if category.Archived {
+			archivedIDs[category.ID] = struct{}{}
+		}
+	}
+
+	activeCategories := make([]Category, 0, len(categories))
+	for _, category := range categories {
+		_, parentArchived := archivedIDs[category.ParentID]
+		if category.Archived || (category.IsSubcategory() && parentArchived) {
+			continue
+		}
+
+		activeCategories = append(activeCategories, category)
+	}
+
+	return activeCategories
+}
+
+// GetArchivedCategories returns categories that were archived by the user.
+func GetArchivedCategories(categories []Category) []Category {
+	var archivedCategories []Category
+	for _, category := range categories {
+		if category.Archived {
+			archivedCategories = append(archivedCategories, category)
+		}
+	}
+
+	return archivedCategories
+}
+
+// GetCategoriesInListOrder returns active categories in the same order as they are shown in the categories list,
+// i.e. every top-level category is followed by its sub-categories.
+func GetCategoriesInListOrder(categories []Category) []Category {
+	orderedCategories := make([]Category, 0, len(categories))
+	for _, node := range BuildCategoryTree(GetActiveCategories(categories)) {
+		orderedCategories = append(orderedCategories, node.Category)
+		orderedCategories = append(orderedCategories, node.Subcategories...)
+	}
+
+	return orderedCategories
+}
+
+// GetNextCategoryPosition returns the position for a new category, so it's shown after the existing categories with the same parent.
+func GetNextCategoryPosition(parentID string, categories []Category) int {
+	var lastPosition int
+	for _, category := range categories {
+		if category.ParentID == parentID && category.Position > lastPosition {
+			lastPosition = category.Position
+		}
+	}
+
+	return lastPosition + 1
+}
+
+// MoveCategory moves the category to the position among active categories with the same parent.
+// Positions start from 1, returned categories have their positions recalculated according to the new order.
+func MoveCategory(categoryID string, position int, categories []Category) ([]Category, error) {
+	index := slices.IndexFunc(categories, func(category Category) bool {
+		return category.ID == categoryID
+	})
+	if index == -1 {
+		return nil, ErrInvalidCategoryPosition
+	}
+	parentID := categories[index].ParentID
+
+	siblings := slices.DeleteFunc(GetActiveCategories(categories), func(category Category) bool {
+		return category.ParentID != parentID
+	})
+	if position < 1 || position > len(siblings) {
+		return nil, ErrInvalidCategoryPosition
+	}
+
+	siblingIndex := slices.IndexFunc(siblings, func(category Category) bool {
+		return category.ID == categoryID
+	})
+	if siblingIndex == -1 {
+		return nil, ErrInvalidCategoryPosition
+	}
+
+	movedCategory := siblings[siblingIndex]
+	siblings = slices.Delete(siblings, siblingIndex, siblingIndex+1)
+	siblings = slices.Insert(siblings, position-1, movedCategory)
+
+	for i := range siblings {
+		siblings[i].Position = i + 1
+	}
+
+	return siblings, nil
+}
+
+// CountSiblingCategories returns the number of active categories with the same parent as the given one, including itself.
+func CountSiblingCategories(category Category, categories []Category) int {
+	var count int
+	for _, sibling := range GetActiveCategories(categories) {
+		if sibling.ParentID == category.ParentID {
+			count++
+		}
+	}
+
+	return count
+}
+
+func hasCategory(categories []Category, id string) bool {
+	for _, category := range categories {
+		if category.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
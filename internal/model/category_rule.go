@@ -0,0 +1,259 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/pkg/money"
+)
+
+// CategoryRule represents a user-defined rule for automatic categorization of operations.
+type CategoryRule struct {
+	ID         string `db:"id"`
+	UserID     string `db:"user_id"`
+	CategoryID string `db:"category_id"`
+	// BalanceID limits the rule to operations of the specific balance, empty value means any balance.
+	BalanceID string `db:"balance_id"`
+
+	MatchType CategoryRuleMatchType `db:"match_type"`
+	Pattern   string                `db:"pattern"`
+	// MinAmount and MaxAmount limit the rule to operations with amount in the range, empty value means no limit.
+	MinAmount string `db:"min_amount"`
+	MaxAmount string `db:"max_amount"`
+
+	CreatedAt time.Time `db:"created_at"`
+
+	// expression is the compiled pattern of the regex rule, it's set by CompilePattern.
+	expression *regexp.Regexp `db:"-"`
+}
+
+// CategoryRuleMatchType represents the way how the rule pattern is matched with operation description.
+type CategoryRuleMatchType string
+
+const (
+	// CategoryRuleMatchTypeContains represents a rule that matches descriptions containing the pattern.
+	CategoryRuleMatchTypeContains CategoryRuleMatchType = "contains"
+	// CategoryRuleMatchTypeRegex represents a rule that matches descriptions by the regular expression.
+	CategoryRuleMatchTypeRegex CategoryRuleMatchType = "regex"
+)
+
+// maxCategoryRulePatternLength limits the length of the pattern, so matching of the rules is always cheap.
+const maxCategoryRulePatternLength = 100
+
+var (
+	// ErrInvalidCategoryRulePattern happens when the pattern of category rule is empty or not a valid regular expression.
+	ErrInvalidCategoryRulePattern = errors.New("invalid category rule pattern")
+	// ErrInvalidCategoryRuleAmountRange happens when the amount range of category rule can't be parsed.
+	ErrInvalidCategoryRuleAmountRange = errors.New("invalid category rule amount range")
+)
+
+// GetID returns the category rule ID.
+func (r CategoryRule) GetID() string {
+	return r.ID
+}
+
+// GetName returns short representation of the rule pattern.
+func (r CategoryRule) GetName() string {
+	if r.MatchType == CategoryRuleMatchTypeRegex {
+		return fmt.Sprintf("/%s/", r.Pattern)
+	}
+
+	return fmt.Sprintf("%q", r.Pattern)
+}
+
+// GetAmountRange returns human readable representation of the rule amount range.
+func (r CategoryRule) GetAmountRange() string {
+	switch {
+	case r.MinAmount != "" && r.MaxAmount != "":
+		return fmt.Sprintf("%s-%s", r.MinAmount, r.MaxAmount)
+	case r.MinAmount != "":
+		return ">=" + r.MinAmount
+	case r.MaxAmount != "":
+		return "<=" + r.MaxAmount
+	default:
+		return ""
+	}
+}
+
+// CategoryRuleInput represents operation data used to find matching category rule.
+type CategoryRuleInput struct {
+	Description string
+	Amount      money.Money
+	// BalanceID could be empty when balance is not known yet, in that case rules limited to a balance are skipped.
+	BalanceID string
+}
+
+// CompilePattern compiles the pattern of the regex rule, so it's not compiled again on each match.
+// It should be called once after the rule is loaded, regex rules without compiled pattern never match.
+func (r *CategoryRule) CompilePattern() error {
+	if r.MatchType != CategoryRuleMatchTypeRegex {
+		return nil
+	}
+
+	expression, err := compileCategoryRuleRegex(r.Pattern)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCategoryRulePattern, err)
+	}
+
+	r.expression = expression
+	return nil
+}
+
+// Matches checks if the operation data satisfies all conditions of the rule.
+func (r CategoryRule) Matches(input CategoryRuleInput) bool {
+	if r.BalanceID != "" && r.BalanceID != input.BalanceID {
+		return false
+	}
+
+	if r.MinAmount != "" {
+		minAmount, err := money.NewFromString(r.MinAmount)
+		if err != nil || input.Amount.LessThan(minAmount) {
+			return false
+		}
+	}
+	if r.MaxAmount != "" {
+		maxAmount, err := money.NewFromString(r.MaxAmount)
+		if err != nil || input.Amount.GreaterThan(maxAmount) {
+			return false
+		}
+	}
+
+	switch r.MatchType {
+	case CategoryRuleMatchTypeRegex:
+		if r.expression == nil {
+			return false
+		}
+
+		return r.expression.MatchString(input.Description)
+	default:
+		return strings.Contains(strings.ToLower(input.Description), strings.ToLower(r.Pattern))
+	}
+}
+
+// FindCategoryRule returns the first rule that matches the operation data or nil if there is no such rule.
+// Rules are checked in the given order, so the earlier created rules have higher priority.
+func FindCategoryRule(rules []CategoryRule, input CategoryRuleInput) *CategoryRule {
+	for _, rule := range rules {
+		if rule.Matches(input) {
+			return &rule
+		}
+	}
+
+	return nil
+}
+
+// ParseCategoryRulePattern parses the pattern entered by the user.
+// Pattern wrapped into slashes is treated as a regular expression, e.g. "/^uber/", otherwise the rule matches
+// descriptions which contain the pattern. Both kinds of rules are case insensitive.
+func ParseCategoryRulePattern(input string) (CategoryRuleMatchType, string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" || len(input) > maxCategoryRulePatternLength {
+		return "", "", ErrInvalidCategoryRulePattern
+	}
+
+	if len(input) > 2 && strings.HasPrefix(input, "/") && strings.HasSuffix(input, "/") {
+		pattern := input[1 : len(input)-1]
+
+		_, err := compileCategoryRuleRegex(pattern)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: %w", ErrInvalidCategoryRulePattern, err)
+		}
+
+		return CategoryRuleMatchTypeRegex, pattern, nil
+	}
+
+	return CategoryRuleMatchTypeContains, input, nil
+}
+
+// ParseCategoryRuleAmountRange parses amount range entered by the user and returns its bounds.
+// Supported formats are "100-500", ">100" and "<500", bounds are inclusive.
+func ParseCategoryRuleAmountRange(input string) (string, string, error) {
+	input = strings.ReplaceAll(input, " ", "")
+
+	parseBound := func(bound string) (money.Money, error) {
+		amount, err := money.NewFromString(strings.TrimPrefix(bound, "="))
+		if err != nil || amount.LessThan(money.Zero) {
+			return money.Zero, ErrInvalidCategoryRuleAmountRange
+		}
+
+		return amount, nil
+	}
+
+	switch {
+	case strings.HasPrefix(input, ">"):
+		minAmount, err := parseBound(input[1:])
+		if err != nil {
+			return "", "", err
+		}
+
+		return minAmount.StringFixed(), "", nil
+	case strings.HasPrefix(input, "<"):
+		maxAmount, err := parseBound(input[1:])
+		if err != nil {
+			return "", "", err
+		}
+
+		return "", maxAmount.StringFixed(), nil
+	}
+
+	bounds := strings.Split(input, "-")
+	if len(bounds) != 2 {
+		return "", "", ErrInvalidCategoryRuleAmountRange
+	}
+
+	minAmount, err := parseBound(bounds[0])
+	if err != nil {
+		return "", "", err
+	}
+	maxAmount, err := parseBound(bounds[1])
+	if err != nil {
+		return "", "", err
+	}
+	if minAmount.GreaterThan(maxAmount) {
+		return "", "", ErrInvalidCategoryRuleAmountRange
+	}
+
+	return minAmount.StringFixed(), maxAmount.StringFixed(), nil
+}
+
+// BuildCategoryRulesMessage returns numbered list of the rules with their categories and conditions.
+func BuildCategoryRulesMessage(rules []CategoryRule, categories []Category, balances []Balance) string {
+	categoryTitles := make(map[string]string, len(categories))
+	for _, category := range categories {
+		categoryTitles[category.ID] = GetCategoryFullTitle(category, categories)
+	}
+
+	balanceNames := make(map[string]string, len(balances))
+	for _, balance := range balances {
+		balanceNames[balance.ID] = balance.Name
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Category rules:\n")
+
+	for i, rule := range rules {
+		builder.WriteString(fmt.Sprintf("%d. %s → %s", i+1, rule.GetName(), categoryTitles[rule.CategoryID]))
+
+		var conditions []string
+		if amountRange := rule.GetAmountRange(); amountRange != "" {
+			conditions = append(conditions, "amount "+amountRange)
+		}
+		if rule.BalanceID != "" {
+			conditions = append(conditions, "balance "+balanceNames[rule.BalanceID])
+		}
+		if len(conditions) > 0 {
+			builder.WriteString(fmt.Sprintf(" (%s)", strings.Join(conditions, ", ")))
+		}
+
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
+
+func compileCategoryRuleRegex(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("(?i)" + pattern)
+}
@@ -0,0 +1,195 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/VladPetriv/finance_bot/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindCategoryRule(t *testing.T) {
+	t.Parallel()
+
+	rules := []model.CategoryRule{
+		{ID: "1", CategoryID: "taxi", MatchType: model.CategoryRuleMatchTypeRegex, Pattern: "^(uber|bolt)"},
+		{ID: "2", CategoryID: "cafe", MatchType: model.CategoryRuleMatchTypeContains, Pattern: "coffee", MaxAmount: "10.00"},
+		{ID: "3", CategoryID: "restaurants", MatchType: model.CategoryRuleMatchTypeContains, Pattern: "coffee"},
+		{ID: "4", CategoryID: "salary", MatchType: model.CategoryRuleMatchTypeContains, Pattern: "salary", BalanceID: "card"},
+	}
+	for i := range rules {
+		assert.NoError(t, rules[i].CompilePattern())
+	}
+
+	testCases := [...]struct {
+		desc     string
+		input    model.CategoryRuleInput
+		expected string
+	}{
+		{
+			desc:     "matched by regex ignoring case",
+			input:    model.CategoryRuleInput{Description: "Uber trip home", Amount: money.NewFromInt(15)},
+			expected: "1",
+		},
+		{
+			desc:     "matched by contains with amount in range",
+			input:    model.CategoryRuleInput{Description: "Morning COFFEE", Amount: money.NewFromInt(5)},
+			expected: "2",
+		},
+		{
+			desc:     "matched by the next rule when amount is out of range",
+			input:    model.CategoryRuleInput{Description: "coffee with friends", Amount: money.NewFromInt(50)},
+			expected: "3",
+		},
+		{
+			desc:     "matched by rule limited to balance",
+			input:    model.CategoryRuleInput{Description: "salary", Amount: money.NewFromInt(1000), BalanceID: "card"},
+			expected: "4",
+		},
+		{
+			desc:  "not matched when balance is different",
+			input: model.CategoryRuleInput{Description: "salary", Amount: money.NewFromInt(1000), BalanceID: "cash"},
+		},
+		{
+			desc:  "not matched when description is different",
+			input: model.CategoryRuleInput{Description: "groceries", Amount: money.NewFromInt(5)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual := model.FindCategoryRule(rules, tc.input)
+			if tc.expected == "" {
+				assert.Nil(t, actual)
+				return
+			}
+
+			if assert.NotNil(t, actual) {
+				assert.Equal(t, tc.expected, actual.ID)
+			}
+		})
+	}
+}
+
+func TestCategoryRule_CompilePattern(t *testing.T) {
+	t.Parallel()
+
+	rule := model.CategoryRule{MatchType: model.CategoryRuleMatchTypeRegex, Pattern: "^uber"}
+	input := model.CategoryRuleInput{Description: "Uber trip", Amount: money.NewFromInt(10)}
+
+	assert.False(t, rule.Matches(input), "regex rule without compiled pattern must not match")
+
+	assert.NoError(t, rule.CompilePattern())
+	assert.True(t, rule.Matches(input))
+
+	invalidRule := model.CategoryRule{MatchType: model.CategoryRuleMatchTypeRegex, Pattern: "(uber"}
+	assert.ErrorIs(t, invalidRule.CompilePattern(), model.ErrInvalidCategoryRulePattern)
+}
+
+func TestParseCategoryRulePattern(t *testing.T) {
+	t.Parallel()
+
+	testCases := [...]struct {
+		desc              string
+		input             string
+		expectedMatchType model.CategoryRuleMatchType
+		expectedPattern   string
+		expectedErr       error
+	}{
+		{
+			desc:              "contains pattern",
+			input:             " coffee ",
+			expectedMatchType: model.CategoryRuleMatchTypeContains,
+			expectedPattern:   "coffee",
+		},
+		{
+			desc:              "regex pattern",
+			input:             "/^uber/",
+			expectedMatchType: model.CategoryRuleMatchTypeRegex,
+			expectedPattern:   "^uber",
+		},
+		{
+			desc:        "invalid regex pattern",
+			input:       "/(uber/",
+			expectedErr: model.ErrInvalidCategoryRulePattern,
+		},
+		{
+			desc:        "empty pattern",
+			input:       "  ",
+			expectedErr: model.ErrInvalidCategoryRulePattern,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			matchType, pattern, err := model.ParseCategoryRulePattern(tc.input)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedMatchType, matchType)
+			assert.Equal(t, tc.expectedPattern, pattern)
+		})
+	}
+}
+
+func TestParseCategoryRuleAmountRange(t *testing.T) {
+	t.Parallel()
+
+	testCases := [...]struct {
+		desc        string
+		input       string
+		expectedMin string
+		expectedMax string
+		expectedErr error
+	}{
+		{
+			desc:        "range",
+			input:       "100 - 500",
+			expectedMin: "100.00",
+			expectedMax: "500.00",
+		},
+		{
+			desc:        "only minimum",
+			input:       ">100",
+			expectedMin: "100.00",
+		},
+		{
+			desc:        "only maximum",
+			input:       "<=50.5",
+			expectedMax: "50.50",
+		},
+		{
+			desc:        "minimum greater than maximum",
+			input:       "500-100",
+			expectedErr: model.ErrInvalidCategoryRuleAmountRange,
+		},
+		{
+			desc:        "invalid input",
+			input:       "abc",
+			expectedErr: model.ErrInvalidCategoryRuleAmountRange,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			minAmount, maxAmount, err := model.ParseCategoryRuleAmountRange(tc.input)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedMin, minAmount)
+			assert.Equal(t, tc.expectedMax, maxAmount)
+		})
+	}
+}
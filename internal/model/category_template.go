@@ -0,0 +1,136 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// CategoryTemplate represents a predefined set of categories the user could start with.
+type CategoryTemplate struct {
+	Name       string
+	Categories []CategoryTemplateItem
+}
+
+// CategoryTemplateItem represents a category from the template.
+type CategoryTemplateItem struct {
+	Title string
+	Kind  CategoryKind
+}
+
+// CategoryTemplates contains all available category templates.
+var CategoryTemplates = []CategoryTemplate{
+	{
+		Name: "Basic Personal 👤",
+		Categories: []CategoryTemplateItem{
+			{Title: "💰 Salary", Kind: CategoryKindIncome},
+			{Title: "🛒 Groceries", Kind: CategoryKindExpense},
+			{Title: "🍽️ Restaurants", Kind: CategoryKindExpense},
+			{Title: "🚕 Transport", Kind: CategoryKindExpense},
+			{Title: "🏠 Housing", Kind: CategoryKindExpense},
+			{Title: "💡 Utilities", Kind: CategoryKindExpense},
+			{Title: "💊 Health", Kind: CategoryKindExpense},
+			{Title: "👕 Clothing", Kind: CategoryKindExpense},
+			{Title: "🎬 Entertainment", Kind: CategoryKindExpense},
+			{Title: "🎁 Gifts", Kind: CategoryKindBoth},
+		},
+	},
+	{
+		Name: "Family 👨‍👩‍👧",
+		Categories: []CategoryTemplateItem{
+			{Title: "💰 Salary", Kind: CategoryKindIncome},
+			{Title: "👪 Family Support", Kind: CategoryKindBoth},
+			{Title: "🛒 Groceries", Kind: CategoryKindExpense},
+			{Title: "🏠 Housing", Kind: CategoryKindExpense},
+			{Title: "💡 Utilities", Kind: CategoryKindExpense},
+			{Title: "👶 Kids", Kind: CategoryKindExpense},
+			{Title: "🎓 Education", Kind: CategoryKindExpense},
+			{Title: "💊 Health", Kind: CategoryKindExpense},
+			{Title: "🚗 Car", Kind: CategoryKindExpense},
+			{Title: "🐶 Pets", Kind: CategoryKindExpense},
+			{Title: "✈️ Vacation", Kind: CategoryKindExpense},
+		},
+	},
+	{
+		Name: "Freelancer 💻",
+		Categories: []CategoryTemplateItem{
+			{Title: "💼 Client Payments", Kind: CategoryKindIncome},
+			{Title: "🔁 Refunds", Kind: CategoryKindBoth},
+			{Title: "🧾 Taxes", Kind: CategoryKindExpense},
+			{Title: "💻 Software & Subscriptions", Kind: CategoryKindExpense},
+			{Title: "🖥️ Equipment", Kind: CategoryKindExpense},
+			{Title: "🏢 Coworking", Kind: CategoryKindExpense},
+			{Title: "📣 Marketing", Kind: CategoryKindExpense},
+			{Title: "📚 Courses", Kind: CategoryKindExpense},
+			{Title: "🏦 Bank Fees", Kind: CategoryKindExpense},
+			{Title: "🛒 Groceries", Kind: CategoryKindExpense},
+		},
+	},
+}
+
+// GetID returns the category template name, since templates are not stored anywhere.
+func (t CategoryTemplate) GetID() string {
+	return t.Name
+}
+
+// GetName returns the category template name.
+func (t CategoryTemplate) GetName() string {
+	return t.Name
+}
+
+// GetCategoryTemplate returns the category template by its name.
+func GetCategoryTemplate(name string) (CategoryTemplate, bool) {
+	for _, template := range CategoryTemplates {
+		if template.Name == name {
+			return template, true
+		}
+	}
+
+	return CategoryTemplate{}, false
+}
+
+// GetMissingCategories returns template categories which the user doesn't have yet, so the template
+// could be applied several times without creating duplicates. Titles are compared without emoji and case,
+// e.g. existing "groceries" category is treated as "🛒 Groceries" from the template.
+func (t CategoryTemplate) GetMissingCategories(existingCategories []Category) []CategoryTemplateItem {
+	existingTitles := make(map[string]struct{}, len(existingCategories))
+	for _, category := range existingCategories {
+		existingTitles[normalizeCategoryTitle(category.Title)] = struct{}{}
+	}
+
+	var missingCategories []CategoryTemplateItem
+	for _, item := range t.Categories {
+		if _, ok := existingTitles[normalizeCategoryTitle(item.Title)]; ok {
+			continue
+		}
+
+		missingCategories = append(missingCategories, item)
+	}
+
+	return missingCategories
+}
+
+// BuildCategoryTemplatesMessage returns the list of available templates with their categories.
+func BuildCategoryTemplatesMessage() string {
+	var builder strings.Builder
+	builder.WriteString("Available category templates:\n")
+
+	for _, template := range CategoryTemplates {
+		titles := make([]string, 0, len(template.Categories))
+		for _, item := range template.Categories {
+			titles = append(titles, item.Title)
+		}
+
+		builder.WriteString(fmt.Sprintf("\n%s\n%s\n", template.Name, strings.Join(titles, ", ")))
+	}
+
+	return builder.String()
+}
+
+func normalizeCategoryTitle(title string) string {
+	title = strings.TrimLeftFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.ToLower(strings.TrimSpace(title))
+}
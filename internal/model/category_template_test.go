@@ -0,0 +1,94 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategoryTemplate_GetMissingCategories(t *testing.T) {
+	t.Parallel()
+
+	template := model.CategoryTemplate{
+		Name: "Test",
+		Categories: []model.CategoryTemplateItem{
+			{Title: "🛒 Groceries", Kind: model.CategoryKindExpense},
+			{Title: "💰 Salary", Kind: model.CategoryKindIncome},
+			{Title: "🚕 Transport", Kind: model.CategoryKindExpense},
+		},
+	}
+
+	testCases := [...]struct {
+		desc       string
+		categories []model.Category
+		expected   []string
+	}{
+		{
+			desc:     "all categories are missing",
+			expected: []string{"🛒 Groceries", "💰 Salary", "🚕 Transport"},
+		},
+		{
+			desc: "categories with the same title are skipped",
+			categories: []model.Category{
+				{Title: "🛒 Groceries"},
+				{Title: "Food"},
+			},
+			expected: []string{"💰 Salary", "🚕 Transport"},
+		},
+		{
+			desc: "titles are compared without emoji and case",
+			categories: []model.Category{
+				{Title: "salary"},
+				{Title: "🚌 TRANSPORT"},
+			},
+			expected: []string{"🛒 Groceries"},
+		},
+		{
+			desc: "template is already applied",
+			categories: []model.Category{
+				{Title: "🛒 Groceries"},
+				{Title: "💰 Salary"},
+				{Title: "🚕 Transport"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var actual []string
+			for _, item := range template.GetMissingCategories(tc.categories) {
+				actual = append(actual, item.Title)
+			}
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestCategoryTemplates(t *testing.T) {
+	t.Parallel()
+
+	names := make(map[string]struct{})
+	for _, template := range model.CategoryTemplates {
+		_, ok := names[template.Name]
+		assert.False(t, ok, "template name should be unique: %s", template.Name)
+		names[template.Name] = struct{}{}
+
+		actual, ok := model.GetCategoryTemplate(template.Name)
+		assert.True(t, ok)
+		assert.Equal(t, template, actual)
+
+		// Applying the template for the second time should not create anything.
+		var categories []model.Category
+		for _, item := range template.Categories {
+			categories = append(categories, model.Category{Title: item.Title})
+		}
+		assert.Empty(t, template.GetMissingCategories(categories))
+	}
+
+	_, ok := model.GetCategoryTemplate("unknown")
+	assert.False(t, ok)
+}
@@ -0,0 +1,239 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCategoryTree(t *testing.T) {
+	t.Parallel()
+
+	categories := []model.Category{
+		{ID: "1", Title: "Food"},
+		{ID: "2", Title: "Groceries", ParentID: "1"},
+		{ID: "3", Title: "Transport"},
+		{ID: "4", Title: "Restaurants", ParentID: "1"},
+		{ID: "5", Title: "Taxi", ParentID: "deleted"},
+	}
+
+	expected := []model.CategoryNode{
+		{
+			Category: model.Category{ID: "1", Title: "Food"},
+			Subcategories: []model.Category{
+				{ID: "2", Title: "Groceries", ParentID: "1"},
+				{ID: "4", Title: "Restaurants", ParentID: "1"},
+			},
+		},
+		{Category: model.Category{ID: "3", Title: "Transport"}},
+		{Category: model.Category{ID: "5", Title: "Taxi", ParentID: "deleted"}},
+	}
+
+	assert.Equal(t, expected, model.BuildCategoryTree(categories))
+	assert.Equal(t, "Categories: \n1. Food\n    • Groceries\n    • Restaurants\n2. Transport\n3. Taxi\n", model.BuildCategoriesListMessage(categories))
+}
+
+func TestGetCategoryFullTitle(t *testing.T) {
+	t.Parallel()
+
+	categories := []model.Category{
+		{ID: "1", Title: "Food"},
+		{ID: "2", Title: "Groceries", ParentID: "1"},
+		{ID: "3", Title: "Taxi", ParentID: "deleted"},
+	}
+
+	testCases := [...]struct {
+		desc     string
+		category model.Category
+		expected string
+	}{
+		{
+			desc:     "top-level category",
+			category: categories[0],
+			expected: "Food",
+		},
+		{
+			desc:     "sub-category",
+			category: categories[1],
+			expected: "Food / Groceries",
+		},
+		{
+			desc:     "sub-category without parent in the list",
+			category: categories[2],
+			expected: "Taxi",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, model.GetCategoryFullTitle(tc.category, categories))
+		})
+	}
+}
+
+func TestFilterCategoriesByOperationType(t *testing.T) {
+	t.Parallel()
+
+	categories := []model.Category{
+		{ID: "1", Title: "Salary", Kind: model.CategoryKindIncome},
+		{ID: "2", Title: "Coffee", Kind: model.CategoryKindExpense},
+		{ID: "3", Title: "Gifts", Kind: model.CategoryKindBoth},
+		{ID: "4", Title: "Other"},
+	}
+
+	testCases := [...]struct {
+		desc          string
+		operationType model.OperationType
+		expected      []model.Category
+	}{
+		{
+			desc:          "incoming operation",
+			operationType: model.OperationTypeIncoming,
+			expected:      []model.Category{categories[0], categories[2], categories[3]},
+		},
+		{
+			desc:          "spending operation",
+			operationType: model.OperationTypeSpending,
+			expected:      []model.Category{categories[1], categories[2], categories[3]},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, model.FilterCategoriesByOperationType(categories, tc.operationType))
+		})
+	}
+}
+
+func TestGetOperationTypeFromInputSign(t *testing.T) {
+	t.Parallel()
+
+	testCases := [...]struct {
+		desc         string
+		input        string
+		expectedType model.OperationType
+		expectedOK   bool
+	}{
+		{
+			desc:         "input with plus sign",
+			input:        " +500 salary",
+			expectedType: model.OperationTypeIncoming,
+			expectedOK:   true,
+		},
+		{
+			desc:         "input with minus sign",
+			input:        "-40 coffee",
+			expectedType: model.OperationTypeSpending,
+			expectedOK:   true,
+		},
+		{
+			desc:  "input without sign",
+			input: "coffee 40",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actualType, actualOK := model.GetOperationTypeFromInputSign(tc.input)
+			assert.Equal(t, tc.expectedType, actualType)
+			assert.Equal(t, tc.expectedOK, actualOK)
+		})
+	}
+}
+
+func TestGetActiveCategories(t *testing.T) {
+	t.Parallel()
+
+	categories := []model.Category{
+		{ID: "1", Title: "Food"},
+		{ID: "2", Title: "Groceries", ParentID: "1"},
+		{ID: "3", Title: "Hobby", Archived: true},
+		{ID: "4", Title: "Photo", ParentID: "3"},
+		{ID: "5", Title: "Restaurants", ParentID: "1", Archived: true},
+	}
+
+	assert.Equal(t, []model.Category{categories[0], categories[1]}, model.GetActiveCategories(categories))
+	assert.Equal(t, []model.Category{categories[2], categories[4]}, model.GetArchivedCategories(categories))
+	assert.Equal(t,
+		"Categories: \n1. Food\n    • Groceries\n\nArchived: \n    • Hobby\n    • Food / Restaurants\n",
+		model.BuildCategoriesListMessage(categories),
+	)
+}
+
+func TestMoveCategory(t *testing.T) {
+	t.Parallel()
+
+	categories := []model.Category{
+		{ID: "1", Title: "Food", Position: 1},
+		{ID: "2", Title: "Groceries", ParentID: "1", Position: 1},
+		{ID: "3", Title: "Transport", Position: 2},
+		{ID: "4", Title: "Hobby", Position: 3, Archived: true},
+		{ID: "5", Title: "Home", Position: 4},
+	}
+
+	testCases := [...]struct {
+		desc        string
+		categoryID  string
+		position    int
+		expected    []model.Category
+		expectedErr error
+	}{
+		{
+			desc:       "moved to the first position",
+			categoryID: "5",
+			position:   1,
+			expected: []model.Category{
+				{ID: "5", Title: "Home", Position: 1},
+				{ID: "1", Title: "Food", Position: 2},
+				{ID: "3", Title: "Transport", Position: 3},
+			},
+		},
+		{
+			desc:       "moved to the last position",
+			categoryID: "1",
+			position:   3,
+			expected: []model.Category{
+				{ID: "3", Title: "Transport", Position: 1},
+				{ID: "5", Title: "Home", Position: 2},
+				{ID: "1", Title: "Food", Position: 3},
+			},
+		},
+		{
+			desc:        "position is out of siblings",
+			categoryID:  "2",
+			position:    2,
+			expectedErr: model.ErrInvalidCategoryPosition,
+		},
+		{
+			desc:        "archived category is not moved",
+			categoryID:  "4",
+			position:    1,
+			expectedErr: model.ErrInvalidCategoryPosition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := model.MoveCategory(tc.categoryID, tc.position, categories)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	assert.Equal(t, 5, model.GetNextCategoryPosition("", categories))
+	assert.Equal(t, 2, model.GetNextCategoryPosition("1", categories))
+}
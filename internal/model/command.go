@@ -24,6 +24,12 @@ const (
 	BotUpdateUserAIParserCommand string = "Update AI Parser 🤖"
 	// BotUpdateUserSubscriptionNotificationsCommand represents the command to update subscription notification settings
 	BotUpdateUserSubscriptionNotificationsCommand string = "Update Subscription Notifications 💳"
+	// BotUpdateUserSubscriptionNotificationLeadTimeCommand represents the command to update subscription notification lead time
+	BotUpdateUserSubscriptionNotificationLeadTimeCommand string = "Update Notification Lead Time ⏰"
+	// BotUpdateUserBaseCurrencyCommand represents the command to update base currency of the user
+	BotUpdateUserBaseCurrencyCommand string = "Update Base Currency 💱"
+	// BotUpdateUserDigestCommand represents the command to update digest frequency and send time of the user
+	BotUpdateUserDigestCommand string = "Update Digest 📬"
 
 	// BotCreateBalanceCommand represents the command to create a new balance
 	BotCreateBalanceCommand string = "Create Balance 💰"
@@ -39,6 +45,16 @@ const (
 	BotGetBalanceCommand string = "Get Balance Info 📊"
 	// BotDeleteBalanceCommand represents the command to delete a balance
 	BotDeleteBalanceCommand string = "Delete Balance ❌"
+	// BotGetNetWorthCommand represents the command to get net worth across all balances
+	BotGetNetWorthCommand string = "Net Worth 💎"
+	// BotCompareBalancePeriodsCommand represents the command to compare balance statistics of two periods
+	BotCompareBalancePeriodsCommand string = "Compare Periods 🆚"
+	// BotCreateCurrencyCommand represents the command to create a private currency
+	BotCreateCurrencyCommand string = "Create Currency 🪙"
+	// BotListCurrenciesCommand represents the command to list private currencies
+	BotListCurrenciesCommand string = "List Currencies 📋"
+	// BotSetCurrencyRateCommand represents the command to set manual rate of a private currency
+	BotSetCurrencyRateCommand string = "Set Currency Rate 💱"
 
 	// BotCreateCategoryCommand represents the command to create a new category
 	BotCreateCategoryCommand string = "Create Category ✨"
@@ -48,6 +64,34 @@ const (
 	BotUpdateCategoryCommand string = "Update Category ✏️"
 	// BotDeleteCategoryCommand represents the command to delete a category
 	BotDeleteCategoryCommand string = "Delete Category ❌"
+	// BotMergeCategoriesCommand represents the command to merge two categories
+	BotMergeCategoriesCommand string = "Merge Categories 🔀"
+	// BotArchiveCategoryCommand represents the command to archive a category
+	BotArchiveCategoryCommand string = "Archive Category 🗄️"
+	// BotUnarchiveCategoryCommand represents the command to unarchive a category
+	BotUnarchiveCategoryCommand string = "Unarchive Category 📤"
+	// BotReorderCategoriesCommand represents the command to change the position of a category in the list
+	BotReorderCategoriesCommand string = "Reorder Categories ↕️"
+	// BotCreateCategoryRuleCommand represents the command to create a rule for automatic categorization
+	BotCreateCategoryRuleCommand string = "Create Rule ⚙️"
+	// BotListCategoryRulesCommand represents the command to list all category rules
+	BotListCategoryRulesCommand string = "List Rules 📜"
+	// BotDeleteCategoryRuleCommand represents the command to delete a category rule
+	BotDeleteCategoryRuleCommand string = "Delete Rule 🗑️"
+	// BotApplyCategoryTemplateCommand represents the command to add categories from the predefined template
+	BotApplyCategoryTemplateCommand string = "Category Templates 🧩"
+	// BotWithoutParentCategoryCommand represents the command to create a top-level category without parent
+	BotWithoutParentCategoryCommand string = "Without Parent Category 🚫"
+	// BotUpdateCategoryNameCommand represents the command to update category name
+	BotUpdateCategoryNameCommand string = "Update Category Name 📝"
+	// BotUpdateCategoryKindCommand represents the command to update category kind
+	BotUpdateCategoryKindCommand string = "Update Category Kind 🏷️"
+	// BotIncomeCategoryKindCommand represents the command to choose income category kind
+	BotIncomeCategoryKindCommand string = "Income 🔼"
+	// BotExpenseCategoryKindCommand represents the command to choose expense category kind
+	BotExpenseCategoryKindCommand string = "Expense 🔻"
+	// BotIncomeAndExpenseCategoryKindCommand represents the command to choose category kind used for both income and expense
+	BotIncomeAndExpenseCategoryKindCommand string = "Income & Expense 🔄"
 
 	// BotCreateOperationCommand represents the command to create a new operation
 	BotCreateOperationCommand string = "Create Operation 🤔"
@@ -71,6 +115,8 @@ const (
 	BotUpdateOperationDateCommand string = "Update Date 📅"
 	// BotUpdateOperationCategoryCommand represents the command to update operation category
 	BotUpdateOperationCategoryCommand string = "Update Category 🏷️"
+	// BotCreateCategoryRuleFromOperationCommand represents the command to create a category rule from the operation
+	BotCreateCategoryRuleFromOperationCommand string = "Create Rule From Operation ⚙️"
 
 	// BotCreateBalanceSubscriptionCommand represents the command to create a balance subscription
 	BotCreateBalanceSubscriptionCommand string = "Create Balance Subscription 📈"
@@ -86,8 +132,18 @@ const (
 	BotUpdateBalanceSubscriptionCategoryCommand string = "Update Balance Subscription Category 🏷️"
 	// BotUpdateBalanceSubscriptionPeriodCommand represents the command to update balance subscription period
 	BotUpdateBalanceSubscriptionPeriodCommand string = "Update Balance Subscription Period 📅"
+	// BotUpdateBalanceSubscriptionNotificationLeadTimeCommand represents the command to update balance subscription notification lead time
+	BotUpdateBalanceSubscriptionNotificationLeadTimeCommand string = "Update Balance Subscription Reminder ⏰"
+	// BotUpdateBalanceSubscriptionCurrencyCommand represents the command to update balance subscription currency
+	BotUpdateBalanceSubscriptionCurrencyCommand string = "Update Balance Subscription Currency 💱"
 	// BotDeleteBalanceSubscriptionCommand represents the command to delete a balance subscription
 	BotDeleteBalanceSubscriptionCommand string = "Delete Balance Subscription 🗑️"
+	// BotDetectRecurringPaymentsCommand represents the command to detect recurring payments that could be subscriptions
+	BotDetectRecurringPaymentsCommand string = "Detect Recurring Payments 🔍"
+	// BotGetBalanceSubscriptionsSummaryCommand represents the command to get balance subscriptions cost summary
+	BotGetBalanceSubscriptionsSummaryCommand string = "Subscriptions Summary 📊"
+	// BotExportBalanceSubscriptionsCalendarCommand represents the command to export upcoming subscription payments to calendar
+	BotExportBalanceSubscriptionsCalendarCommand string = "Export Payments Calendar 📆"
 
 	// BotPreviousCommand represents the command to go back to the previous page
 	BotPreviousCommand string = "Previous ⬅️"
@@ -116,8 +172,30 @@ var AvailableCommands = []string{
 	BotUpdateOperationAmountCommand, BotUpdateOperationDescriptionCommand, BotUpdateOperationDateCommand, BotUpdateOperationCategoryCommand,
 	BotCreateBalanceSubscriptionCommand, BotListBalanceSubscriptionsCommand, BotDeleteBalanceSubscriptionCommand, BotUpdateBalanceSubscriptionCommand,
 	BotUpdateBalanceSubscriptionNameCommand, BotUpdateBalanceSubscriptionCategoryCommand, BotUpdateBalanceSubscriptionAmountCommand, BotUpdateBalanceSubscriptionPeriodCommand,
+	BotUpdateUserSubscriptionNotificationLeadTimeCommand, BotUpdateBalanceSubscriptionNotificationLeadTimeCommand, BotDetectRecurringPaymentsCommand,
+	BotGetBalanceSubscriptionsSummaryCommand, BotExportBalanceSubscriptionsCalendarCommand, BotUpdateBalanceSubscriptionCurrencyCommand,
+	BotCreateCurrencyCommand, BotListCurrenciesCommand, BotSetCurrencyRateCommand, BotUpdateUserBaseCurrencyCommand,
+	BotGetNetWorthCommand, BotWithoutParentCategoryCommand, BotUpdateCategoryNameCommand, BotUpdateCategoryKindCommand,
+	BotIncomeCategoryKindCommand, BotExpenseCategoryKindCommand, BotIncomeAndExpenseCategoryKindCommand, BotMergeCategoriesCommand,
+	BotCreateCategoryRuleCommand, BotListCategoryRulesCommand, BotDeleteCategoryRuleCommand, BotCreateCategoryRuleFromOperationCommand,
+	BotArchiveCategoryCommand, BotUnarchiveCategoryCommand, BotReorderCategoriesCommand, BotApplyCategoryTemplateCommand,
+	BotCompareBalancePeriodsCommand, BotUpdateUserDigestCommand,
 }
 
+// Callback data prefixes for inline buttons that are attached to notifications sent outside of any flow.
+// The prefix is followed by the ID of the entity the action is related to.
+const (
+	// SkipScheduledOperationCallbackPrefix represents the callback data prefix for skipping a scheduled subscription payment
+	SkipScheduledOperationCallbackPrefix string = "skip_scheduled_operation:"
+	// SnoozeScheduledOperationCallbackPrefix represents the callback data prefix for snoozing a subscription payment reminder
+	SnoozeScheduledOperationCallbackPrefix string = "snooze_scheduled_operation:"
+	// CancelBalanceSubscriptionCallbackPrefix represents the callback data prefix for canceling a subscription from the trial end reminder
+	CancelBalanceSubscriptionCallbackPrefix string = "cancel_balance_subscription:"
+	// BalanceStatisticsChartCallbackPrefix represents the callback data prefix for drawing charts of balance statistics,
+	// the prefix is followed by the balance ID and the period ID separated by colon.
+	BalanceStatisticsChartCallbackPrefix string = "chart:"
+)
+
 // CommandToEvent maps bot commands to their corresponding events
 var CommandToEvent = map[string]Event{
 	// General
@@ -137,16 +215,28 @@ var CommandToEvent = map[string]Event{
 	BotUpdateUserSettingsCommand: UpdateUserSettingsEvent,
 
 	// Balance
-	BotCreateBalanceCommand: CreateBalanceEvent,
-	BotUpdateBalanceCommand: UpdateBalanceEvent,
-	BotGetBalanceCommand:    GetBalanceEvent,
-	BotDeleteBalanceCommand: DeleteBalanceEvent,
+	BotCreateBalanceCommand:         CreateBalanceEvent,
+	BotUpdateBalanceCommand:         UpdateBalanceEvent,
+	BotGetBalanceCommand:            GetBalanceEvent,
+	BotDeleteBalanceCommand:         DeleteBalanceEvent,
+	BotGetNetWorthCommand:           GetNetWorthEvent,
+	BotCompareBalancePeriodsCommand: CompareBalancePeriodsEvent,
+
+	// Currency
+	BotCreateCurrencyCommand:  CreateCurrencyEvent,
+	BotListCurrenciesCommand:  ListCurrenciesEvent,
+	BotSetCurrencyRateCommand: SetCurrencyRateEvent,
 
 	// Category
-	BotCreateCategoryCommand: CreateCategoryEvent,
-	BotListCategoriesCommand: ListCategoriesEvent,
-	BotUpdateCategoryCommand: UpdateCategoryEvent,
-	BotDeleteCategoryCommand: DeleteCategoryEvent,
+	BotCreateCategoryCommand:        CreateCategoryEvent,
+	BotListCategoriesCommand:        ListCategoriesEvent,
+	BotUpdateCategoryCommand:        UpdateCategoryEvent,
+	BotDeleteCategoryCommand:        DeleteCategoryEvent,
+	BotMergeCategoriesCommand:       MergeCategoriesEvent,
+	BotCreateCategoryRuleCommand:    CreateCategoryRuleEvent,
+	BotListCategoryRulesCommand:     ListCategoryRulesEvent,
+	BotDeleteCategoryRuleCommand:    DeleteCategoryRuleEvent,
+	BotApplyCategoryTemplateCommand: ApplyCategoryTemplateEvent,
 
 	// Operation
 	BotCreateOperationCommand: CreateOperationEvent,
@@ -155,10 +245,13 @@ var CommandToEvent = map[string]Event{
 	BotUpdateOperationCommand: UpdateOperationEvent,
 
 	// Balance Subscriptions
-	BotCreateBalanceSubscriptionCommand: CreateBalanceSubscriptionEvent,
-	BotListBalanceSubscriptionsCommand:  ListBalanceSubscriptionEvent,
-	BotUpdateBalanceSubscriptionCommand: UpdateBalanceSubscriptionEvent,
-	BotDeleteBalanceSubscriptionCommand: DeleteBalanceSubscriptionEvent,
+	BotCreateBalanceSubscriptionCommand:          CreateBalanceSubscriptionEvent,
+	BotListBalanceSubscriptionsCommand:           ListBalanceSubscriptionEvent,
+	BotUpdateBalanceSubscriptionCommand:          UpdateBalanceSubscriptionEvent,
+	BotDeleteBalanceSubscriptionCommand:          DeleteBalanceSubscriptionEvent,
+	BotDetectRecurringPaymentsCommand:            DetectRecurringPaymentsEvent,
+	BotGetBalanceSubscriptionsSummaryCommand:     GetBalanceSubscriptionsSummaryEvent,
+	BotExportBalanceSubscriptionsCalendarCommand: ExportBalanceSubscriptionsCalendarEvent,
 }
 
 // CommandToFistFlowStep maps commands to their initial flow steps
@@ -168,16 +261,28 @@ var CommandToFistFlowStep = map[string]FlowStep{
 	BotUpdateUserSettingsCommand: UpdateUserSettingsFlowStep,
 
 	// Balance
-	BotCreateBalanceCommand: CreateBalanceFlowStep,
-	BotUpdateBalanceCommand: UpdateBalanceFlowStep,
-	BotGetBalanceCommand:    GetBalanceFlowStep,
-	BotDeleteBalanceCommand: DeleteBalanceFlowStep,
+	BotCreateBalanceCommand:         CreateBalanceFlowStep,
+	BotUpdateBalanceCommand:         UpdateBalanceFlowStep,
+	BotGetBalanceCommand:            GetBalanceFlowStep,
+	BotDeleteBalanceCommand:         DeleteBalanceFlowStep,
+	BotGetNetWorthCommand:           GetNetWorthFlowStep,
+	BotCompareBalancePeriodsCommand: CompareBalancePeriodsFlowStep,
+
+	// Currency
+	BotCreateCurrencyCommand:  CreateCurrencyFlowStep,
+	BotListCurrenciesCommand:  ListCurrenciesFlowStep,
+	BotSetCurrencyRateCommand: SetCurrencyRateFlowStep,
 
 	// Category
-	BotCreateCategoryCommand: CreateCategoryFlowStep,
-	BotListCategoriesCommand: ListCategoriesFlowStep,
-	BotUpdateCategoryCommand: UpdateCategoryFlowStep,
-	BotDeleteCategoryCommand: DeleteCategoryFlowStep,
+	BotCreateCategoryCommand:        CreateCategoryFlowStep,
+	BotListCategoriesCommand:        ListCategoriesFlowStep,
+	BotUpdateCategoryCommand:        UpdateCategoryFlowStep,
+	BotDeleteCategoryCommand:        DeleteCategoryFlowStep,
+	BotMergeCategoriesCommand:       MergeCategoriesFlowStep,
+	BotCreateCategoryRuleCommand:    CreateCategoryRuleFlowStep,
+	BotListCategoryRulesCommand:     ListCategoryRulesFlowStep,
+	BotDeleteCategoryRuleCommand:    DeleteCategoryRuleFlowStep,
+	BotApplyCategoryTemplateCommand: ApplyCategoryTemplateFlowStep,
 
 	// Operation
 	BotCreateOperationCommand: CreateOperationFlowStep,
@@ -186,10 +291,13 @@ var CommandToFistFlowStep = map[string]FlowStep{
 	BotUpdateOperationCommand: UpdateOperationFlowStep,
 
 	// Balance Subscription
-	BotCreateBalanceSubscriptionCommand: CreateBalanceSubscriptionFlowStep,
-	BotListBalanceSubscriptionsCommand:  ListBalanceSubscriptionFlowStep,
-	BotUpdateBalanceSubscriptionCommand: UpdateBalanceSubscriptionFlowStep,
-	BotDeleteBalanceSubscriptionCommand: DeleteBalanceSubscriptionFlowStep,
+	BotCreateBalanceSubscriptionCommand:          CreateBalanceSubscriptionFlowStep,
+	BotListBalanceSubscriptionsCommand:           ListBalanceSubscriptionFlowStep,
+	BotUpdateBalanceSubscriptionCommand:          UpdateBalanceSubscriptionFlowStep,
+	BotDeleteBalanceSubscriptionCommand:          DeleteBalanceSubscriptionFlowStep,
+	BotDetectRecurringPaymentsCommand:            DetectRecurringPaymentsFlowStep,
+	BotGetBalanceSubscriptionsSummaryCommand:     GetBalanceSubscriptionsSummaryFlowStep,
+	BotExportBalanceSubscriptionsCalendarCommand: ExportBalanceSubscriptionsCalendarFlowStep,
 }
 
 // OperationCommandToOperationType maps operation commands to their corresponding operation types
@@ -1,6 +1,15 @@
 package model
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/pkg/money"
+)
+
+// MaxCurrencyDecimalPlaces represents the max number of decimal places allowed for user-defined currencies.
+const MaxCurrencyDecimalPlaces = 8
 
 // Currency represents currency model which contains currency name, code and symbol
 type Currency struct {
@@ -8,6 +17,15 @@ type Currency struct {
 	Name   string `db:"name"`
 	Code   string `db:"code"`
 	Symbol string `db:"symbol"`
+
+	// UserID is set only for private currencies, which are created by the user and available only to them.
+	// Public currencies have empty UserID.
+	UserID string `db:"user_id"`
+	// DecimalPlaces represents the minor units of the currency, e.g. 0 for JPY, 3 for BHD or 8 for BTC.
+	DecimalPlaces int `db:"decimal_places"`
+	// Deprecated is set for public currencies that are no longer provided by the currency exchanger.
+	// They're kept, so balances that use them still work, but they can't be chosen for new ones.
+	Deprecated bool `db:"deprecated"`
 }
 
 // GetID returns the currency data
@@ -19,3 +37,191 @@ func (c Currency) GetID() string {
 func (c Currency) GetName() string {
 	return fmt.Sprintf("%s (%s)", c.Name, c.Code)
 }
+
+// GetMoneyFormat returns the format used to render amounts in the currency.
+// Minor units are taken from the stored currency, the currency that wasn't loaded from the store uses the default ones,
+// so amounts are never rounded to zero places by mistake.
+func (c Currency) GetMoneyFormat() money.Format {
+	decimalPlaces := money.DefaultDecimalPlaces
+	if c.ID != "" {
+		decimalPlaces = int32(c.DecimalPlaces)
+	}
+
+	return money.NewFormat(c.Code, c.Symbol, decimalPlaces)
+}
+
+// StringifyAmount returns the amount rounded to the currency minor units in the format it's stored,
+// so amounts of currencies with more than 2 decimal places, e.g. KWD or BTC, don't lose precision.
+func (c Currency) StringifyAmount(amount money.Money) string {
+	return amount.StringFixedPlaces(c.GetMoneyFormat().DecimalPlaces)
+}
+
+// IsPrivate checks if the currency was created by the user.
+func (c Currency) IsPrivate() bool {
+	return c.UserID != ""
+}
+
+// NormalizeCurrencyCode returns the currency code in the format it's stored.
+func NormalizeCurrencyCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// IsValidCurrencyCode checks if the code could be used for the user-defined currency.
+// Code should contain from 2 to 10 latin letters or digits.
+func IsValidCurrencyCode(code string) bool {
+	if len(code) < 2 || len(code) > 10 {
+		return false
+	}
+
+	for _, r := range code {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CurrencyRate represents the manual exchange rate of the private currency.
+// The rate means that 1 unit of the private currency equals to Rate units of the target currency
+// starting from the effective date until the next rate to the same target currency is set.
+type CurrencyRate struct {
+	ID             string    `db:"id"`
+	CurrencyID     string    `db:"currency_id"`
+	TargetCurrency string    `db:"target_currency"`
+	Rate           string    `db:"rate"`
+	EffectiveDate  time.Time `db:"effective_date"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// GetCurrencyRateForDate returns the manual rate to the target currency that is used to convert the private currency on the provided date.
+// It's the rate with the latest effective date on or before the date. Returns false when no rate to the target currency was set by that date.
+func GetCurrencyRateForDate(rates []CurrencyRate, targetCurrency string, date time.Time) (CurrencyRate, bool) {
+	var (
+		rate  CurrencyRate
+		found bool
+	)
+	for _, currencyRate := range rates {
+		if currencyRate.TargetCurrency != targetCurrency || currencyRate.EffectiveDate.After(date) {
+			continue
+		}
+		if !found || currencyRate.EffectiveDate.After(rate.EffectiveDate) {
+			rate, found = currencyRate, true
+		}
+	}
+
+	return rate, found
+}
+
+// GetLatestCurrencyRates returns the latest rate for each target currency, keeping the order in which target currencies appear.
+func GetLatestCurrencyRates(rates []CurrencyRate) []CurrencyRate {
+	latestRates := make([]CurrencyRate, 0, len(rates))
+	positions := make(map[string]int, len(rates))
+	for _, rate := range rates {
+		position, ok := positions[rate.TargetCurrency]
+		if !ok {
+			positions[rate.TargetCurrency] = len(latestRates)
+			latestRates = append(latestRates, rate)
+			continue
+		}
+		if !rate.EffectiveDate.Before(latestRates[position].EffectiveDate) {
+			latestRates[position] = rate
+		}
+	}
+
+	return latestRates
+}
+
+// BuildPrivateCurrenciesMessage returns the list of private currencies with their manual rates in string format.
+func BuildPrivateCurrenciesMessage(currencies []Currency, ratesByCurrencyID map[string][]CurrencyRate) string {
+	var buffer strings.Builder
+	buffer.WriteString("🪙 Your Currencies:\n")
+
+	for _, currency := range currencies {
+		buffer.WriteString(fmt.Sprintf(
+			"\n%s, symbol: %s, decimal places: %d\n",
+			currency.GetName(), currency.Symbol, currency.DecimalPlaces,
+		))
+
+		rates := GetLatestCurrencyRates(ratesByCurrencyID[currency.ID])
+		if len(rates) == 0 {
+			buffer.WriteString("	No rates set, amounts in this currency can't be converted.\n")
+		}
+		for _, rate := range rates {
+			buffer.WriteString(fmt.Sprintf(
+				"	- 1 %s = %s %s (since %s)\n", currency.Code, rate.Rate, rate.TargetCurrency, rate.EffectiveDate.Format("02 Jan 2006"),
+			))
+		}
+	}
+
+	return buffer.String()
+}
+
+// CurrencyCatalogChanges represents the difference between stored public currencies and the ones provided by the currency exchanger.
+type CurrencyCatalogChanges struct {
+	Created    []Currency
+	Updated    []Currency
+	Deprecated []Currency
+}
+
+// IsEmpty checks if the catalog doesn't have any changes.
+func (c CurrencyCatalogChanges) IsEmpty() bool {
+	return len(c.Created) == 0 && len(c.Updated) == 0 && len(c.Deprecated) == 0
+}
+
+// GetCurrencyCatalogChanges compares stored public currencies with the provided ones.
+// Provided currencies are expected to have only name, code, symbol and decimal places, the rest of the fields are taken from the stored currency.
+// Deprecated currencies that are provided again are restored and returned as updated ones.
+func GetCurrencyCatalogChanges(stored, provided []Currency) CurrencyCatalogChanges {
+	var changes CurrencyCatalogChanges
+
+	storedByCode := make(map[string]Currency, len(stored))
+	for _, currency := range stored {
+		storedByCode[currency.Code] = currency
+	}
+
+	providedCodes := make(map[string]struct{}, len(provided))
+	for _, currency := range provided {
+		providedCodes[currency.Code] = struct{}{}
+
+		storedCurrency, ok := storedByCode[currency.Code]
+		if !ok {
+			changes.Created = append(changes.Created, currency)
+			continue
+		}
+
+		if storedCurrency.Name == currency.Name && storedCurrency.Symbol == currency.Symbol &&
+			storedCurrency.DecimalPlaces == currency.DecimalPlaces && !storedCurrency.Deprecated {
+			continue
+		}
+
+		storedCurrency.Name = currency.Name
+		storedCurrency.Symbol = currency.Symbol
+		storedCurrency.DecimalPlaces = currency.DecimalPlaces
+		storedCurrency.Deprecated = false
+		changes.Updated = append(changes.Updated, storedCurrency)
+	}
+
+	for _, currency := range stored {
+		if _, ok := providedCodes[currency.Code]; ok || currency.Deprecated {
+			continue
+		}
+
+		currency.Deprecated = true
+		changes.Deprecated = append(changes.Deprecated, currency)
+	}
+
+	return changes
+}
+
+// GetCurrencyCodes returns codes of the currencies.
+func GetCurrencyCodes(currencies []Currency) []string {
+	codes := make([]string, 0, len(currencies))
+	for _, currency := range currencies {
+		codes = append(codes, currency.Code)
+	}
+
+	return codes
+}
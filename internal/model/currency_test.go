@@ -0,0 +1,269 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/VladPetriv/finance_bot/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidCurrencyCode(t *testing.T) {
+	t.Parallel()
+
+	testCases := [...]struct {
+		desc     string
+		code     string
+		expected bool
+	}{
+		{
+			desc:     "valid code with letters",
+			code:     model.NormalizeCurrencyCode(" miles "),
+			expected: true,
+		},
+		{
+			desc:     "valid code with letters and digits",
+			code:     "USDT2",
+			expected: true,
+		},
+		{
+			desc:     "too short code",
+			code:     "B",
+			expected: false,
+		},
+		{
+			desc:     "too long code",
+			code:     "BONUSPOINTS",
+			expected: false,
+		},
+		{
+			desc:     "code with lowercase letters",
+			code:     "btc",
+			expected: false,
+		},
+		{
+			desc:     "code with special characters",
+			code:     "BT-C",
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, model.IsValidCurrencyCode(tc.code))
+		})
+	}
+}
+
+func TestCurrency_GetMoneyFormat(t *testing.T) {
+	t.Parallel()
+
+	testCases := [...]struct {
+		desc     string
+		currency model.Currency
+		expected money.Format
+	}{
+		{
+			desc:     "public currency with default minor units",
+			currency: model.Currency{ID: "uah_id", Code: "UAH", Symbol: "₴", DecimalPlaces: 2},
+			expected: money.Format{Symbol: "₴", DecimalPlaces: 2},
+		},
+		{
+			desc:     "public currency without minor units",
+			currency: model.Currency{ID: "jpy_id", Code: "JPY", Symbol: "¥", DecimalPlaces: 0},
+			expected: money.Format{Symbol: "¥", DecimalPlaces: 0, SymbolBefore: true},
+		},
+		{
+			desc:     "currency not loaded from the store uses default minor units",
+			currency: model.Currency{Code: "JPY", Symbol: "¥"},
+			expected: money.Format{Symbol: "¥", DecimalPlaces: 2, SymbolBefore: true},
+		},
+		{
+			desc:     "private currency uses its own minor units",
+			currency: model.Currency{ID: "miles_id", Code: "MILES", Symbol: "✈️", UserID: "user_id", DecimalPlaces: 0},
+			expected: money.Format{Symbol: "✈️", DecimalPlaces: 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.currency.GetMoneyFormat())
+		})
+	}
+}
+
+func TestCurrency_StringifyAmount(t *testing.T) {
+	t.Parallel()
+
+	testCases := [...]struct {
+		desc     string
+		currency model.Currency
+		amount   money.Money
+		expected string
+	}{
+		{
+			desc:     "currency with default minor units",
+			currency: model.Currency{ID: "usd_id", Code: "USD", Symbol: "$", DecimalPlaces: 2},
+			amount:   money.NewFromFloat(10.129),
+			expected: "10.13",
+		},
+		{
+			desc:     "currency with 3 minor units",
+			currency: model.Currency{ID: "kwd_id", Code: "KWD", Symbol: "KD", DecimalPlaces: 3},
+			amount:   money.NewFromFloat(1.235),
+			expected: "1.235",
+		},
+		{
+			desc:     "currency with 8 minor units",
+			currency: model.Currency{ID: "btc_id", Code: "BTC", Symbol: "₿", DecimalPlaces: 8},
+			amount:   money.NewFromFloat(0.00012345),
+			expected: "0.00012345",
+		},
+		{
+			desc:     "private currency rounded to its own minor units",
+			currency: model.Currency{ID: "sats_id", Code: "SATS", Symbol: "sats", UserID: "user_id", DecimalPlaces: 0},
+			amount:   money.NewFromFloat(1500.6),
+			expected: "1501",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.currency.StringifyAmount(tc.amount))
+		})
+	}
+}
+
+func TestGetCurrencyRateForDate(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	rates := []model.CurrencyRate{
+		{ID: "usd_rate", TargetCurrency: "USD", EffectiveDate: date.AddDate(0, 0, -10)},
+		{ID: "eur_rate", TargetCurrency: "EUR", EffectiveDate: date.AddDate(0, 0, -5)},
+		{ID: "updated_usd_rate", TargetCurrency: "USD", EffectiveDate: date},
+		{ID: "next_usd_rate", TargetCurrency: "USD", EffectiveDate: date.AddDate(0, 0, 1)},
+	}
+
+	testCases := [...]struct {
+		desc           string
+		targetCurrency string
+		date           time.Time
+		expectedID     string
+		expectedFound  bool
+	}{
+		{
+			desc:           "rate set on the date is used for that date",
+			targetCurrency: "USD",
+			date:           date,
+			expectedID:     "updated_usd_rate",
+			expectedFound:  true,
+		},
+		{
+			desc:           "rate set before the update is used for earlier dates",
+			targetCurrency: "USD",
+			date:           date.AddDate(0, 0, -1),
+			expectedID:     "usd_rate",
+			expectedFound:  true,
+		},
+		{
+			desc:           "latest rate is used for later dates",
+			targetCurrency: "USD",
+			date:           date.AddDate(0, 1, 0),
+			expectedID:     "next_usd_rate",
+			expectedFound:  true,
+		},
+		{
+			desc:           "rate to the target currency set after the date is ignored",
+			targetCurrency: "EUR",
+			date:           date.AddDate(0, 0, -7),
+			expectedFound:  false,
+		},
+		{
+			desc:           "rate to other target currency is not used",
+			targetCurrency: "GBP",
+			date:           date,
+			expectedFound:  false,
+		},
+		{
+			desc:           "no rates set by the date",
+			targetCurrency: "USD",
+			date:           date.AddDate(0, 0, -11),
+			expectedFound:  false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual, found := model.GetCurrencyRateForDate(rates, tc.targetCurrency, tc.date)
+			assert.Equal(t, tc.expectedFound, found)
+			assert.Equal(t, tc.expectedID, actual.ID)
+		})
+	}
+}
+
+func TestGetLatestCurrencyRates(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	rates := []model.CurrencyRate{
+		{ID: "usd_rate", TargetCurrency: "USD", EffectiveDate: date.AddDate(0, 0, -10)},
+		{ID: "eur_rate", TargetCurrency: "EUR", EffectiveDate: date.AddDate(0, 0, -5)},
+		{ID: "updated_usd_rate", TargetCurrency: "USD", EffectiveDate: date},
+	}
+
+	actual := model.GetLatestCurrencyRates(rates)
+
+	ids := make([]string, 0, len(actual))
+	for _, rate := range actual {
+		ids = append(ids, rate.ID)
+	}
+	assert.Equal(t, []string{"updated_usd_rate", "eur_rate"}, ids)
+}
+
+func TestGetCurrencyCatalogChanges(t *testing.T) {
+	t.Parallel()
+
+	stored := []model.Currency{
+		{ID: "usd_id", Name: "US Dollar", Code: "USD", Symbol: "$"},
+		{ID: "uah_id", Name: "Hryvnia", Code: "UAH", Symbol: "₴"},
+		{ID: "eur_id", Name: "Euro", Code: "EUR", Symbol: "€"},
+		{ID: "byr_id", Name: "Belarusian Ruble", Code: "BYR", Symbol: "Br", Deprecated: true},
+		{ID: "gbp_id", Name: "Pound Sterling", Code: "GBP", Symbol: "£", Deprecated: true},
+		{ID: "kwd_id", Name: "Kuwaiti Dinar", Code: "KWD", Symbol: "KD", DecimalPlaces: 2},
+	}
+	provided := []model.Currency{
+		{Name: "US Dollar", Code: "USD", Symbol: "$"},
+		{Name: "Kuwaiti Dinar", Code: "KWD", Symbol: "KD", DecimalPlaces: 3},
+		{Name: "Ukrainian Hryvnia", Code: "UAH", Symbol: "₴"},
+		{Name: "Pound Sterling", Code: "GBP", Symbol: "£"},
+		{Name: "Swiss Franc", Code: "CHF", Symbol: "CHF"},
+	}
+
+	expected := model.CurrencyCatalogChanges{
+		Created: []model.Currency{
+			{Name: "Swiss Franc", Code: "CHF", Symbol: "CHF"},
+		},
+		Updated: []model.Currency{
+			{ID: "kwd_id", Name: "Kuwaiti Dinar", Code: "KWD", Symbol: "KD", DecimalPlaces: 3},
+			{ID: "uah_id", Name: "Ukrainian Hryvnia", Code: "UAH", Symbol: "₴"},
+			{ID: "gbp_id", Name: "Pound Sterling", Code: "GBP", Symbol: "£"},
+		},
+		Deprecated: []model.Currency{
+			{ID: "eur_id", Name: "Euro", Code: "EUR", Symbol: "€", Deprecated: true},
+		},
+	}
+
+	actual := model.GetCurrencyCatalogChanges(stored, provided)
+	assert.Equal(t, expected, actual)
+	assert.False(t, actual.IsEmpty())
+	assert.True(t, model.GetCurrencyCatalogChanges(stored[:2], stored[:2]).IsEmpty())
+}
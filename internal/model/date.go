@@ -62,47 +62,6 @@ var monthToMonthIndex = map[Month]int{
 	MonthDecember:  12,
 }
 
-// GetTimeRange returns the start and end time of the month.
-// If the current time is in the same month, the start time is the current time and the end time is the end of the day.
-func (m Month) GetTimeRange(currentTime time.Time) (time.Time, time.Time) {
-	selectedMonth := time.Month(m.GetIndex())
-
-	if currentTime.Month() == selectedMonth {
-		startTime := time.Date(currentTime.Year(), selectedMonth, 1, 0, 0, 0, 0, time.UTC)
-		endTime := time.Date(
-			currentTime.Year(),
-			selectedMonth,
-			currentTime.Day(),
-			currentTime.Hour(),
-			currentTime.Minute(),
-			currentTime.Second(),
-			0,
-			time.UTC,
-		)
-
-		return startTime, endTime
-	}
-
-	startTime := time.Date(currentTime.Year(), selectedMonth, 1, 0, 0, 0, 0, time.UTC)
-
-	// Get the first day of next month
-	nextMonth := selectedMonth + 1
-	nextYear := currentTime.Year()
-
-	// Handle December -> January transition
-	if nextMonth > 12 {
-		nextMonth = 1
-		nextYear++
-	}
-
-	// Set endTime to the last day of the month (by taking first day of next month and subtracting 1 day)
-	firstDayOfNextMonth := time.Date(nextYear, nextMonth, 1, 0, 0, 0, 0, time.UTC)
-	endTime := firstDayOfNextMonth.AddDate(0, 0, -1)
-	endTime = time.Date(endTime.Year(), endTime.Month(), endTime.Day(), 23, 59, 59, 0, time.UTC)
-
-	return startTime, endTime
-}
-
 // Months represents an array of all months
 var Months = []Month{
 	MonthJanuary, MonthFebruary, MonthMarch,
@@ -149,20 +108,3 @@ func (c CreationPeriod) CalculateTimeRange() (time.Time, time.Time) {
 
 	return startTime, endTime
 }
-
-// GetCreationPeriodFromText checks if the input text matches any of the CreationPeriod enums.
-// If there is no match, it returns nil. Otherwise, it returns the corresponding CreationPeriod type.
-func GetCreationPeriodFromText(value string) CreationPeriod {
-	switch value {
-	case string(CreationPeriodDay):
-		return CreationPeriodDay
-	case string(CreationPeriodWeek):
-		return CreationPeriodWeek
-	case string(CreationPeriodMonth):
-		return CreationPeriodMonth
-	case string(CreationPeriodYear):
-		return CreationPeriodYear
-	default:
-		return ""
-	}
-}
@@ -79,70 +79,6 @@ func TestMonth_GetIndex(t *testing.T) {
 	}
 }
 
-func TestMonth_GetTimeRange(t *testing.T) {
-	t.Parallel()
-
-	fixedTime := time.Date(2024, 3, 15, 14, 30, 0, 0, time.UTC)
-
-	testCases := [...]struct {
-		desc        string
-		month       Month
-		currentTime time.Time
-		expected    struct {
-			start time.Time
-			end   time.Time
-		}
-	}{
-		{
-			desc:        "positive: get time range for current month",
-			month:       MonthMarch,
-			currentTime: fixedTime,
-			expected: struct {
-				start time.Time
-				end   time.Time
-			}{
-				start: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
-				end:   time.Date(2024, 3, 15, 14, 30, 0, 0, time.UTC),
-			},
-		},
-		{
-			desc:        "positive: get time range for different month",
-			month:       MonthJanuary,
-			currentTime: fixedTime,
-			expected: struct {
-				start time.Time
-				end   time.Time
-			}{
-				start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-				end:   time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC),
-			},
-		},
-		{
-			desc:        "positive: get time range for December with year transition",
-			month:       MonthDecember,
-			currentTime: fixedTime,
-			expected: struct {
-				start time.Time
-				end   time.Time
-			}{
-				start: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
-				end:   time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC),
-			},
-		},
-	}
-
-	for _, tc := range testCases {
-		tc := tc
-		t.Run(tc.desc, func(t *testing.T) {
-			t.Parallel()
-
-			start, end := tc.month.GetTimeRange(tc.currentTime)
-			assert.Equal(t, tc.expected.start, start)
-			assert.Equal(t, tc.expected.end, end)
-		})
-	}
-}
-
 func TestCreationPeriod_CalculateTimeRange(t *testing.T) {
 	t.Parallel()
 
@@ -204,49 +140,3 @@ func TestCreationPeriod_CalculateTimeRange(t *testing.T) {
 		})
 	}
 }
-
-func TestGetCreationPeriodFromText(t *testing.T) {
-	t.Parallel()
-
-	testCases := [...]struct {
-		desc     string
-		text     string
-		expected CreationPeriod
-	}{
-		{
-			desc:     "positive: get day period",
-			text:     "day",
-			expected: CreationPeriodDay,
-		},
-		{
-			desc:     "positive: get week period",
-			text:     "week",
-			expected: CreationPeriodWeek,
-		},
-		{
-			desc:     "positive: get month period",
-			text:     "month",
-			expected: CreationPeriodMonth,
-		},
-		{
-			desc:     "positive: get year period",
-			text:     "year",
-			expected: CreationPeriodYear,
-		},
-		{
-			desc:     "negative: invalid period",
-			text:     "invalid",
-			expected: "",
-		},
-	}
-
-	for _, tc := range testCases {
-		tc := tc
-		t.Run(tc.desc, func(t *testing.T) {
-			t.Parallel()
-
-			actual := GetCreationPeriodFromText(tc.text)
-			assert.Equal(t, tc.expected, actual)
-		})
-	}
-}
@@ -0,0 +1,273 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/pkg/money"
+)
+
+// DigestFrequency represents how often the user receives the digest with the summary of his finances.
+type DigestFrequency string
+
+const (
+	// DigestFrequencyOff represents disabled digest.
+	DigestFrequencyOff DigestFrequency = "off"
+	// DigestFrequencyWeekly represents the digest for the previous week, which is sent on Mondays.
+	DigestFrequencyWeekly DigestFrequency = "weekly"
+	// DigestFrequencyMonthly represents the digest for the previous month, which is sent on the first day of the month.
+	DigestFrequencyMonthly DigestFrequency = "monthly"
+)
+
+// AvailableDigestFrequencies is a list of digest frequencies that user can choose from.
+var AvailableDigestFrequencies = []DigestFrequency{
+	DigestFrequencyOff,
+	DigestFrequencyWeekly,
+	DigestFrequencyMonthly,
+}
+
+// String returns the human readable label of the digest frequency.
+func (d DigestFrequency) String() string {
+	switch d {
+	case DigestFrequencyWeekly:
+		return "Weekly (on Mondays)"
+	case DigestFrequencyMonthly:
+		return "Monthly (on the 1st)"
+	default:
+		return "Off"
+	}
+}
+
+// ParseDigestFrequency parses a digest frequency from its label.
+func ParseDigestFrequency(value string) (DigestFrequency, error) {
+	for _, frequency := range AvailableDigestFrequencies {
+		if frequency.String() == value {
+			return frequency, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid digest frequency: %s", value)
+}
+
+// GetUpcomingChargesPeriodInDays returns the number of days ahead for which upcoming subscription charges are shown,
+// it covers the time until the next digest.
+func (d DigestFrequency) GetUpcomingChargesPeriodInDays() int {
+	if d == DigestFrequencyWeekly {
+		return 7
+	}
+
+	return UpcomingChargesPeriodInDays
+}
+
+// DigestSendTime represents the hour of the day in UTC when the digest is sent.
+type DigestSendTime int
+
+// DefaultDigestSendTime represents the send time that is used when user didn't change it.
+const DefaultDigestSendTime DigestSendTime = 9
+
+// AvailableDigestSendTimes is a list of send times that user can choose from.
+var AvailableDigestSendTimes = []DigestSendTime{6, 8, 9, 12, 18, 21}
+
+// String returns the human readable label of the send time.
+func (d DigestSendTime) String() string {
+	return fmt.Sprintf("%02d:00 UTC", int(d))
+}
+
+// ParseDigestSendTime parses a send time from its label.
+func ParseDigestSendTime(value string) (DigestSendTime, error) {
+	for _, sendTime := range AvailableDigestSendTimes {
+		if sendTime.String() == value {
+			return sendTime, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid digest send time: %s", value)
+}
+
+// digestTopOperationsLimit represents the maximum number of the biggest operations shown in the digest.
+const digestTopOperationsLimit = 3
+
+// DigestBalance represents a balance with its operations created during the digest period.
+type DigestBalance struct {
+	// Balance is expected to have preloaded currency.
+	Balance    Balance
+	Operations []Operation
+}
+
+// DigestUpcomingCharge represents a subscription charge expected after the digest is sent,
+// the amount is in the currency in which the subscription is charged.
+type DigestUpcomingCharge struct {
+	UpcomingSubscriptionCharge
+
+	Currency Currency
+}
+
+// Digest contains the data required to build the periodic summary of user finances.
+type Digest struct {
+	Period     Period
+	Frequency  DigestFrequency
+	Balances   []DigestBalance
+	Categories []Category
+
+	UpcomingCharges []DigestUpcomingCharge
+	// UpcomingChargesPeriodInDays represents the number of days ahead for which upcoming charges were collected.
+	UpcomingChargesPeriodInDays int
+}
+
+// BuildMessage returns the digest in markdown format.
+func (d Digest) BuildMessage() (string, error) {
+	var buffer strings.Builder
+
+	title := "🗓️ Weekly Digest"
+	if d.Frequency == DigestFrequencyMonthly {
+		title = "🗓️ Monthly Digest"
+	}
+	buffer.WriteString(fmt.Sprintf("*%s*\n%s\n", title, formatPeriod(d.Period)))
+
+	for _, digestBalance := range d.Balances {
+		balanceMessage, err := d.buildBalanceMessage(digestBalance)
+		if err != nil {
+			return "", fmt.Errorf("build balance message: %w", err)
+		}
+
+		buffer.WriteString(balanceMessage)
+	}
+
+	buffer.WriteString(fmt.Sprintf("\n⏳ Upcoming Charges (next %d days):\n", d.UpcomingChargesPeriodInDays))
+	if len(d.UpcomingCharges) == 0 {
+		buffer.WriteString("	No charges expected.\n")
+	}
+	for _, charge := range d.UpcomingCharges {
+		buffer.WriteString(fmt.Sprintf(
+			"	- %s: %s — %s\n",
+			charge.Date.Format(dateFormat), charge.Name, formatAmount(charge.Amount, charge.Currency),
+		))
+	}
+
+	return buffer.String(), nil
+}
+
+func (d Digest) buildBalanceMessage(digestBalance DigestBalance) (string, error) {
+	var buffer strings.Builder
+
+	currency := digestBalance.Balance.GetCurrency()
+	balanceAmount, _ := money.NewFromString(digestBalance.Balance.Amount)
+	buffer.WriteString(fmt.Sprintf("\n💰 *%s*: %s\n", digestBalance.Balance.Name, formatAmount(balanceAmount, currency)))
+
+	stats, err := calculateOperationsStatistics(digestBalance.Operations)
+	if err != nil {
+		return "", fmt.Errorf("error calculating statistics: %w", err)
+	}
+
+	buffer.WriteString(fmt.Sprintf("📥 Income: %s *(%d)*\n", formatAmount(stats.IncomingTotal, currency), stats.IncomingCount))
+	buffer.WriteString(fmt.Sprintf("💸 Spending: %s *(%d)*\n", formatAmount(stats.SpendingTotal, currency), stats.SpendingCount))
+
+	spendingCategoriesStatistics, err := calculateCategoryStatistics(stats.SpendingTotal, stats.OperationsByType[OperationTypeSpending], d.Categories)
+	if err != nil {
+		return "", fmt.Errorf("error calculating spending categories statistics: %w", err)
+	}
+	for _, category := range spendingCategoriesStatistics {
+		buffer.WriteString(fmt.Sprintf(
+			"	- %s: %s *(%s%%)*\n",
+			category.Title, formatAmount(category.Amount, currency), category.Percentage.StringFixed(),
+		))
+	}
+
+	topOperations, err := getTopOperations(stats.OperationsByType[OperationTypeSpending], digestTopOperationsLimit)
+	if err != nil {
+		return "", fmt.Errorf("error getting top operations: %w", err)
+	}
+	if len(topOperations) > 0 {
+		buffer.WriteString("🔝 Top Spending:\n")
+	}
+	for _, operation := range topOperations {
+		buffer.WriteString(fmt.Sprintf(
+			"	- %s: %s — %s\n",
+			operation.CreatedAt.Format(dateFormat), d.getOperationTitle(operation), formatAmount(operation.amount, currency),
+		))
+	}
+
+	return buffer.String(), nil
+}
+
+// getOperationTitle returns the operation description or the title of its category when description is empty.
+func (d Digest) getOperationTitle(operation topOperation) string {
+	if operation.Description != "" {
+		return operation.Description
+	}
+
+	for _, category := range d.Categories {
+		if category.ID == operation.CategoryID {
+			return category.Title
+		}
+	}
+
+	return "Unknown"
+}
+
+type topOperation struct {
+	Operation
+
+	amount money.Money
+}
+
+// getTopOperations returns up to limit operations with the biggest amount, the earlier operation goes first on equal amounts.
+func getTopOperations(operations []Operation, limit int) ([]topOperation, error) {
+	topOperations := make([]topOperation, 0, len(operations))
+	for _, operation := range operations {
+		amount, err := money.NewFromString(operation.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operation amount: %w", err)
+		}
+
+		topOperations = append(topOperations, topOperation{
+			Operation: operation,
+			amount:    amount,
+		})
+	}
+
+	sort.SliceStable(topOperations, func(i, j int) bool {
+		if topOperations[i].amount.Equal(topOperations[j].amount) {
+			return topOperations[i].CreatedAt.Before(topOperations[j].CreatedAt)
+		}
+
+		return topOperations[i].amount.GreaterThan(topOperations[j].amount)
+	})
+
+	if len(topOperations) > limit {
+		topOperations = topOperations[:limit]
+	}
+
+	return topOperations, nil
+}
+
+// GetDueDigestPeriod returns the period which should be covered by the digest if it's time to send it.
+// Weekly digest covers the previous week and is sent on Monday, monthly digest covers the previous month
+// and is sent on the first day of the month, both of them are sent at the chosen send time in UTC.
+func (u *UserSettings) GetDueDigestPeriod(now time.Time) (Period, bool) {
+	now = now.UTC()
+
+	var period Period
+	switch u.DigestFrequency {
+	case DigestFrequencyWeekly:
+		year, week := now.AddDate(0, 0, -7).ISOWeek()
+		period = NewWeekPeriod(year, week)
+	case DigestFrequencyMonthly:
+		previousMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+		period = NewMonthPeriod(previousMonth.Year(), previousMonth.Month())
+	default:
+		return Period{}, false
+	}
+
+	sendAt := time.Date(period.To.Year(), period.To.Month(), period.To.Day()+1, int(u.DigestSendTime), 0, 0, 0, time.UTC)
+	if now.Before(sendAt) {
+		return Period{}, false
+	}
+	if u.LastDigestSentAt != nil && !u.LastDigestSentAt.Before(sendAt) {
+		return Period{}, false
+	}
+
+	return period, true
+}
@@ -0,0 +1,191 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/VladPetriv/finance_bot/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDigestSendTime(t *testing.T) {
+	t.Parallel()
+
+	sendTime, err := model.ParseDigestSendTime("09:00 UTC")
+	require.NoError(t, err)
+	assert.Equal(t, model.DigestSendTime(9), sendTime)
+
+	_, err = model.ParseDigestSendTime("09:00")
+	assert.Error(t, err)
+}
+
+func TestUserSettings_GetDueDigestPeriod(t *testing.T) {
+	t.Parallel()
+
+	sentAfterPreviousWeek := time.Date(2025, time.January, 6, 9, 30, 0, 0, time.UTC)
+	sentBeforeCurrentWeek := time.Date(2025, time.January, 12, 20, 0, 0, 0, time.UTC)
+
+	testCases := [...]struct {
+		desc           string
+		settings       model.UserSettings
+		now            time.Time
+		expectedDue    bool
+		expectedPeriod string
+	}{
+		{
+			desc: "digest is disabled",
+			settings: model.UserSettings{
+				DigestFrequency: model.DigestFrequencyOff,
+				DigestSendTime:  9,
+			},
+			now: time.Date(2025, time.January, 13, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "weekly digest is due on monday after the send time",
+			settings: model.UserSettings{
+				DigestFrequency:  model.DigestFrequencyWeekly,
+				DigestSendTime:   9,
+				LastDigestSentAt: &sentAfterPreviousWeek,
+			},
+			now:            time.Date(2025, time.January, 13, 9, 0, 0, 0, time.UTC),
+			expectedDue:    true,
+			expectedPeriod: "2025-W02",
+		},
+		{
+			desc: "weekly digest is not due on monday before the send time",
+			settings: model.UserSettings{
+				DigestFrequency:  model.DigestFrequencyWeekly,
+				DigestSendTime:   9,
+				LastDigestSentAt: &sentAfterPreviousWeek,
+			},
+			now: time.Date(2025, time.January, 13, 8, 59, 0, 0, time.UTC),
+		},
+		{
+			desc: "weekly digest is due later in the week when it was not sent on monday",
+			settings: model.UserSettings{
+				DigestFrequency:  model.DigestFrequencyWeekly,
+				DigestSendTime:   9,
+				LastDigestSentAt: &sentBeforeCurrentWeek,
+			},
+			now:            time.Date(2025, time.January, 15, 12, 0, 0, 0, time.UTC),
+			expectedDue:    true,
+			expectedPeriod: "2025-W02",
+		},
+		{
+			desc: "weekly digest was already sent this week",
+			settings: model.UserSettings{
+				DigestFrequency:  model.DigestFrequencyWeekly,
+				DigestSendTime:   9,
+				LastDigestSentAt: &sentAfterPreviousWeek,
+			},
+			now: time.Date(2025, time.January, 12, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "weekly digest is due for the first time",
+			settings: model.UserSettings{
+				DigestFrequency: model.DigestFrequencyWeekly,
+				DigestSendTime:  18,
+			},
+			now:            time.Date(2025, time.January, 5, 18, 0, 0, 0, time.UTC),
+			expectedDue:    true,
+			expectedPeriod: "2024-W52",
+		},
+		{
+			desc: "monthly digest is due on the first day of the month",
+			settings: model.UserSettings{
+				DigestFrequency:  model.DigestFrequencyMonthly,
+				DigestSendTime:   6,
+				LastDigestSentAt: &sentAfterPreviousWeek,
+			},
+			now:            time.Date(2025, time.February, 1, 6, 0, 0, 0, time.UTC),
+			expectedDue:    true,
+			expectedPeriod: "2025-01",
+		},
+		{
+			desc: "monthly digest was already sent this month",
+			settings: model.UserSettings{
+				DigestFrequency:  model.DigestFrequencyMonthly,
+				DigestSendTime:   6,
+				LastDigestSentAt: &sentAfterPreviousWeek,
+			},
+			now: time.Date(2025, time.January, 20, 6, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actualPeriod, actualDue := tc.settings.GetDueDigestPeriod(tc.now)
+			assert.Equal(t, tc.expectedDue, actualDue)
+			assert.Equal(t, tc.expectedPeriod, actualPeriod.ID)
+		})
+	}
+}
+
+func TestDigest_BuildMessage(t *testing.T) {
+	t.Parallel()
+
+	usd := model.Currency{Code: "USD", Symbol: "$"}
+	eur := model.Currency{Code: "EUR", Symbol: "€"}
+
+	digest := model.Digest{
+		Period:    model.NewWeekPeriod(2025, 2),
+		Frequency: model.DigestFrequencyWeekly,
+		Categories: []model.Category{
+			{ID: "food", Title: "Food"},
+			{ID: "restaurants", Title: "Restaurants", ParentID: "food"},
+			{ID: "taxi", Title: "Taxi"},
+			{ID: "salary", Title: "Salary"},
+		},
+		Balances: []model.DigestBalance{
+			{
+				Balance: model.Balance{Name: "Card", Amount: "1200.00", Currency: usd},
+				Operations: []model.Operation{
+					{Type: model.OperationTypeIncoming, Amount: "1000.00", CategoryID: "salary", CreatedAt: time.Date(2025, time.January, 6, 10, 0, 0, 0, time.UTC)},
+					{Type: model.OperationTypeSpending, Amount: "30.00", CategoryID: "taxi", CreatedAt: time.Date(2025, time.January, 7, 10, 0, 0, 0, time.UTC)},
+					{Type: model.OperationTypeSpending, Amount: "50.00", CategoryID: "food", Description: "Groceries", CreatedAt: time.Date(2025, time.January, 8, 10, 0, 0, 0, time.UTC)},
+					{Type: model.OperationTypeSpending, Amount: "20.00", CategoryID: "restaurants", CreatedAt: time.Date(2025, time.January, 9, 10, 0, 0, 0, time.UTC)},
+					{Type: model.OperationTypeSpending, Amount: "10.00", CategoryID: "taxi", CreatedAt: time.Date(2025, time.January, 10, 10, 0, 0, 0, time.UTC)},
+				},
+			},
+			{
+				Balance: model.Balance{Name: "Cash", Amount: "50.00", Currency: eur},
+			},
+		},
+		UpcomingCharges: []model.DigestUpcomingCharge{
+			{
+				UpcomingSubscriptionCharge: model.UpcomingSubscriptionCharge{
+					Name:   "Netflix",
+					Amount: money.NewFromInt(10),
+					Date:   time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC),
+				},
+				Currency: eur,
+			},
+		},
+		UpcomingChargesPeriodInDays: model.DigestFrequencyWeekly.GetUpcomingChargesPeriodInDays(),
+	}
+
+	expected := "*🗓️ Weekly Digest*\n" +
+		"📅 Period: _06 Jan 2025 - 12 Jan 2025_\n" +
+		"\n💰 *Card*: `$1200.00`\n" +
+		"📥 Income: `$1000.00` *(1)*\n" +
+		"💸 Spending: `$110.00` *(4)*\n" +
+		"	- Food: `$70.00` *(63.64%)*\n" +
+		"	- Taxi: `$40.00` *(36.36%)*\n" +
+		"🔝 Top Spending:\n" +
+		"	- 08 Jan 2025: Groceries — `$50.00`\n" +
+		"	- 07 Jan 2025: Taxi — `$30.00`\n" +
+		"	- 09 Jan 2025: Restaurants — `$20.00`\n" +
+		"\n💰 *Cash*: `50.00€`\n" +
+		"📥 Income: `0.00€` *(0)*\n" +
+		"💸 Spending: `0.00€` *(0)*\n" +
+		"\n⏳ Upcoming Charges (next 7 days):\n" +
+		"	- 15 Jan 2025: Netflix — `10.00€`\n"
+
+	actual, err := digest.BuildMessage()
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
@@ -6,6 +6,8 @@ type Event string
 const (
 	// StartEvent represents the event when user starts interacting with the bot
 	StartEvent Event = "start"
+	// OnboardingEvent represents the event for the steps of the start flow which follow the start command
+	OnboardingEvent Event = "start/onboarding"
 	// CancelEvent represents the event when user wants to stop the current floe
 	CancelEvent Event = "cancel"
 	// BackEvent represents the event when user wants to go back to the previous menu
@@ -37,6 +39,17 @@ const (
 	GetBalanceEvent Event = "balance/get"
 	// DeleteBalanceEvent represents the event for deleting a balance
 	DeleteBalanceEvent Event = "balance/delete"
+	// GetNetWorthEvent represents the event for getting net worth across all balances
+	GetNetWorthEvent Event = "balance/net_worth"
+	// CompareBalancePeriodsEvent represents the event for comparing balance statistics of two periods
+	CompareBalancePeriodsEvent Event = "balance/compare_periods"
+
+	// CreateCurrencyEvent represents the event for creating a private currency
+	CreateCurrencyEvent Event = "currency/create"
+	// ListCurrenciesEvent represents the event for listing private currencies
+	ListCurrenciesEvent Event = "currency/list"
+	// SetCurrencyRateEvent represents the event for setting manual rate of a private currency
+	SetCurrencyRateEvent Event = "currency/set_rate"
 
 	// CreateCategoryEvent represents the event for creating a new category
 	CreateCategoryEvent Event = "category/create"
@@ -46,6 +59,16 @@ const (
 	UpdateCategoryEvent Event = "category/update"
 	// DeleteCategoryEvent represents the event for deleting a category
 	DeleteCategoryEvent Event = "category/delete"
+	// MergeCategoriesEvent represents the event for merging two categories
+	MergeCategoriesEvent Event = "category/merge"
+	// CreateCategoryRuleEvent represents the event for creating a rule for automatic categorization
+	CreateCategoryRuleEvent Event = "category/create_rule"
+	// ListCategoryRulesEvent represents the event for listing all category rules
+	ListCategoryRulesEvent Event = "category/list_rules"
+	// DeleteCategoryRuleEvent represents the event for deleting a category rule
+	DeleteCategoryRuleEvent Event = "category/delete_rule"
+	// ApplyCategoryTemplateEvent represents the event for adding categories from the predefined template
+	ApplyCategoryTemplateEvent Event = "category/apply_template"
 
 	// CreateOperationEvent represents the event for creating a new operation
 	CreateOperationEvent Event = "operation/create"
@@ -66,14 +89,30 @@ const (
 	UpdateBalanceSubscriptionEvent Event = "balance_subscription/update"
 	// DeleteBalanceSubscriptionEvent represents the event for deleting a balance subscription
 	DeleteBalanceSubscriptionEvent Event = "balance_subscription/delete"
+	// DetectRecurringPaymentsEvent represents the event for detecting recurring payments that could be subscriptions
+	DetectRecurringPaymentsEvent Event = "balance_subscription/detect_recurring_payments"
+	// GetBalanceSubscriptionsSummaryEvent represents the event for getting balance subscriptions cost summary
+	GetBalanceSubscriptionsSummaryEvent Event = "balance_subscription/summary"
+	// ExportBalanceSubscriptionsCalendarEvent represents the event for exporting upcoming subscription payments to calendar
+	ExportBalanceSubscriptionsCalendarEvent Event = "balance_subscription/export_calendar"
+
+	// SkipScheduledOperationEvent represents the event for skipping a scheduled subscription payment from notification
+	SkipScheduledOperationEvent Event = "scheduled_operation/skip"
+	// SnoozeScheduledOperationEvent represents the event for snoozing a subscription payment notification
+	SnoozeScheduledOperationEvent Event = "scheduled_operation/snooze"
+	// CancelBalanceSubscriptionEvent represents the event for canceling a balance subscription from the trial end reminder
+	CancelBalanceSubscriptionEvent Event = "balance_subscription/cancel"
+	// BalanceStatisticsChartEvent represents the event for drawing charts of balance statistics
+	BalanceStatisticsChartEvent Event = "balance/statistics_chart"
 )
 
 // EventToFlow maps events to their corresponding flows
 var EventToFlow = map[Event]Flow{
 	// General
-	StartEvent:  StartFlow,
-	CancelEvent: CancelFlow,
-	BackEvent:   BackFlow,
+	StartEvent:      StartFlow,
+	OnboardingEvent: StartFlow,
+	CancelEvent:     CancelFlow,
+	BackEvent:       BackFlow,
 
 	// Wrappers
 	BalanceEvent:             BalanceFlow,
@@ -87,16 +126,28 @@ var EventToFlow = map[Event]Flow{
 	UpdateUserSettingsEvent: UpdateUserSettingsFlow,
 
 	// Balance
-	CreateBalanceEvent: CreateBalanceFlow,
-	UpdateBalanceEvent: UpdateBalanceFlow,
-	DeleteBalanceEvent: DeleteBalanceFlow,
-	GetBalanceEvent:    GetBalanceFlow,
+	CreateBalanceEvent:         CreateBalanceFlow,
+	UpdateBalanceEvent:         UpdateBalanceFlow,
+	DeleteBalanceEvent:         DeleteBalanceFlow,
+	GetBalanceEvent:            GetBalanceFlow,
+	GetNetWorthEvent:           GetNetWorthFlow,
+	CompareBalancePeriodsEvent: CompareBalancePeriodsFlow,
+
+	// Currency
+	CreateCurrencyEvent:  CreateCurrencyFlow,
+	ListCurrenciesEvent:  ListCurrenciesFlow,
+	SetCurrencyRateEvent: SetCurrencyRateFlow,
 
 	// Category
-	CreateCategoryEvent: CreateCategoryFlow,
-	ListCategoriesEvent: ListCategoriesFlow,
-	UpdateCategoryEvent: UpdateCategoryFlow,
-	DeleteCategoryEvent: DeleteCategoryFlow,
+	CreateCategoryEvent:        CreateCategoryFlow,
+	ListCategoriesEvent:        ListCategoriesFlow,
+	UpdateCategoryEvent:        UpdateCategoryFlow,
+	DeleteCategoryEvent:        DeleteCategoryFlow,
+	MergeCategoriesEvent:       MergeCategoriesFlow,
+	CreateCategoryRuleEvent:    CreateCategoryRuleFlow,
+	ListCategoryRulesEvent:     ListCategoryRulesFlow,
+	DeleteCategoryRuleEvent:    DeleteCategoryRuleFlow,
+	ApplyCategoryTemplateEvent: ApplyCategoryTemplateFlow,
 
 	// Operation
 	CreateOperationEvent:                     CreateOperationFlow,
@@ -106,8 +157,11 @@ var EventToFlow = map[Event]Flow{
 	CreateOperationsThroughOneTimeInputEvent: CreateOperationsThroughOneTimeInputFlow,
 
 	// Balance subscriptions
-	CreateBalanceSubscriptionEvent: CreateBalanceSubscriptionFlow,
-	ListBalanceSubscriptionEvent:   ListBalanceSubscriptionFlow,
-	UpdateBalanceSubscriptionEvent: UpdateBalanceSubscriptionFlow,
-	DeleteBalanceSubscriptionEvent: DeleteBalanceSubscriptionFlow,
+	CreateBalanceSubscriptionEvent:          CreateBalanceSubscriptionFlow,
+	ListBalanceSubscriptionEvent:            ListBalanceSubscriptionFlow,
+	UpdateBalanceSubscriptionEvent:          UpdateBalanceSubscriptionFlow,
+	DeleteBalanceSubscriptionEvent:          DeleteBalanceSubscriptionFlow,
+	DetectRecurringPaymentsEvent:            DetectRecurringPaymentsFlow,
+	GetBalanceSubscriptionsSummaryEvent:     GetBalanceSubscriptionsSummaryFlow,
+	ExportBalanceSubscriptionsCalendarEvent: ExportBalanceSubscriptionsCalendarFlow,
 }
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// ExchangeRate represents the rate between two currencies that was actual on the specific date.
+type ExchangeRate struct {
+	ID             string    `db:"id"`
+	BaseCurrency   string    `db:"base_currency"`
+	TargetCurrency string    `db:"target_currency"`
+	Date           time.Time `db:"date"`
+	Rate           string    `db:"rate"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// GetExchangeRateDate returns the day for which the exchange rate is stored, rates are tracked once per day in UTC.
+func GetExchangeRateDate(date time.Time) time.Time {
+	date = date.UTC()
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -42,6 +42,17 @@ const (
 	GetBalanceFlow Flow = "get_balance"
 	// DeleteBalanceFlow represents the flow for deleting a balance
 	DeleteBalanceFlow Flow = "delete_balance"
+	// GetNetWorthFlow represents the flow for getting net worth across all balances
+	GetNetWorthFlow Flow = "get_net_worth"
+	// CompareBalancePeriodsFlow represents the flow for comparing balance statistics of two periods
+	CompareBalancePeriodsFlow Flow = "compare_balance_periods"
+
+	// CreateCurrencyFlow represents the flow for creating a private currency
+	CreateCurrencyFlow Flow = "create_currency"
+	// ListCurrenciesFlow represents the flow for listing private currencies
+	ListCurrenciesFlow Flow = "list_currencies"
+	// SetCurrencyRateFlow represents the flow for setting manual rate of a private currency
+	SetCurrencyRateFlow Flow = "set_currency_rate"
 
 	// CreateCategoryFlow represents the flow for creating a new category
 	CreateCategoryFlow Flow = "create_category"
@@ -51,6 +62,16 @@ const (
 	UpdateCategoryFlow Flow = "update_category"
 	// DeleteCategoryFlow represents the flow for deleting a category
 	DeleteCategoryFlow Flow = "delete_category"
+	// MergeCategoriesFlow represents the flow for merging two categories
+	MergeCategoriesFlow Flow = "merge_categories"
+	// CreateCategoryRuleFlow represents the flow for creating a rule for automatic categorization
+	CreateCategoryRuleFlow Flow = "create_category_rule"
+	// ListCategoryRulesFlow represents the flow for listing all category rules
+	ListCategoryRulesFlow Flow = "list_category_rules"
+	// DeleteCategoryRuleFlow represents the flow for deleting a category rule
+	DeleteCategoryRuleFlow Flow = "delete_category_rule"
+	// ApplyCategoryTemplateFlow represents the flow for adding categories from the predefined template
+	ApplyCategoryTemplateFlow Flow = "apply_category_template"
 
 	// CreateOperationFlow represents the flow for creating a new operation
 	CreateOperationFlow Flow = "create_operation"
@@ -71,18 +92,26 @@ const (
 	UpdateBalanceSubscriptionFlow Flow = "update_balance_subscription"
 	// DeleteBalanceSubscriptionFlow represents the flow for deleting a balance subscription
 	DeleteBalanceSubscriptionFlow Flow = "delete_balance_subscription"
+	// DetectRecurringPaymentsFlow represents the flow for detecting recurring payments and creating subscriptions from them
+	DetectRecurringPaymentsFlow Flow = "detect_recurring_payments"
+	// GetBalanceSubscriptionsSummaryFlow represents the flow for getting balance subscriptions cost summary
+	GetBalanceSubscriptionsSummaryFlow Flow = "get_balance_subscriptions_summary"
+	// ExportBalanceSubscriptionsCalendarFlow represents the flow for exporting upcoming subscription payments to calendar
+	ExportBalanceSubscriptionsCalendarFlow Flow = "export_balance_subscriptions_calendar"
 )
 
 // GetBaseFlowFromCurrentFlow returns base(wrapper) flow from current one.
 func GetBaseFlowFromCurrentFlow(flow Flow) Flow {
 	if slices.Contains([]Flow{
-		CreateBalanceFlow, UpdateBalanceFlow, GetBalanceFlow, DeleteBalanceFlow,
+		CreateBalanceFlow, UpdateBalanceFlow, GetBalanceFlow, DeleteBalanceFlow, GetNetWorthFlow, CompareBalancePeriodsFlow,
+		CreateCurrencyFlow, ListCurrenciesFlow, SetCurrencyRateFlow,
 	}, flow) {
 		return BalanceFlow
 	}
 
 	if slices.Contains([]Flow{
-		CreateCategoryFlow, ListCategoriesFlow, UpdateCategoryFlow, DeleteCategoryFlow,
+		CreateCategoryFlow, ListCategoriesFlow, UpdateCategoryFlow, DeleteCategoryFlow, MergeCategoriesFlow,
+		CreateCategoryRuleFlow, ListCategoryRulesFlow, DeleteCategoryRuleFlow, ApplyCategoryTemplateFlow,
 	}, flow) {
 		return CategoryFlow
 	}
@@ -95,6 +124,7 @@ func GetBaseFlowFromCurrentFlow(flow Flow) Flow {
 
 	if slices.Contains([]Flow{
 		CreateBalanceSubscriptionFlow, ListBalanceSubscriptionFlow, UpdateBalanceSubscriptionFlow, DeleteBalanceSubscriptionFlow,
+		DetectRecurringPaymentsFlow, GetBalanceSubscriptionsSummaryFlow, ExportBalanceSubscriptionsCalendarFlow,
 	}, flow) {
 		return BalanceSubscriptionFlow
 	}
@@ -144,6 +174,12 @@ const (
 	StartFlowStep FlowStep = "start"
 	// EndFlowStep represents the final step of any flow
 	EndFlowStep FlowStep = "end"
+	// ChoosePeriodTypeFlowStep represents the step for choosing the way how the period is picked
+	ChoosePeriodTypeFlowStep FlowStep = "choose_period_type"
+	// ChoosePeriodYearFlowStep represents the step for choosing the year of the period
+	ChoosePeriodYearFlowStep FlowStep = "choose_period_year"
+	// ChoosePeriodFlowStep represents the step for choosing the period
+	ChoosePeriodFlowStep FlowStep = "choose_period"
 
 	// Steps that are related for user settings
 
@@ -157,6 +193,14 @@ const (
 	UpdateAIParserEnabledUserSettingFlowStep FlowStep = "update_ai_parser_enabled_user_setting"
 	// UpdateSubscriptionNotificationUserSettingFlowStep represents the step for updating subscription notification user setting
 	UpdateSubscriptionNotificationUserSettingFlowStep FlowStep = "update_subscription_notification_user_setting"
+	// UpdateSubscriptionNotificationLeadTimeUserSettingFlowStep represents the step for updating subscription notification lead time user setting
+	UpdateSubscriptionNotificationLeadTimeUserSettingFlowStep FlowStep = "update_subscription_notification_lead_time_user_setting"
+	// UpdateBaseCurrencyUserSettingFlowStep represents the step for updating base currency user setting
+	UpdateBaseCurrencyUserSettingFlowStep FlowStep = "update_base_currency_user_setting"
+	// UpdateDigestFrequencyUserSettingFlowStep represents the step for updating digest frequency user setting
+	UpdateDigestFrequencyUserSettingFlowStep FlowStep = "update_digest_frequency_user_setting"
+	// UpdateDigestSendTimeUserSettingFlowStep represents the step for updating digest send time user setting
+	UpdateDigestSendTimeUserSettingFlowStep FlowStep = "update_digest_send_time_user_setting"
 
 	// Steps that are related for balance
 
@@ -170,8 +214,6 @@ const (
 	ChooseUpdateBalanceOptionFlowStep FlowStep = "choose_update_balance_option"
 	// GetBalanceFlowStep represents the step for getting a balance
 	GetBalanceFlowStep FlowStep = "get_balance"
-	// ChooseMonthBalanceStatisticsFlowStep represents the step for choosing month for balance statistics
-	ChooseMonthBalanceStatisticsFlowStep FlowStep = "choose_month_for_balance_statistics"
 	// DeleteBalanceFlowStep represents the step for deleting a balance
 	DeleteBalanceFlowStep FlowStep = "delete_balance"
 	// ChooseBalanceFlowStep represents the step for choosing balance that will be used for an action
@@ -184,6 +226,33 @@ const (
 	EnterBalanceCurrencyFlowStep FlowStep = "enter_balance_currency"
 	// EnterBalanceAmountFlowStep represents the step for entering balance amount
 	EnterBalanceAmountFlowStep FlowStep = "enter_balance_amount"
+	// GetNetWorthFlowStep represents the step for getting net worth across all balances
+	GetNetWorthFlowStep FlowStep = "get_net_worth"
+	// CompareBalancePeriodsFlowStep represents the step for comparing balance statistics of two periods
+	CompareBalancePeriodsFlowStep FlowStep = "compare_balance_periods"
+
+	// Steps that are related for currency
+
+	// CreateCurrencyFlowStep represents the step for creating a private currency
+	CreateCurrencyFlowStep FlowStep = "create_currency"
+	// EnterCurrencyCodeFlowStep represents the step for entering private currency code
+	EnterCurrencyCodeFlowStep FlowStep = "enter_currency_code"
+	// EnterCurrencyNameFlowStep represents the step for entering private currency name
+	EnterCurrencyNameFlowStep FlowStep = "enter_currency_name"
+	// EnterCurrencySymbolFlowStep represents the step for entering private currency symbol
+	EnterCurrencySymbolFlowStep FlowStep = "enter_currency_symbol"
+	// EnterCurrencyDecimalPlacesFlowStep represents the step for entering number of decimal places of private currency
+	EnterCurrencyDecimalPlacesFlowStep FlowStep = "enter_currency_decimal_places"
+	// ListCurrenciesFlowStep represents the step for listing private currencies
+	ListCurrenciesFlowStep FlowStep = "list_currencies"
+	// SetCurrencyRateFlowStep represents the step for setting manual rate of a private currency
+	SetCurrencyRateFlowStep FlowStep = "set_currency_rate"
+	// ChooseCurrencyFlowStep represents the step for choosing private currency
+	ChooseCurrencyFlowStep FlowStep = "choose_currency"
+	// EnterCurrencyRateTargetFlowStep represents the step for entering currency in which the rate is quoted
+	EnterCurrencyRateTargetFlowStep FlowStep = "enter_currency_rate_target"
+	// EnterCurrencyRateFlowStep represents the step for entering manual rate of private currency
+	EnterCurrencyRateFlowStep FlowStep = "enter_currency_rate"
 
 	// Steps that are related for category
 
@@ -199,6 +268,40 @@ const (
 	EnterUpdatedCategoryNameFlowStep FlowStep = "enter_updated_category_name"
 	// EnterCategoryNameFlowStep represents the step for entering category name
 	EnterCategoryNameFlowStep FlowStep = "enter_category_name"
+	// ChooseParentCategoryFlowStep represents the step for choosing parent of the new category
+	ChooseParentCategoryFlowStep FlowStep = "choose_parent_category"
+	// ChooseCategoryKindFlowStep represents the step for choosing category kind
+	ChooseCategoryKindFlowStep FlowStep = "choose_category_kind"
+	// ChooseUpdateCategoryOptionFlowStep represents the step for choosing what should be updated in category
+	ChooseUpdateCategoryOptionFlowStep FlowStep = "choose_update_category_option"
+	// ChooseReplacementCategoryFlowStep represents the step for choosing category that replaces the deleted one
+	ChooseReplacementCategoryFlowStep FlowStep = "choose_replacement_category"
+	// MergeCategoriesFlowStep represents the step for merging two categories
+	MergeCategoriesFlowStep FlowStep = "merge_categories"
+	// ChooseTargetCategoryFlowStep represents the step for choosing category into which the other one is merged
+	ChooseTargetCategoryFlowStep FlowStep = "choose_target_category"
+	// ChooseCategoriesListOptionFlowStep represents the step for choosing action with the categories list
+	ChooseCategoriesListOptionFlowStep FlowStep = "choose_categories_list_option"
+	// EnterCategoryPositionFlowStep represents the step for entering new position of the category
+	EnterCategoryPositionFlowStep FlowStep = "enter_category_position"
+	// ChooseArchivedCategoryFlowStep represents the step for choosing archived category
+	ChooseArchivedCategoryFlowStep FlowStep = "choose_archived_category"
+	// CreateCategoryRuleFlowStep represents the step for creating a rule for automatic categorization
+	CreateCategoryRuleFlowStep FlowStep = "create_category_rule"
+	// EnterCategoryRulePatternFlowStep represents the step for entering the pattern matched with operation description
+	EnterCategoryRulePatternFlowStep FlowStep = "enter_category_rule_pattern"
+	// EnterCategoryRuleAmountRangeFlowStep represents the step for entering the amount range of the rule
+	EnterCategoryRuleAmountRangeFlowStep FlowStep = "enter_category_rule_amount_range"
+	// ListCategoryRulesFlowStep represents the step for listing all category rules
+	ListCategoryRulesFlowStep FlowStep = "list_category_rules"
+	// DeleteCategoryRuleFlowStep represents the step for deleting a category rule
+	DeleteCategoryRuleFlowStep FlowStep = "delete_category_rule"
+	// ChooseCategoryRuleFlowStep represents the step for choosing category rule
+	ChooseCategoryRuleFlowStep FlowStep = "choose_category_rule"
+	// ApplyCategoryTemplateFlowStep represents the step for adding categories from the predefined template
+	ApplyCategoryTemplateFlowStep FlowStep = "apply_category_template"
+	// ChooseCategoryTemplateFlowStep represents the step for choosing category template
+	ChooseCategoryTemplateFlowStep FlowStep = "choose_category_template"
 	// ListCategoriesFlowStep represents the step for listing all categories
 	ListCategoriesFlowStep FlowStep = "list_categories"
 
@@ -251,8 +354,18 @@ const (
 	EnterBalanceSubscriptionAmountFlowStep FlowStep = "enter_balance_subscription_amount"
 	// ChooseBalanceSubscriptionFrequencyFlowStep represents the step for choosing balance subscription frequency
 	ChooseBalanceSubscriptionFrequencyFlowStep FlowStep = "choose_balance_subscription_frequency"
+	// ChooseBalanceSubscriptionNotificationLeadTimeFlowStep represents the step for choosing balance subscription notification lead time
+	ChooseBalanceSubscriptionNotificationLeadTimeFlowStep FlowStep = "choose_balance_subscription_notification_lead_time"
+	// ChooseBalanceSubscriptionCurrencyFlowStep represents the step for choosing balance subscription currency
+	ChooseBalanceSubscriptionCurrencyFlowStep FlowStep = "choose_balance_subscription_currency"
 	// EnterStartAtDateForBalanceSubscriptionFlowStep represents the step for entering start at date for balance subscription
 	EnterStartAtDateForBalanceSubscriptionFlowStep FlowStep = "enter_start_at_date_for_balance_subscription"
+	// ConfirmBalanceSubscriptionTrialFlowStep represents the step for confirming that balance subscription starts with a free trial
+	ConfirmBalanceSubscriptionTrialFlowStep FlowStep = "confirm_balance_subscription_trial"
+	// EnterBalanceSubscriptionTrialEndDateFlowStep represents the step for entering the free trial end date of balance subscription
+	EnterBalanceSubscriptionTrialEndDateFlowStep FlowStep = "enter_balance_subscription_trial_end_date"
+	// EnterBalanceSubscriptionAmountAfterTrialFlowStep represents the step for entering the amount charged after the free trial of balance subscription
+	EnterBalanceSubscriptionAmountAfterTrialFlowStep FlowStep = "enter_balance_subscription_amount_after_trial"
 	// ListBalanceSubscriptionFlowStep represents the step for listing balance subscriptions
 	ListBalanceSubscriptionFlowStep FlowStep = "list_balance_subscriptions"
 	// UpdateBalanceSubscriptionFlowStep represents the step for updating a balance subscription
@@ -267,4 +380,14 @@ const (
 	ChooseBalanceSubscriptionToDeleteFlowStep FlowStep = "choose_balance_subscription_to_delete"
 	// ConfirmDeleteBalanceSubscriptionFlowStep represents the step for confirming deletion of a balance subscription
 	ConfirmDeleteBalanceSubscriptionFlowStep FlowStep = "confirm_delete_balance_subscription"
+	// DetectRecurringPaymentsFlowStep represents the step for detecting recurring payments
+	DetectRecurringPaymentsFlowStep FlowStep = "detect_recurring_payments"
+	// ConfirmSubscriptionFromRecurringPaymentFlowStep represents the step for confirming subscription creation from a recurring payment
+	ConfirmSubscriptionFromRecurringPaymentFlowStep FlowStep = "confirm_subscription_from_recurring_payment"
+	// GetBalanceSubscriptionsSummaryFlowStep represents the step for getting balance subscriptions cost summary
+	GetBalanceSubscriptionsSummaryFlowStep FlowStep = "get_balance_subscriptions_summary"
+	// ChooseBalanceSubscriptionsSummaryCurrencyFlowStep represents the step for choosing currency of balance subscriptions summary
+	ChooseBalanceSubscriptionsSummaryCurrencyFlowStep FlowStep = "choose_balance_subscriptions_summary_currency"
+	// ExportBalanceSubscriptionsCalendarFlowStep represents the step for exporting upcoming subscription payments to calendar
+	ExportBalanceSubscriptionsCalendarFlowStep FlowStep = "export_balance_subscriptions_calendar"
 )
@@ -66,6 +66,12 @@ const (
 	BaseFlowMetadataKey MetadataKey = "base_flow"
 	// PageMetadataKey represents the current page number.
 	PageMetadataKey MetadataKey = "page"
+	// PeriodTypeMetadataKey represents the type of the period chosen by the user.
+	PeriodTypeMetadataKey MetadataKey = "period_type"
+	// PeriodMetadataKey represents the ID of the period chosen by the user.
+	PeriodMetadataKey MetadataKey = "period"
+	// DigestFrequencyMetadataKey represents the digest frequency chosen by the user.
+	DigestFrequencyMetadataKey MetadataKey = "digest_frequency"
 
 	// Balance related keys
 
@@ -81,8 +87,19 @@ const (
 	BalanceToMetadataKey MetadataKey = "balance_to"
 	// CurrentBalanceNameMetadataKey represents the current balance name.
 	CurrentBalanceNameMetadataKey MetadataKey = "current_balance_name"
-	// MonthForBalanceStatisticsKey represents the month that was used for balance statistics.
-	MonthForBalanceStatisticsKey MetadataKey = "month_for_balance_statistics"
+
+	// Currency related keys
+
+	// CurrencyIDMetadataKey represents the ID of the currency.
+	CurrencyIDMetadataKey MetadataKey = "currency_id"
+	// CurrencyCodeMetadataKey represents the code of the currency.
+	CurrencyCodeMetadataKey MetadataKey = "currency_code"
+	// CurrencyNameMetadataKey represents the name of the currency.
+	CurrencyNameMetadataKey MetadataKey = "currency_name"
+	// CurrencySymbolMetadataKey represents the symbol of the currency.
+	CurrencySymbolMetadataKey MetadataKey = "currency_symbol"
+	// CurrencyRateTargetMetadataKey represents the code of the currency in which the manual rate is quoted.
+	CurrencyRateTargetMetadataKey MetadataKey = "currency_rate_target"
 
 	// Category related keys
 
@@ -92,11 +109,23 @@ const (
 	CategoryTitleMetadataKey MetadataKey = "category_title"
 	// CategoryIDMetadataKey represents the ID of the category.
 	CategoryIDMetadataKey MetadataKey = "category_id"
+	// ParentCategoryIDMetadataKey represents the ID of the category which sub-categories are shown to the user.
+	ParentCategoryIDMetadataKey MetadataKey = "parent_category_id"
+	// CategoryKindMetadataKey represents the kind of the category.
+	CategoryKindMetadataKey MetadataKey = "category_kind"
+	// CategoryRulePatternMetadataKey represents the pattern of the category rule entered by the user.
+	CategoryRulePatternMetadataKey MetadataKey = "category_rule_pattern"
+	// CategoryRuleMinAmountMetadataKey represents the minimal operation amount of the category rule.
+	CategoryRuleMinAmountMetadataKey MetadataKey = "category_rule_min_amount"
+	// CategoryRuleMaxAmountMetadataKey represents the maximal operation amount of the category rule.
+	CategoryRuleMaxAmountMetadataKey MetadataKey = "category_rule_max_amount"
 
 	// Operation related keys
 
 	// ExchangeRateMetadataKey represents the exchange rate.
 	ExchangeRateMetadataKey MetadataKey = "exchange_rate"
+	// MarketExchangeRateMetadataKey represents the market exchange rate suggested to the user.
+	MarketExchangeRateMetadataKey MetadataKey = "market_exchange_rate"
 	// OperationDescriptionMetadataKey represents the description of the operation.
 	OperationDescriptionMetadataKey MetadataKey = "operation_description"
 	// OperationAmountMetadataKey represents the amount of the operation.
@@ -105,8 +134,6 @@ const (
 	OperationTypeMetadataKey MetadataKey = "operation_type"
 	// OperationIDMetadataKey represents the ID of the operation.
 	OperationIDMetadataKey MetadataKey = "operation_id"
-	// OperationCreationPeriodMetadataKey represents the creation period of the operation.
-	OperationCreationPeriodMetadataKey MetadataKey = "operation_creation_period"
 
 	// Balance subscription related keys
 
@@ -118,4 +145,12 @@ const (
 	BalanceSubscriptionPeriodMetadataKey MetadataKey = "balance_subscription_period"
 	// BalanceSubscriptionAmountMetadataKey represents the amount of the balance subscription.
 	BalanceSubscriptionAmountMetadataKey MetadataKey = "balance_subscription_amount"
+	// BalanceSubscriptionStartAtMetadataKey represents the start date of the balance subscription.
+	BalanceSubscriptionStartAtMetadataKey MetadataKey = "balance_subscription_start_at"
+	// BalanceSubscriptionTrialEndAtMetadataKey represents the free trial end date of the balance subscription.
+	BalanceSubscriptionTrialEndAtMetadataKey MetadataKey = "balance_subscription_trial_end_at"
+	// BalanceSubscriptionAmountAfterTrialMetadataKey represents the amount charged after the free trial of the balance subscription.
+	BalanceSubscriptionAmountAfterTrialMetadataKey MetadataKey = "balance_subscription_amount_after_trial"
+	// SkippedRecurringPaymentsMetadataKey represents the number of recurring payment suggestions declined by user.
+	SkippedRecurringPaymentsMetadataKey MetadataKey = "skipped_recurring_payments"
 )
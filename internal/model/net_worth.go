@@ -0,0 +1,80 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/VladPetriv/finance_bot/pkg/money"
+)
+
+// NetWorthItem represents a balance with its amount converted into the base currency.
+type NetWorthItem struct {
+	Balance Balance
+	// ConvertedAmount is nil when the balance amount couldn't be converted into the base currency.
+	ConvertedAmount *money.Money
+}
+
+// NetWorth contains the data required to build consolidated overview of all user balances.
+type NetWorth struct {
+	Currency Currency
+
+	Items []NetWorthItem
+}
+
+// BuildMessage returns the net worth overview in markdown format.
+// Balances that couldn't be converted are listed separately and excluded from the total.
+func (n NetWorth) BuildMessage() string {
+	var buffer strings.Builder
+
+	total := money.Zero
+	convertedItems := make([]NetWorthItem, 0, len(n.Items))
+	notConvertedBalances := make([]string, 0)
+	for _, item := range n.Items {
+		if item.ConvertedAmount == nil {
+			notConvertedBalances = append(notConvertedBalances, item.Balance.Name)
+			continue
+		}
+
+		total.Inc(*item.ConvertedAmount)
+		convertedItems = append(convertedItems, item)
+	}
+	sort.SliceStable(convertedItems, func(i, j int) bool {
+		return convertedItems[i].ConvertedAmount.GreaterThan(*convertedItems[j].ConvertedAmount)
+	})
+
+	buffer.WriteString(fmt.Sprintf("💎 Net Worth *(%s)*\n", n.Currency.Code))
+	buffer.WriteString(fmt.Sprintf("💰 Total: %s\n", formatAmount(total, n.Currency)))
+
+	if len(n.Items) > 0 {
+		buffer.WriteString("\n🏦 By Balance:\n")
+	}
+	for _, item := range convertedItems {
+		currency := item.Balance.GetCurrency()
+		if currency.Code == n.Currency.Code {
+			buffer.WriteString(fmt.Sprintf("	- %s: %s\n", item.Balance.Name, formatAmount(*item.ConvertedAmount, n.Currency)))
+			continue
+		}
+
+		buffer.WriteString(fmt.Sprintf(
+			"	- %s: %s %s → %s\n",
+			item.Balance.Name, item.Balance.Amount, currency.Code, formatAmount(*item.ConvertedAmount, n.Currency),
+		))
+	}
+	for _, item := range n.Items {
+		if item.ConvertedAmount != nil {
+			continue
+		}
+
+		buffer.WriteString(fmt.Sprintf("	- %s: %s %s ⚠️\n", item.Balance.Name, item.Balance.Amount, item.Balance.GetCurrency().Code))
+	}
+
+	if len(notConvertedBalances) > 0 {
+		buffer.WriteString(fmt.Sprintf(
+			"\n⚠️ Not included in total, because the amount couldn't be converted: %s\n",
+			strings.Join(notConvertedBalances, ", "),
+		))
+	}
+
+	return buffer.String()
+}
@@ -0,0 +1,42 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/VladPetriv/finance_bot/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetWorth_BuildMessage(t *testing.T) {
+	t.Parallel()
+
+	usdAmount, uahAmount := money.NewFromInt(100), money.NewFromFloat(24.5)
+
+	netWorth := model.NetWorth{
+		Currency: model.Currency{Code: "USD", Symbol: "$"},
+		Items: []model.NetWorthItem{
+			{
+				Balance:         model.Balance{Name: "Card", Amount: "1000.00", Currency: model.Currency{Code: "UAH"}},
+				ConvertedAmount: &uahAmount,
+			},
+			{
+				Balance: model.Balance{Name: "Miles", Amount: "5000", Currency: model.Currency{Code: "MILES"}},
+			},
+			{
+				Balance:         model.Balance{Name: "Cash", Amount: "100.00", Currency: model.Currency{Code: "USD"}},
+				ConvertedAmount: &usdAmount,
+			},
+		},
+	}
+
+	expected := "💎 Net Worth *(USD)*\n" +
+		"💰 Total: `$124.50`\n" +
+		"\n🏦 By Balance:\n" +
+		"	- Cash: `$100.00`\n" +
+		"	- Card: 1000.00 UAH → `$24.50`\n" +
+		"	- Miles: 5000 MILES ⚠️\n" +
+		"\n⚠️ Not included in total, because the amount couldn't be converted: Miles\n"
+
+	assert.Equal(t, expected, netWorth.BuildMessage())
+}
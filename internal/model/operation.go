@@ -21,6 +21,14 @@ type Operation struct {
 	Amount       string        `db:"amount"`
 	Description  string        `db:"description"`
 	ExchangeRate string        `db:"exchange_rate"`
+	// MarketExchangeRate is set for transfers between balances with different currencies,
+	// when the market rate was known at the moment of the transfer.
+	MarketExchangeRate string `db:"market_exchange_rate"`
+
+	// OriginalAmount and OriginalCurrency are set when the operation was converted from another currency,
+	// e.g. a subscription that is charged in a currency different from the balance one.
+	OriginalAmount   string `db:"original_amount"`
+	OriginalCurrency string `db:"original_currency"`
 
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
@@ -122,10 +130,55 @@ func (o *Operation) GetDeletionMessage() string {
 
 // GetDetails returns the operation details in string format.
 func (o *Operation) GetDetails() string {
-	return fmt.Sprintf(
+	details := fmt.Sprintf(
 		"Operation Details:\nType: %s\nAmount: %s\nDescription: %s",
 		o.Type, o.Amount, o.Description,
 	)
+
+	if o.ExchangeRate != "" {
+		details += fmt.Sprintf("\nExchange Rate: %s", o.ExchangeRate)
+	}
+	if spread := o.GetExchangeRateSpread(); spread != "" {
+		details += fmt.Sprintf("\nSpread: %s", spread)
+	}
+
+	return details
+}
+
+var percents = money.NewFromInt(100)
+
+// GetExchangeRateSpread returns the difference between the exchange rate used for the operation
+// and the market one in human readable format. Returns empty string when the market rate is unknown.
+func (o Operation) GetExchangeRateSpread() string {
+	if o.ExchangeRate == "" || o.MarketExchangeRate == "" {
+		return ""
+	}
+
+	exchangeRate, err := money.NewFromString(o.ExchangeRate)
+	if err != nil {
+		return ""
+	}
+	marketExchangeRate, err := money.NewFromString(o.MarketExchangeRate)
+	if err != nil || marketExchangeRate.Equal(money.Zero) {
+		return ""
+	}
+
+	spread := money.Zero
+	spread.Inc(exchangeRate)
+	spread.Sub(marketExchangeRate)
+	spread.Mul(percents)
+	spread.Div(marketExchangeRate)
+
+	direction := "above"
+	if spread.LessThan(money.Zero) {
+		direction = "below"
+		spread.Mul(money.NewFromInt(-1))
+	}
+	if spread.Equal(money.Zero) {
+		return fmt.Sprintf("matches market rate %s", marketExchangeRate.StringRounded(ExchangeRatePlaces))
+	}
+
+	return fmt.Sprintf("%s%% %s market rate %s", spread.StringFixed(), direction, marketExchangeRate.StringRounded(ExchangeRatePlaces))
 }
 
 // GetOperationTypeLabel returns the label for the given operation type.
@@ -163,8 +216,39 @@ const (
 )
 
 type createOperationPromptData struct {
-	UserInput  string     `json:"user_input"`
-	Categories []Category `json:"categories"`
+	UserInput  string           `json:"user_input"`
+	Categories []promptCategory `json:"categories"`
+}
+
+type promptCategory struct {
+	ID            string           `json:"id"`
+	Title         string           `json:"title"`
+	Kind          CategoryKind     `json:"kind"`
+	Subcategories []promptCategory `json:"subcategories,omitempty"`
+}
+
+func buildPromptCategories(categories []Category) []promptCategory {
+	tree := BuildCategoryTree(categories)
+
+	promptCategories := make([]promptCategory, 0, len(tree))
+	for _, node := range tree {
+		category := promptCategory{
+			ID:    node.ID,
+			Title: node.Title,
+			Kind:  node.GetKind(),
+		}
+		for _, subcategory := range node.Subcategories {
+			category.Subcategories = append(category.Subcategories, promptCategory{
+				ID:    subcategory.ID,
+				Title: subcategory.Title,
+				Kind:  subcategory.GetKind(),
+			})
+		}
+
+		promptCategories = append(promptCategories, category)
+	}
+
+	return promptCategories
 }
 
 // BuildCreateOperationFromTextPrompt builds a prompt for creating an operation based on provided categories and text from user.
@@ -181,6 +265,8 @@ func BuildCreateOperationFromTextPrompt(userInput string, categories []Category)
   - Amounts always follow this format: 100.12, 100, 123.31 (no commas).
   - Negative (-) = **expense**, Positive (+) = **income**.
   - Select the **most relevant category** based on the text.
+  - Categories may contain "subcategories", prefer the **most specific** matching subcategory over its parent.
+  - Category "kind" is income | expense | both, never select an income category for spending or an expense category for incoming.
   - **If no suitable category is found, return "category_id": ""** (do not invent a category).
   - Return **only JSON**, nothing else.
 
@@ -197,7 +283,7 @@ func BuildCreateOperationFromTextPrompt(userInput string, categories []Category)
 
 	encodedPromptData, err := json.Marshal(createOperationPromptData{
 		UserInput:  userInput,
-		Categories: categories,
+		Categories: buildPromptCategories(categories),
 	})
 	if err != nil {
 		return "", fmt.Errorf("marshal categories: %w", err)
@@ -0,0 +1,51 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperation_GetExchangeRateSpread(t *testing.T) {
+	t.Parallel()
+
+	testCases := [...]struct {
+		desc      string
+		operation model.Operation
+		expected  string
+	}{
+		{
+			desc:      "rate below market one",
+			operation: model.Operation{ExchangeRate: "40.7", MarketExchangeRate: "41.23"},
+			expected:  "1.29% below market rate 41.23",
+		},
+		{
+			desc:      "rate above market one",
+			operation: model.Operation{ExchangeRate: "0.025", MarketExchangeRate: "0.0242"},
+			expected:  "3.31% above market rate 0.0242",
+		},
+		{
+			desc:      "rate matches market one",
+			operation: model.Operation{ExchangeRate: "41.23", MarketExchangeRate: "41.23"},
+			expected:  "matches market rate 41.23",
+		},
+		{
+			desc:      "market rate is unknown",
+			operation: model.Operation{ExchangeRate: "41.23"},
+			expected:  "",
+		},
+		{
+			desc:      "operation without exchange rate",
+			operation: model.Operation{},
+			expected:  "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.operation.GetExchangeRateSpread())
+		})
+	}
+}
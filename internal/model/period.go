@@ -0,0 +1,268 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PeriodType represents the way how the user picks the period for statistics or operations history.
+type PeriodType string
+
+const (
+	// PeriodTypeMonth represents a calendar month of the chosen year.
+	PeriodTypeMonth PeriodType = "month"
+	// PeriodTypeWeek represents an ISO week.
+	PeriodTypeWeek PeriodType = "week"
+	// PeriodTypeQuarter represents a quarter of the chosen year.
+	PeriodTypeQuarter PeriodType = "quarter"
+	// PeriodTypeCustom represents an arbitrary range of dates entered by the user.
+	PeriodTypeCustom PeriodType = "custom"
+)
+
+// PeriodCustomDateFormat is the format of dates used for custom period.
+const PeriodCustomDateFormat = "02/01/2006"
+
+// ErrInvalidPeriod happens when the period can't be parsed or its start is after its end.
+var ErrInvalidPeriod = errors.New("invalid period")
+
+// Period represents an inclusive time range picked by the user.
+type Period struct {
+	// ID is a text representation of the period which is stored in the state metadata,
+	// e.g. "2025-12", "2025-W05", "2025-Q1" or "01/01/2025 - 15/01/2025".
+	ID   string
+	Type PeriodType
+	From time.Time
+	To   time.Time
+}
+
+// NewMonthPeriod returns the period of the month in the year.
+func NewMonthPeriod(year int, month time.Month) Period {
+	from := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+
+	return Period{
+		ID:   from.Format("2006-01"),
+		Type: PeriodTypeMonth,
+		From: from,
+		To:   endOfDay(from.AddDate(0, 1, -1)),
+	}
+}
+
+// NewWeekPeriod returns the period of the ISO week in the year, weeks start on Monday.
+func NewWeekPeriod(year, week int) Period {
+	// 4th of January always belongs to the first ISO week of the year.
+	january4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	daysSinceMonday := (int(january4.Weekday()) + 6) % 7
+	from := january4.AddDate(0, 0, -daysSinceMonday+(week-1)*7)
+
+	return Period{
+		ID:   fmt.Sprintf("%d-W%02d", year, week),
+		Type: PeriodTypeWeek,
+		From: from,
+		To:   endOfDay(from.AddDate(0, 0, 6)),
+	}
+}
+
+// NewQuarterPeriod returns the period of the quarter (1-4) in the year.
+func NewQuarterPeriod(year, quarter int) Period {
+	from := time.Date(year, time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, time.UTC)
+
+	return Period{
+		ID:   fmt.Sprintf("%d-Q%d", year, quarter),
+		Type: PeriodTypeQuarter,
+		From: from,
+		To:   endOfDay(from.AddDate(0, 3, -1)),
+	}
+}
+
+// NewCustomPeriod returns the period between two dates, both days are included.
+func NewCustomPeriod(from, to time.Time) Period {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+
+	return Period{
+		ID:   fmt.Sprintf("%s - %s", from.Format(PeriodCustomDateFormat), to.Format(PeriodCustomDateFormat)),
+		Type: PeriodTypeCustom,
+		From: from,
+		To:   endOfDay(to),
+	}
+}
+
+var (
+	monthPeriodRegex   = regexp.MustCompile(`^(\d{4})-(\d{2})$`)
+	weekPeriodRegex    = regexp.MustCompile(`^(\d{4})-[Ww](\d{1,2})$`)
+	quarterPeriodRegex = regexp.MustCompile(`^(\d{4})-[Qq]([1-4])$`)
+)
+
+// ParsePeriod parses the period from its ID. Custom period could be entered by the user in the same format,
+// e.g. "01/01/2025 - 15/01/2025".
+func ParsePeriod(input string) (Period, error) {
+	input = strings.TrimSpace(input)
+
+	if match := monthPeriodRegex.FindStringSubmatch(input); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		month, _ := strconv.Atoi(match[2])
+		if month < 1 || month > 12 {
+			return Period{}, ErrInvalidPeriod
+		}
+
+		return NewMonthPeriod(year, time.Month(month)), nil
+	}
+
+	if match := weekPeriodRegex.FindStringSubmatch(input); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		week, _ := strconv.Atoi(match[2])
+
+		period := NewWeekPeriod(year, week)
+		// Not every year has 53 weeks, so the week should be checked after calculating its start.
+		periodYear, periodWeek := period.From.ISOWeek()
+		if week < 1 || periodYear != year || periodWeek != week {
+			return Period{}, ErrInvalidPeriod
+		}
+
+		return period, nil
+	}
+
+	if match := quarterPeriodRegex.FindStringSubmatch(input); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		quarter, _ := strconv.Atoi(match[2])
+
+		return NewQuarterPeriod(year, quarter), nil
+	}
+
+	dates := strings.Split(input, "-")
+	if len(dates) != 2 {
+		return Period{}, ErrInvalidPeriod
+	}
+
+	from, err := time.Parse(PeriodCustomDateFormat, strings.TrimSpace(dates[0]))
+	if err != nil {
+		return Period{}, fmt.Errorf("%w: %w", ErrInvalidPeriod, err)
+	}
+	to, err := time.Parse(PeriodCustomDateFormat, strings.TrimSpace(dates[1]))
+	if err != nil {
+		return Period{}, fmt.Errorf("%w: %w", ErrInvalidPeriod, err)
+	}
+	if from.After(to) {
+		return Period{}, ErrInvalidPeriod
+	}
+
+	return NewCustomPeriod(from, to), nil
+}
+
+// GetID returns the period ID.
+func (p Period) GetID() string {
+	return p.ID
+}
+
+// GetName returns human readable representation of the period.
+func (p Period) GetName() string {
+	switch p.Type {
+	case PeriodTypeMonth:
+		return p.From.Format("January 2006")
+	case PeriodTypeWeek:
+		_, week := p.From.ISOWeek()
+		return fmt.Sprintf("W%d: %s - %s", week, p.From.Format("02 Jan"), p.To.Format("02 Jan"))
+	case PeriodTypeQuarter:
+		return fmt.Sprintf("Q%d %d", (int(p.From.Month())-1)/3+1, p.From.Year())
+	default:
+		return fmt.Sprintf("%s - %s", p.From.Format(dateFormat), p.To.Format(dateFormat))
+	}
+}
+
+// GetPrevious returns the period of the same type and length which ends right before the current one.
+func (p Period) GetPrevious() Period {
+	switch p.Type {
+	case PeriodTypeMonth:
+		previous := p.From.AddDate(0, -1, 0)
+		return NewMonthPeriod(previous.Year(), previous.Month())
+	case PeriodTypeWeek:
+		year, week := p.From.AddDate(0, 0, -7).ISOWeek()
+		return NewWeekPeriod(year, week)
+	case PeriodTypeQuarter:
+		previous := p.From.AddDate(0, -3, 0)
+		return NewQuarterPeriod(previous.Year(), (int(previous.Month())-1)/3+1)
+	default:
+		days := int(p.To.Sub(p.From).Hours()/24) + 1
+		return NewCustomPeriod(p.From.AddDate(0, 0, -days), p.From.AddDate(0, 0, -1))
+	}
+}
+
+// GetSamePeriodLastYear returns the period of the same type one year before the current one.
+func (p Period) GetSamePeriodLastYear() Period {
+	switch p.Type {
+	case PeriodTypeMonth:
+		return NewMonthPeriod(p.From.Year()-1, p.From.Month())
+	case PeriodTypeWeek:
+		year, week := p.From.ISOWeek()
+
+		period := NewWeekPeriod(year-1, week)
+		// Previous year could have only 52 weeks, in that case its last week is used.
+		if _, periodWeek := period.From.ISOWeek(); periodWeek != week {
+			return NewWeekPeriod(year-1, week-1)
+		}
+
+		return period
+	case PeriodTypeQuarter:
+		return NewQuarterPeriod(p.From.Year()-1, (int(p.From.Month())-1)/3+1)
+	default:
+		return NewCustomPeriod(p.From.AddDate(-1, 0, 0), p.To.AddDate(-1, 0, 0))
+	}
+}
+
+// GetPeriodYears returns the years available for choosing the period, starting from the current one.
+func GetPeriodYears(now time.Time, count int) []int {
+	years := make([]int, 0, count)
+	for i := 0; i < count; i++ {
+		years = append(years, now.Year()-i)
+	}
+
+	return years
+}
+
+// GetMonthPeriods returns periods for all months of the year which already started.
+func GetMonthPeriods(year int, now time.Time) []Period {
+	var periods []Period
+	for month := time.January; month <= time.December; month++ {
+		period := NewMonthPeriod(year, month)
+		if period.From.After(now) {
+			break
+		}
+
+		periods = append(periods, period)
+	}
+
+	return periods
+}
+
+// GetQuarterPeriods returns periods for all quarters of the year which already started.
+func GetQuarterPeriods(year int, now time.Time) []Period {
+	var periods []Period
+	for quarter := 1; quarter <= 4; quarter++ {
+		period := NewQuarterPeriod(year, quarter)
+		if period.From.After(now) {
+			break
+		}
+
+		periods = append(periods, period)
+	}
+
+	return periods
+}
+
+// GetLastWeekPeriods returns periods for the given number of the last ISO weeks, starting from the current one.
+func GetLastWeekPeriods(now time.Time, count int) []Period {
+	periods := make([]Period, 0, count)
+	for i := 0; i < count; i++ {
+		year, week := now.AddDate(0, 0, -7*i).ISOWeek()
+		periods = append(periods, NewWeekPeriod(year, week))
+	}
+
+	return periods
+}
+
+func endOfDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, time.UTC)
+}
@@ -0,0 +1,220 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePeriod(t *testing.T) {
+	t.Parallel()
+
+	testCases := [...]struct {
+		desc     string
+		input    string
+		expected model.Period
+		err      bool
+	}{
+		{
+			desc:  "month",
+			input: "2024-12",
+			expected: model.Period{
+				ID:   "2024-12",
+				Type: model.PeriodTypeMonth,
+				From: time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2024, time.December, 31, 23, 59, 59, 0, time.UTC),
+			},
+		},
+		{
+			desc:  "ISO week which starts in the previous year",
+			input: "2025-W01",
+			expected: model.Period{
+				ID:   "2025-W01",
+				Type: model.PeriodTypeWeek,
+				From: time.Date(2024, time.December, 30, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2025, time.January, 5, 23, 59, 59, 0, time.UTC),
+			},
+		},
+		{
+			desc:  "53rd ISO week",
+			input: "2020-w53",
+			expected: model.Period{
+				ID:   "2020-W53",
+				Type: model.PeriodTypeWeek,
+				From: time.Date(2020, time.December, 28, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2021, time.January, 3, 23, 59, 59, 0, time.UTC),
+			},
+		},
+		{
+			desc:  "quarter",
+			input: "2024-Q1",
+			expected: model.Period{
+				ID:   "2024-Q1",
+				Type: model.PeriodTypeQuarter,
+				From: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2024, time.March, 31, 23, 59, 59, 0, time.UTC),
+			},
+		},
+		{
+			desc:  "custom range",
+			input: " 25/12/2024-05/01/2025 ",
+			expected: model.Period{
+				ID:   "25/12/2024 - 05/01/2025",
+				Type: model.PeriodTypeCustom,
+				From: time.Date(2024, time.December, 25, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2025, time.January, 5, 23, 59, 59, 0, time.UTC),
+			},
+		},
+		{
+			desc:  "custom range with the same day",
+			input: "01/01/2025 - 01/01/2025",
+			expected: model.Period{
+				ID:   "01/01/2025 - 01/01/2025",
+				Type: model.PeriodTypeCustom,
+				From: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2025, time.January, 1, 23, 59, 59, 0, time.UTC),
+			},
+		},
+		{
+			desc:  "invalid month",
+			input: "2024-13",
+			err:   true,
+		},
+		{
+			desc:  "week which doesn't exist in the year",
+			input: "2024-W53",
+			err:   true,
+		},
+		{
+			desc:  "zero week",
+			input: "2024-W00",
+			err:   true,
+		},
+		{
+			desc:  "invalid quarter",
+			input: "2024-Q5",
+			err:   true,
+		},
+		{
+			desc:  "custom range with start after end",
+			input: "05/01/2025 - 25/12/2024",
+			err:   true,
+		},
+		{
+			desc:  "invalid text",
+			input: "last month",
+			err:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := model.ParsePeriod(tc.input)
+			if tc.err {
+				assert.ErrorIs(t, err, model.ErrInvalidPeriod)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+
+			// Period ID should be parsed into the same period, since only ID is stored in the state.
+			parsedFromID, err := model.ParsePeriod(actual.GetID())
+			assert.NoError(t, err)
+			assert.Equal(t, actual, parsedFromID)
+		})
+	}
+}
+
+func TestPeriod_GetName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "December 2024", model.NewMonthPeriod(2024, time.December).GetName())
+	assert.Equal(t, "W1: 30 Dec - 05 Jan", model.NewWeekPeriod(2025, 1).GetName())
+	assert.Equal(t, "Q3 2024", model.NewQuarterPeriod(2024, 3).GetName())
+	assert.Equal(
+		t,
+		"25 Dec 2024 - 05 Jan 2025",
+		model.NewCustomPeriod(time.Date(2024, time.December, 25, 0, 0, 0, 0, time.UTC), time.Date(2025, time.January, 5, 0, 0, 0, 0, time.UTC)).GetName(),
+	)
+}
+
+func TestPeriod_GetPreviousAndSamePeriodLastYear(t *testing.T) {
+	t.Parallel()
+
+	testCases := [...]struct {
+		desc             string
+		period           model.Period
+		expectedPrevious string
+		expectedLastYear string
+	}{
+		{
+			desc:             "month",
+			period:           model.NewMonthPeriod(2025, time.January),
+			expectedPrevious: "2024-12",
+			expectedLastYear: "2024-01",
+		},
+		{
+			desc:             "week",
+			period:           model.NewWeekPeriod(2025, 1),
+			expectedPrevious: "2024-W52",
+			expectedLastYear: "2024-W01",
+		},
+		{
+			desc:             "53rd week when the previous year has only 52 weeks",
+			period:           model.NewWeekPeriod(2020, 53),
+			expectedPrevious: "2020-W52",
+			expectedLastYear: "2019-W52",
+		},
+		{
+			desc:             "quarter",
+			period:           model.NewQuarterPeriod(2025, 1),
+			expectedPrevious: "2024-Q4",
+			expectedLastYear: "2024-Q1",
+		},
+		{
+			desc: "custom range",
+			period: model.NewCustomPeriod(
+				time.Date(2025, time.January, 10, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, time.January, 19, 0, 0, 0, 0, time.UTC),
+			),
+			expectedPrevious: "31/12/2024 - 09/01/2025",
+			expectedLastYear: "10/01/2024 - 19/01/2024",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expectedPrevious, tc.period.GetPrevious().GetID())
+			assert.Equal(t, tc.expectedLastYear, tc.period.GetSamePeriodLastYear().GetID())
+		})
+	}
+}
+
+func TestGetPeriods(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.May, 14, 10, 0, 0, 0, time.UTC)
+
+	getIDs := func(periods []model.Period) []string {
+		var ids []string
+		for _, period := range periods {
+			ids = append(ids, period.GetID())
+		}
+
+		return ids
+	}
+
+	assert.Equal(t, []int{2025, 2024, 2023}, model.GetPeriodYears(now, 3))
+	assert.Equal(t, []string{"2025-01", "2025-02", "2025-03", "2025-04", "2025-05"}, getIDs(model.GetMonthPeriods(2025, now)))
+	assert.Len(t, model.GetMonthPeriods(2024, now), 12)
+	assert.Equal(t, []string{"2025-Q1", "2025-Q2"}, getIDs(model.GetQuarterPeriods(2025, now)))
+	assert.Equal(t, []string{"2024-Q1", "2024-Q2", "2024-Q3", "2024-Q4"}, getIDs(model.GetQuarterPeriods(2024, now)))
+	assert.Equal(t, []string{"2025-W20", "2025-W19", "2025-W18"}, getIDs(model.GetLastWeekPeriods(now, 3)))
+}
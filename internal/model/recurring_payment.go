@@ -0,0 +1,140 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RecurringPayment represents a group of spending operations that repeat with the same amount and description
+// at a regular interval, so they could be registered as a balance subscription.
+type RecurringPayment struct {
+	BalanceID  string
+	CategoryID string
+
+	Name   string
+	Amount string
+	Period SubscriptionPeriod
+
+	OperationIDs  []string
+	LastPaymentAt time.Time
+}
+
+// GetSuggestionMessage returns the message that offers user to create a subscription from the recurring payment.
+func (r RecurringPayment) GetSuggestionMessage() string {
+	return fmt.Sprintf(
+		"🔍 Looks like \"%s\" %s %s (found %d payments, last one on %s) — create subscription?",
+		r.Name, r.Amount, r.Period, len(r.OperationIDs), r.LastPaymentAt.Format("02/01/2006"),
+	)
+}
+
+// GetNextPaymentDate returns the date of the next expected payment.
+func (r RecurringPayment) GetNextPaymentDate() time.Time {
+	return CalculateScheduledOperationBillingDates(r.Period, r.LastPaymentAt, 2)[1]
+}
+
+// recurringPaymentRule describes how to recognize payments for a subscription period.
+type recurringPaymentRule struct {
+	period         SubscriptionPeriod
+	minIntervalDay int
+	maxIntervalDay int
+	minOccurrences int
+}
+
+var recurringPaymentRules = []recurringPaymentRule{
+	{period: SubscriptionPeriodWeekly, minIntervalDay: 6, maxIntervalDay: 8, minOccurrences: 3},
+	{period: SubscriptionPeriodMonthly, minIntervalDay: 27, maxIntervalDay: 32, minOccurrences: 3},
+	{period: SubscriptionPeriodYearly, minIntervalDay: 360, maxIntervalDay: 370, minOccurrences: 2},
+}
+
+// DetectRecurringPayments scans spending operations for repeating payments with the same
+// amount and description at weekly, monthly or yearly intervals.
+// Operations that are already linked to a balance subscription are ignored.
+// Returns recurring payments sorted by the last payment date, the most recent first.
+func DetectRecurringPayments(operations []Operation) []RecurringPayment {
+	type groupKey struct {
+		balanceID   string
+		description string
+		amount      string
+	}
+
+	groups := make(map[groupKey][]Operation)
+	for _, operation := range operations {
+		if operation.Type != OperationTypeSpending || operation.BalanceSubscriptionID != "" {
+			continue
+		}
+
+		description := strings.ToLower(strings.TrimSpace(operation.Description))
+		if description == "" {
+			continue
+		}
+
+		key := groupKey{
+			balanceID:   operation.BalanceID,
+			description: description,
+			amount:      operation.Amount,
+		}
+		groups[key] = append(groups[key], operation)
+	}
+
+	recurringPayments := make([]RecurringPayment, 0)
+	for _, groupedOperations := range groups {
+		slices.SortFunc(groupedOperations, func(a, b Operation) int {
+			return a.CreatedAt.Compare(b.CreatedAt)
+		})
+
+		for _, rule := range recurringPaymentRules {
+			if !rule.matches(groupedOperations) {
+				continue
+			}
+
+			lastOperation := groupedOperations[len(groupedOperations)-1]
+			recurringPayments = append(recurringPayments, RecurringPayment{
+				BalanceID:     lastOperation.BalanceID,
+				CategoryID:    lastOperation.CategoryID,
+				Name:          strings.TrimSpace(lastOperation.Description),
+				Amount:        lastOperation.Amount,
+				Period:        rule.period,
+				OperationIDs:  extractOperationIDs(groupedOperations),
+				LastPaymentAt: lastOperation.CreatedAt,
+			})
+
+			break
+		}
+	}
+
+	slices.SortFunc(recurringPayments, func(a, b RecurringPayment) int {
+		return b.LastPaymentAt.Compare(a.LastPaymentAt)
+	})
+
+	return recurringPayments
+}
+
+// matches checks if the majority of intervals between operations fit the rule, so a single
+// missed or duplicated charge doesn't hide a real subscription.
+func (r recurringPaymentRule) matches(sortedOperations []Operation) bool {
+	if len(sortedOperations) < r.minOccurrences {
+		return false
+	}
+
+	intervalsCount := len(sortedOperations) - 1
+	matchedIntervalsCount := 0
+	for i := 1; i < len(sortedOperations); i++ {
+		intervalInDays := int(sortedOperations[i].CreatedAt.Sub(sortedOperations[i-1].CreatedAt).Hours() / 24)
+		if intervalInDays >= r.minIntervalDay && intervalInDays <= r.maxIntervalDay {
+			matchedIntervalsCount++
+		}
+	}
+
+	return matchedIntervalsCount*2 > intervalsCount
+}
+
+func extractOperationIDs(operations []Operation) []string {
+	ids := make([]string, 0, len(operations))
+	for _, operation := range operations {
+		ids = append(ids, operation.ID)
+	}
+
+	return ids
+}
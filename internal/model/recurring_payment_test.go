@@ -0,0 +1,132 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectRecurringPayments(t *testing.T) {
+	t.Parallel()
+
+	startDate := time.Date(2025, time.January, 15, 10, 0, 0, 0, time.UTC)
+
+	testCases := [...]struct {
+		desc       string
+		operations []model.Operation
+		expected   []model.RecurringPayment
+	}{
+		{
+			desc: "detected monthly recurring payment",
+			operations: []model.Operation{
+				{ID: "1", BalanceID: "b1", CategoryID: "c1", Type: model.OperationTypeSpending, Amount: "299.00", Description: "Netflix", CreatedAt: startDate},
+				{ID: "2", BalanceID: "b1", CategoryID: "c1", Type: model.OperationTypeSpending, Amount: "299.00", Description: "netflix ", CreatedAt: startDate.AddDate(0, 1, 0)},
+				{ID: "3", BalanceID: "b1", CategoryID: "c1", Type: model.OperationTypeSpending, Amount: "299.00", Description: "Netflix", CreatedAt: startDate.AddDate(0, 2, 0)},
+				{ID: "4", BalanceID: "b1", CategoryID: "c1", Type: model.OperationTypeSpending, Amount: "50.00", Description: "Food", CreatedAt: startDate.AddDate(0, 0, 3)},
+			},
+			expected: []model.RecurringPayment{
+				{
+					BalanceID:     "b1",
+					CategoryID:    "c1",
+					Name:          "Netflix",
+					Amount:        "299.00",
+					Period:        model.SubscriptionPeriodMonthly,
+					OperationIDs:  []string{"1", "2", "3"},
+					LastPaymentAt: startDate.AddDate(0, 2, 0),
+				},
+			},
+		},
+		{
+			desc: "detected weekly and yearly recurring payments",
+			operations: []model.Operation{
+				{ID: "1", BalanceID: "b1", Type: model.OperationTypeSpending, Amount: "10.00", Description: "Gym", CreatedAt: startDate},
+				{ID: "2", BalanceID: "b1", Type: model.OperationTypeSpending, Amount: "10.00", Description: "Gym", CreatedAt: startDate.AddDate(0, 0, 7)},
+				{ID: "3", BalanceID: "b1", Type: model.OperationTypeSpending, Amount: "10.00", Description: "Gym", CreatedAt: startDate.AddDate(0, 0, 14)},
+				{ID: "4", BalanceID: "b1", Type: model.OperationTypeSpending, Amount: "99.00", Description: "Domain", CreatedAt: startDate.AddDate(-1, 0, 0)},
+				{ID: "5", BalanceID: "b1", Type: model.OperationTypeSpending, Amount: "99.00", Description: "Domain", CreatedAt: startDate},
+			},
+			expected: []model.RecurringPayment{
+				{
+					BalanceID:     "b1",
+					Name:          "Gym",
+					Amount:        "10.00",
+					Period:        model.SubscriptionPeriodWeekly,
+					OperationIDs:  []string{"1", "2", "3"},
+					LastPaymentAt: startDate.AddDate(0, 0, 14),
+				},
+				{
+					BalanceID:     "b1",
+					Name:          "Domain",
+					Amount:        "99.00",
+					Period:        model.SubscriptionPeriodYearly,
+					OperationIDs:  []string{"4", "5"},
+					LastPaymentAt: startDate,
+				},
+			},
+		},
+		{
+			desc: "detected monthly recurring payment with one skipped month",
+			operations: []model.Operation{
+				{ID: "1", BalanceID: "b1", Type: model.OperationTypeSpending, Amount: "9.99", Description: "Spotify", CreatedAt: startDate},
+				{ID: "2", BalanceID: "b1", Type: model.OperationTypeSpending, Amount: "9.99", Description: "Spotify", CreatedAt: startDate.AddDate(0, 1, 0)},
+				{ID: "3", BalanceID: "b1", Type: model.OperationTypeSpending, Amount: "9.99", Description: "Spotify", CreatedAt: startDate.AddDate(0, 2, 0)},
+				{ID: "4", BalanceID: "b1", Type: model.OperationTypeSpending, Amount: "9.99", Description: "Spotify", CreatedAt: startDate.AddDate(0, 4, 0)},
+			},
+			expected: []model.RecurringPayment{
+				{
+					BalanceID:     "b1",
+					Name:          "Spotify",
+					Amount:        "9.99",
+					Period:        model.SubscriptionPeriodMonthly,
+					OperationIDs:  []string{"1", "2", "3", "4"},
+					LastPaymentAt: startDate.AddDate(0, 4, 0),
+				},
+			},
+		},
+		{
+			desc: "detected monthly recurring payment with one duplicated charge",
+			operations: []model.Operation{
+				{ID: "1", BalanceID: "b1", Type: model.OperationTypeSpending, Amount: "9.99", Description: "Spotify", CreatedAt: startDate},
+				{ID: "2", BalanceID: "b1", Type: model.OperationTypeSpending, Amount: "9.99", Description: "Spotify", CreatedAt: startDate.AddDate(0, 1, 0)},
+				{ID: "3", BalanceID: "b1", Type: model.OperationTypeSpending, Amount: "9.99", Description: "Spotify", CreatedAt: startDate.AddDate(0, 1, 1)},
+				{ID: "4", BalanceID: "b1", Type: model.OperationTypeSpending, Amount: "9.99", Description: "Spotify", CreatedAt: startDate.AddDate(0, 2, 0)},
+				{ID: "5", BalanceID: "b1", Type: model.OperationTypeSpending, Amount: "9.99", Description: "Spotify", CreatedAt: startDate.AddDate(0, 3, 0)},
+			},
+			expected: []model.RecurringPayment{
+				{
+					BalanceID:     "b1",
+					Name:          "Spotify",
+					Amount:        "9.99",
+					Period:        model.SubscriptionPeriodMonthly,
+					OperationIDs:  []string{"1", "2", "3", "4", "5"},
+					LastPaymentAt: startDate.AddDate(0, 3, 0),
+				},
+			},
+		},
+		{
+			desc: "ignored operations with irregular intervals, linked subscriptions and incoming type",
+			operations: []model.Operation{
+				{ID: "1", BalanceID: "b1", Type: model.OperationTypeSpending, Amount: "5.00", Description: "Coffee", CreatedAt: startDate},
+				{ID: "2", BalanceID: "b1", Type: model.OperationTypeSpending, Amount: "5.00", Description: "Coffee", CreatedAt: startDate.AddDate(0, 0, 2)},
+				{ID: "3", BalanceID: "b1", Type: model.OperationTypeSpending, Amount: "5.00", Description: "Coffee", CreatedAt: startDate.AddDate(0, 0, 20)},
+				{ID: "4", BalanceID: "b1", BalanceSubscriptionID: "s1", Type: model.OperationTypeSpending, Amount: "299.00", Description: "Netflix", CreatedAt: startDate},
+				{ID: "5", BalanceID: "b1", BalanceSubscriptionID: "s1", Type: model.OperationTypeSpending, Amount: "299.00", Description: "Netflix", CreatedAt: startDate.AddDate(0, 1, 0)},
+				{ID: "6", BalanceID: "b1", BalanceSubscriptionID: "s1", Type: model.OperationTypeSpending, Amount: "299.00", Description: "Netflix", CreatedAt: startDate.AddDate(0, 2, 0)},
+				{ID: "7", BalanceID: "b1", Type: model.OperationTypeIncoming, Amount: "1000.00", Description: "Salary", CreatedAt: startDate},
+				{ID: "8", BalanceID: "b1", Type: model.OperationTypeIncoming, Amount: "1000.00", Description: "Salary", CreatedAt: startDate.AddDate(0, 1, 0)},
+				{ID: "9", BalanceID: "b1", Type: model.OperationTypeIncoming, Amount: "1000.00", Description: "Salary", CreatedAt: startDate.AddDate(0, 2, 0)},
+			},
+			expected: []model.RecurringPayment{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual := model.DetectRecurringPayments(tc.operations)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -69,7 +69,11 @@ func (s *State) IsCommandAllowedDuringFlow(command string) bool {
 	case UpdateUserSettingsFlow:
 		if s.GetCurrentStep() == ChooseUpdateUserSettingsOptionFlowStep {
 			return slices.Contains(
-				[]string{BotUpdateUserAIParserCommand, BotUpdateUserSubscriptionNotificationsCommand},
+				[]string{
+					BotUpdateUserAIParserCommand, BotUpdateUserSubscriptionNotificationsCommand,
+					BotUpdateUserSubscriptionNotificationLeadTimeCommand, BotUpdateUserBaseCurrencyCommand,
+					BotUpdateUserDigestCommand,
+				},
 				command,
 			)
 		}
@@ -81,6 +85,55 @@ func (s *State) IsCommandAllowedDuringFlow(command string) bool {
 			)
 		}
 
+		if s.GetCurrentStep() == UpdateBaseCurrencyUserSettingFlowStep {
+			return slices.Contains(
+				[]string{BotPreviousCommand, BotNextCommand},
+				command,
+			)
+		}
+
+		return false
+
+	case CreateCategoryFlow:
+		switch s.GetCurrentStep() {
+		case ChooseCategoryKindFlowStep:
+			return slices.Contains(
+				[]string{BotIncomeCategoryKindCommand, BotExpenseCategoryKindCommand, BotIncomeAndExpenseCategoryKindCommand},
+				command,
+			)
+		case ChooseParentCategoryFlowStep:
+			return slices.Contains(
+				[]string{BotWithoutParentCategoryCommand},
+				command,
+			)
+		}
+
+		return false
+
+	case UpdateCategoryFlow:
+		switch s.GetCurrentStep() {
+		case ChooseUpdateCategoryOptionFlowStep:
+			return slices.Contains(
+				[]string{BotUpdateCategoryNameCommand, BotUpdateCategoryKindCommand, BotArchiveCategoryCommand},
+				command,
+			)
+		case ChooseCategoryKindFlowStep:
+			return slices.Contains(
+				[]string{BotIncomeCategoryKindCommand, BotExpenseCategoryKindCommand, BotIncomeAndExpenseCategoryKindCommand},
+				command,
+			)
+		}
+
+		return false
+
+	case ListCategoriesFlow:
+		if s.GetCurrentStep() == ChooseCategoriesListOptionFlowStep {
+			return slices.Contains(
+				[]string{BotReorderCategoriesCommand, BotUnarchiveCategoryCommand},
+				command,
+			)
+		}
+
 		return false
 
 	case CreateOperationFlow:
@@ -125,6 +178,7 @@ func (s *State) IsCommandAllowedDuringFlow(command string) bool {
 				[]string{
 					BotUpdateOperationAmountCommand, BotUpdateOperationDescriptionCommand,
 					BotUpdateOperationCategoryCommand, BotUpdateOperationDateCommand,
+					BotCreateCategoryRuleFromOperationCommand,
 				},
 				command,
 			)
@@ -161,6 +215,17 @@ func (s *State) IsCommandAllowedDuringFlow(command string) bool {
 
 		return false
 
+	case CreateBalanceSubscriptionFlow:
+		switch s.GetCurrentStep() {
+		case ChooseBalanceSubscriptionCurrencyFlowStep:
+			return slices.Contains(
+				[]string{BotPreviousCommand, BotNextCommand},
+				command,
+			)
+		}
+
+		return false
+
 	case ListBalanceSubscriptionFlow:
 		switch s.GetCurrentStep() {
 		case ChooseBalanceFlowStep:
@@ -185,9 +250,16 @@ func (s *State) IsCommandAllowedDuringFlow(command string) bool {
 				[]string{
 					BotUpdateBalanceSubscriptionNameCommand, BotUpdateBalanceSubscriptionAmountCommand,
 					BotUpdateBalanceSubscriptionCategoryCommand, BotUpdateBalanceSubscriptionPeriodCommand,
+					BotUpdateBalanceSubscriptionNotificationLeadTimeCommand, BotUpdateBalanceSubscriptionCurrencyCommand,
 				},
 				command,
 			)
+
+		case ChooseBalanceSubscriptionCurrencyFlowStep:
+			return slices.Contains(
+				[]string{BotPreviousCommand, BotNextCommand},
+				command,
+			)
 		}
 
 		return false
@@ -230,8 +302,13 @@ func (s *State) GetEvent() Event {
 	case UpdateUserSettingsFlowStep:
 		return UpdateUserSettingsEvent
 
+	// Start
+	// Start flow begins with choosing category template, after that the initial balance is created.
+	case ChooseCategoryTemplateFlowStep, CreateInitialBalanceFlowStep:
+		return OnboardingEvent
+
 	// Balance
-	case CreateInitialBalanceFlowStep, CreateBalanceFlowStep:
+	case CreateBalanceFlowStep:
 		return CreateBalanceEvent
 	case UpdateBalanceFlowStep:
 		return UpdateBalanceEvent
@@ -239,6 +316,18 @@ func (s *State) GetEvent() Event {
 		return GetBalanceEvent
 	case DeleteBalanceFlowStep:
 		return DeleteBalanceEvent
+	case GetNetWorthFlowStep:
+		return GetNetWorthEvent
+	case CompareBalancePeriodsFlowStep:
+		return CompareBalancePeriodsEvent
+
+	// Currency
+	case CreateCurrencyFlowStep:
+		return CreateCurrencyEvent
+	case ListCurrenciesFlowStep:
+		return ListCurrenciesEvent
+	case SetCurrencyRateFlowStep:
+		return SetCurrencyRateEvent
 
 	// Category
 	case CreateCategoryFlowStep:
@@ -249,6 +338,16 @@ func (s *State) GetEvent() Event {
 		return UpdateCategoryEvent
 	case DeleteCategoryFlowStep:
 		return DeleteCategoryEvent
+	case MergeCategoriesFlowStep:
+		return MergeCategoriesEvent
+	case CreateCategoryRuleFlowStep:
+		return CreateCategoryRuleEvent
+	case ListCategoryRulesFlowStep:
+		return ListCategoryRulesEvent
+	case DeleteCategoryRuleFlowStep:
+		return DeleteCategoryRuleEvent
+	case ApplyCategoryTemplateFlowStep:
+		return ApplyCategoryTemplateEvent
 
 	// Operation
 	case CreateOperationFlowStep:
@@ -271,6 +370,12 @@ func (s *State) GetEvent() Event {
 		return UpdateBalanceSubscriptionEvent
 	case DeleteBalanceSubscriptionFlowStep:
 		return DeleteBalanceSubscriptionEvent
+	case DetectRecurringPaymentsFlowStep:
+		return DetectRecurringPaymentsEvent
+	case GetBalanceSubscriptionsSummaryFlowStep:
+		return GetBalanceSubscriptionsSummaryEvent
+	case ExportBalanceSubscriptionsCalendarFlowStep:
+		return ExportBalanceSubscriptionsCalendarEvent
 	default:
 		return UnknownEvent
 	}
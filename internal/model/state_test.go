@@ -0,0 +1,79 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_GetEvent(t *testing.T) {
+	t.Parallel()
+
+	testCases := [...]struct {
+		desc     string
+		state    model.State
+		expected model.Event
+	}{
+		{
+			desc: "start flow on choosing category template",
+			state: model.State{
+				Flow:  model.StartFlow,
+				Steps: []model.FlowStep{model.StartFlowStep, model.ChooseCategoryTemplateFlowStep},
+			},
+			expected: model.OnboardingEvent,
+		},
+		{
+			desc: "start flow on creating initial balance after choosing category template",
+			state: model.State{
+				Flow: model.StartFlow,
+				Steps: []model.FlowStep{
+					model.StartFlowStep, model.ChooseCategoryTemplateFlowStep, model.CreateInitialBalanceFlowStep,
+				},
+			},
+			expected: model.OnboardingEvent,
+		},
+		{
+			desc: "create balance flow",
+			state: model.State{
+				Flow:  model.CreateBalanceFlow,
+				Steps: []model.FlowStep{model.StartFlowStep, model.CreateBalanceFlowStep},
+			},
+			expected: model.CreateBalanceEvent,
+		},
+		{
+			desc: "apply category template flow on choosing category template",
+			state: model.State{
+				Flow: model.ApplyCategoryTemplateFlow,
+				Steps: []model.FlowStep{
+					model.StartFlowStep, model.ApplyCategoryTemplateFlowStep, model.ChooseCategoryTemplateFlowStep,
+				},
+			},
+			expected: model.ApplyCategoryTemplateEvent,
+		},
+		{
+			desc: "cancel flow",
+			state: model.State{
+				Flow:  model.CancelFlow,
+				Steps: []model.FlowStep{model.StartFlowStep},
+			},
+			expected: model.CancelEvent,
+		},
+		{
+			desc: "unknown step",
+			state: model.State{
+				Flow:  model.StartFlow,
+				Steps: []model.FlowStep{model.StartFlowStep, model.EndFlowStep},
+			},
+			expected: model.UnknownEvent,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.state.GetEvent())
+		})
+	}
+}
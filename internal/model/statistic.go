@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-	"time"
 
 	"github.com/VladPetriv/finance_bot/pkg/money"
 )
@@ -31,7 +30,7 @@ func NewStatisticsMessageBuilder(balance *Balance, operations []Operation, categ
 
 // Build generates a formatted message string containing financial statistics.
 // It includes balance information, period details, and breakdowns of operations by type and category.
-func (b *StatisticsMessageBuilder) Build(month Month) (string, error) {
+func (b *StatisticsMessageBuilder) Build(period Period) (string, error) {
 	stats, err := calculateOperationsStatistics(b.operations)
 	if err != nil {
 		return "", fmt.Errorf("error calculating statistics: %w", err)
@@ -39,7 +38,7 @@ func (b *StatisticsMessageBuilder) Build(month Month) (string, error) {
 
 	return b.
 		addHeader().
-		addPeriod(month).
+		addPeriod(period).
 		addOperationsAndCategoriesStatistics(stats).buffer.String(), nil
 }
 
@@ -50,13 +49,13 @@ func (b *StatisticsMessageBuilder) addHeader() *StatisticsMessageBuilder {
 💰 Current Balance: %s
 
 `
-	b.buffer.WriteString(fmt.Sprintf(template, b.balance.Name, formatAmount(balanceAmount, b.balance.GetCurrency().Symbol)))
+	b.buffer.WriteString(fmt.Sprintf(template, b.balance.Name, formatAmount(balanceAmount, b.balance.GetCurrency())))
 
 	return b
 }
 
-func (b *StatisticsMessageBuilder) addPeriod(month Month) *StatisticsMessageBuilder {
-	b.buffer.WriteString(formatPeriod(month))
+func (b *StatisticsMessageBuilder) addPeriod(period Period) *StatisticsMessageBuilder {
+	b.buffer.WriteString(formatPeriod(period))
 	b.buffer.WriteString(`
 
 `)
@@ -73,7 +72,7 @@ func (b *StatisticsMessageBuilder) addOperationsAndCategoriesStatistics(stats *o
 	b.buffer.WriteString(
 		fmt.Sprintf(
 			incomingOperationTemplate,
-			formatAmount(stats.IncomingTotal, b.balance.GetCurrency().Symbol),
+			formatAmount(stats.IncomingTotal, b.balance.GetCurrency()),
 			stats.IncomingCount,
 		),
 	)
@@ -86,7 +85,7 @@ func (b *StatisticsMessageBuilder) addOperationsAndCategoriesStatistics(stats *o
 	b.buffer.WriteString(
 		fmt.Sprintf(
 			spendingOperationTemplate,
-			formatAmount(stats.SpendingTotal, b.balance.GetCurrency().Symbol),
+			formatAmount(stats.SpendingTotal, b.balance.GetCurrency()),
 			stats.SpendingCount,
 		),
 	)
@@ -104,10 +103,10 @@ func (b *StatisticsMessageBuilder) addOperationsAndCategoriesStatistics(stats *o
 			transferOperationTemplate,
 			totalTransfers,
 
-			formatAmount(stats.TransferInTotal, b.balance.GetCurrency().Symbol),
+			formatAmount(stats.TransferInTotal, b.balance.GetCurrency()),
 			stats.TransferInCount,
 
-			formatAmount(stats.TransferOutTotal, b.balance.GetCurrency().Symbol),
+			formatAmount(stats.TransferOutTotal, b.balance.GetCurrency()),
 			stats.TransferOutCount,
 		),
 	)
@@ -121,6 +120,8 @@ func (b *StatisticsMessageBuilder) buildCategoriesStatisticsMessage(categoriesSt
 	templateForFirstElement := `			- %s: %s *(%s%%)*`
 	regularTemplate := `
 			- %s: %s *(%s%%)*`
+	subcategoryTemplate := `
+				◦ %s: %s *(%s%%)*`
 
 	for index, category := range categoriesStat {
 		template := regularTemplate
@@ -133,10 +134,21 @@ func (b *StatisticsMessageBuilder) buildCategoriesStatisticsMessage(categoriesSt
 			fmt.Sprintf(
 				template,
 				category.Title,
-				formatAmount(category.Amount, b.balance.GetCurrency().Symbol),
+				formatAmount(category.Amount, b.balance.GetCurrency()),
 				category.Percentage.StringFixed(),
 			),
 		)
+
+		for _, subcategory := range category.Subcategories {
+			builder.WriteString(
+				fmt.Sprintf(
+					subcategoryTemplate,
+					subcategory.Title,
+					formatAmount(subcategory.Amount, b.balance.GetCurrency()),
+					subcategory.Percentage.StringFixed(),
+				),
+			)
+		}
 	}
 
 	if len(categoriesStat) > 0 {
@@ -148,8 +160,8 @@ func (b *StatisticsMessageBuilder) buildCategoriesStatisticsMessage(categoriesSt
 	return builder.String()
 }
 
-func formatAmount(amount money.Money, currency string) string {
-	return formatInlineCode(amount.StringFixed() + currency)
+func formatAmount(amount money.Money, currency Currency) string {
+	return formatInlineCode(amount.Format(currency.GetMoneyFormat()))
 }
 
 func formatInlineCode(s string) string {
@@ -158,12 +170,10 @@ func formatInlineCode(s string) string {
 
 const dateFormat = "02 Jan 2006"
 
-func formatPeriod(month Month) string {
+func formatPeriod(period Period) string {
 	template := "📅 Period: _%s - %s_"
 
-	startTime, endTime := month.GetTimeRange(time.Now())
-
-	return fmt.Sprintf(template, startTime.Format(dateFormat), endTime.Format(dateFormat))
+	return fmt.Sprintf(template, period.From.Format(dateFormat), period.To.Format(dateFormat))
 }
 
 type operationsStatistics struct {
@@ -222,52 +232,85 @@ type categoryStatistics struct {
 	Title      string
 	Amount     money.Money
 	Percentage money.Money
+	// Subcategories contains statistics of sub-categories, which amounts are already included into Amount.
+	Subcategories []categoryStatistics
 }
 
 func calculateCategoryStatistics(totalAmount money.Money, operations []Operation, categories []Category) ([]categoryStatistics, error) {
-	categoryStats := make(map[string]*categoryStatistics)
+	amountByCategoryID := make(map[string]money.Money)
 
-	for _, category := range categories {
-		categoryStats[category.Title] = &categoryStatistics{
-			Title:      category.Title,
-			Amount:     money.Zero,
-			Percentage: money.Zero,
+	for _, operation := range operations {
+		if !hasCategory(categories, operation.CategoryID) {
+			continue
 		}
+
+		amount, err := money.NewFromString(operation.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operation amount: %w", err)
+		}
+
+		categoryAmount, ok := amountByCategoryID[operation.CategoryID]
+		if !ok {
+			categoryAmount = money.Zero
+		}
+		categoryAmount.Inc(amount)
+		amountByCategoryID[operation.CategoryID] = categoryAmount
 	}
 
-	for _, operation := range operations {
-		for _, category := range categories {
-			if operation.CategoryID == category.ID {
-				amount, err := money.NewFromString(operation.Amount)
-				if err != nil {
-					return nil, fmt.Errorf("invalid operation amount: %w", err)
-				}
-
-				stats := categoryStats[category.Title]
-				stats.Amount.Inc(amount)
+	result := make([]categoryStatistics, 0, len(categories))
+	for _, node := range BuildCategoryTree(categories) {
+		stats := newCategoryStatistics(node.Title, amountByCategoryID[node.ID])
+
+		for _, subcategory := range node.Subcategories {
+			subcategoryAmount, ok := amountByCategoryID[subcategory.ID]
+			if !ok || subcategoryAmount.Equal(money.Zero) {
+				continue
 			}
+
+			stats.Amount.Inc(subcategoryAmount)
+			stats.Subcategories = append(stats.Subcategories, newCategoryStatistics(subcategory.Title, subcategoryAmount))
 		}
-	}
 
-	result := make([]categoryStatistics, 0, len(categoryStats))
-	for _, stats := range categoryStats {
-		if stats.Amount == money.Zero {
+		if stats.Amount.Equal(money.Zero) {
 			continue
 		}
 
-		hundred := money.NewFromInt(100)
-		percentageAmount := stats.Amount
-		percentageAmount.Mul(hundred)
-		percentageAmount.Div(totalAmount)
-
-		stats.Percentage = percentageAmount
+		stats.Percentage = calculatePercentage(stats.Amount, totalAmount)
+		for i := range stats.Subcategories {
+			stats.Subcategories[i].Percentage = calculatePercentage(stats.Subcategories[i].Amount, totalAmount)
+		}
+		sortCategoryStatistics(stats.Subcategories)
 
-		result = append(result, *stats)
+		result = append(result, stats)
 	}
 
-	sort.Slice(result, func(i, j int) bool {
-		return result[i].Percentage.GreaterThan(result[j].Percentage)
-	})
+	sortCategoryStatistics(result)
 
 	return result, nil
 }
+
+func newCategoryStatistics(title string, amount money.Money) categoryStatistics {
+	stats := categoryStatistics{
+		Title:      title,
+		Amount:     money.Zero,
+		Percentage: money.Zero,
+	}
+	stats.Amount.Inc(amount)
+
+	return stats
+}
+
+func calculatePercentage(amount, totalAmount money.Money) money.Money {
+	hundred := money.NewFromInt(100)
+	percentageAmount := amount
+	percentageAmount.Mul(hundred)
+	percentageAmount.Div(totalAmount)
+
+	return percentageAmount
+}
+
+func sortCategoryStatistics(stats []categoryStatistics) {
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].Percentage.GreaterThan(stats[j].Percentage)
+	})
+}
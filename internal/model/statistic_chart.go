@@ -0,0 +1,171 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/pkg/money"
+)
+
+// chartDateFormat is the format of dates used as labels on statistics charts.
+const chartDateFormat = "02 Jan"
+
+// ChartValue represents a single labeled amount on the statistics chart.
+type ChartValue struct {
+	Label  string
+	Amount money.Money
+}
+
+// StatisticsChartData represents the data which is used for drawing charts of balance statistics.
+type StatisticsChartData struct {
+	// SpendingByCategory contains spending amounts of top-level categories,
+	// amounts of sub-categories are included into their parents.
+	SpendingByCategory []ChartValue
+	// DailySpending contains spending amount for each day of the period.
+	DailySpending []ChartValue
+	// BalanceHistory contains the balance amount at the end of each day of the period.
+	BalanceHistory []ChartValue
+}
+
+// BuildStatisticsChartData calculates the data for statistics charts of the balance for the period.
+// Operations should contain all operations of the balance created since the start of the period,
+// including the ones created after its end, since they're needed to restore the balance amount in the past.
+// Days after now are not included into the charts.
+func BuildStatisticsChartData(balance *Balance, operations []Operation, categories []Category, period Period, now time.Time) (*StatisticsChartData, error) {
+	days := getChartDays(period, now)
+
+	periodOperations := make([]Operation, 0, len(operations))
+	for _, operation := range operations {
+		if operation.CreatedAt.Before(period.From) || operation.CreatedAt.After(period.To) {
+			continue
+		}
+
+		periodOperations = append(periodOperations, operation)
+	}
+
+	stats, err := calculateOperationsStatistics(periodOperations)
+	if err != nil {
+		return nil, fmt.Errorf("error calculating statistics: %w", err)
+	}
+
+	spendingCategoriesStatistics, err := calculateCategoryStatistics(stats.SpendingTotal, stats.OperationsByType[OperationTypeSpending], categories)
+	if err != nil {
+		return nil, fmt.Errorf("error calculating spending categories statistics: %w", err)
+	}
+
+	spendingByCategory := make([]ChartValue, 0, len(spendingCategoriesStatistics))
+	for _, categoryStatistics := range spendingCategoriesStatistics {
+		spendingByCategory = append(spendingByCategory, ChartValue{
+			Label:  categoryStatistics.Title,
+			Amount: categoryStatistics.Amount,
+		})
+	}
+
+	dailySpending, err := calculateDailySpending(days, stats.OperationsByType[OperationTypeSpending])
+	if err != nil {
+		return nil, fmt.Errorf("error calculating daily spending: %w", err)
+	}
+
+	balanceHistory, err := calculateBalanceHistory(days, balance, operations)
+	if err != nil {
+		return nil, fmt.Errorf("error calculating balance history: %w", err)
+	}
+
+	return &StatisticsChartData{
+		SpendingByCategory: spendingByCategory,
+		DailySpending:      dailySpending,
+		BalanceHistory:     balanceHistory,
+	}, nil
+}
+
+// getChartDays returns the start of each day of the period which already started.
+func getChartDays(period Period, now time.Time) []time.Time {
+	var days []time.Time
+	for day := period.From; !day.After(period.To) && !day.After(now); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+
+	return days
+}
+
+func calculateDailySpending(days []time.Time, spendingOperations []Operation) ([]ChartValue, error) {
+	amountByDay := make(map[string]money.Money, len(days))
+	for _, operation := range spendingOperations {
+		amount, err := money.NewFromString(operation.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operation amount: %w", err)
+		}
+
+		day := operation.CreatedAt.UTC().Format(time.DateOnly)
+
+		dayAmount, ok := amountByDay[day]
+		if !ok {
+			dayAmount = money.Zero
+		}
+		dayAmount.Inc(amount)
+		amountByDay[day] = dayAmount
+	}
+
+	dailySpending := make([]ChartValue, 0, len(days))
+	for _, day := range days {
+		amount, ok := amountByDay[day.Format(time.DateOnly)]
+		if !ok {
+			amount = money.Zero
+		}
+
+		dailySpending = append(dailySpending, ChartValue{
+			Label:  day.Format(chartDateFormat),
+			Amount: amount,
+		})
+	}
+
+	return dailySpending, nil
+}
+
+// calculateBalanceHistory restores the balance amount at the end of each day, starting from the current amount
+// and reverting operations which were created after the day in reverse order.
+func calculateBalanceHistory(days []time.Time, balance *Balance, operations []Operation) ([]ChartValue, error) {
+	amount, err := money.NewFromString(balance.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid balance amount: %w", err)
+	}
+
+	sortedOperations := make([]Operation, len(operations))
+	copy(sortedOperations, operations)
+	sort.SliceStable(sortedOperations, func(i, j int) bool {
+		return sortedOperations[i].CreatedAt.After(sortedOperations[j].CreatedAt)
+	})
+
+	balanceHistory := make([]ChartValue, len(days))
+	operationIndex := 0
+	for i := len(days) - 1; i >= 0; i-- {
+		nextDay := days[i].AddDate(0, 0, 1)
+
+		for ; operationIndex < len(sortedOperations); operationIndex++ {
+			operation := sortedOperations[operationIndex]
+			if operation.CreatedAt.Before(nextDay) {
+				break
+			}
+
+			operationAmount, err := money.NewFromString(operation.Amount)
+			if err != nil {
+				return nil, fmt.Errorf("invalid operation amount: %w", err)
+			}
+
+			switch operation.Type {
+			case OperationTypeIncoming, OperationTypeTransferIn:
+				amount.Sub(operationAmount)
+			case OperationTypeSpending, OperationTypeTransferOut:
+				amount.Inc(operationAmount)
+			}
+		}
+
+		balanceHistory[i] = ChartValue{
+			Label:  days[i].Format(chartDateFormat),
+			Amount: amount,
+		}
+	}
+
+	return balanceHistory, nil
+}
@@ -0,0 +1,95 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/VladPetriv/finance_bot/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildStatisticsChartData(t *testing.T) {
+	t.Parallel()
+
+	balance := &model.Balance{
+		Name:   "Main Balance",
+		Amount: "1000.00",
+	}
+	categories := []model.Category{
+		{ID: "food", Title: "Food"},
+		{ID: "restaurants", Title: "Restaurants", ParentID: "food"},
+		{ID: "taxi", Title: "Taxi"},
+	}
+	period := model.NewCustomPeriod(
+		time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.January, 5, 0, 0, 0, 0, time.UTC),
+	)
+	now := time.Date(2025, time.January, 4, 12, 0, 0, 0, time.UTC)
+
+	operations := []model.Operation{
+		{
+			Type:       model.OperationTypeSpending,
+			Amount:     "100.00",
+			CategoryID: "food",
+			CreatedAt:  time.Date(2025, time.January, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			Type:       model.OperationTypeSpending,
+			Amount:     "50.00",
+			CategoryID: "restaurants",
+			CreatedAt:  time.Date(2025, time.January, 1, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			Type:       model.OperationTypeIncoming,
+			Amount:     "500.00",
+			CategoryID: "salary",
+			CreatedAt:  time.Date(2025, time.January, 2, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			Type:       model.OperationTypeSpending,
+			Amount:     "30.00",
+			CategoryID: "taxi",
+			CreatedAt:  time.Date(2025, time.January, 3, 23, 59, 59, 500, time.UTC),
+		},
+		// Operation created after the period end is used only for restoring the balance amount.
+		{
+			Type:      model.OperationTypeTransferIn,
+			Amount:    "200.00",
+			CreatedAt: time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	expected := &model.StatisticsChartData{
+		SpendingByCategory: []model.ChartValue{
+			{Label: "Food", Amount: money.NewFromInt(150)},
+			{Label: "Taxi", Amount: money.NewFromInt(30)},
+		},
+		DailySpending: []model.ChartValue{
+			{Label: "01 Jan", Amount: money.NewFromInt(150)},
+			{Label: "02 Jan", Amount: money.Zero},
+			{Label: "03 Jan", Amount: money.NewFromInt(30)},
+			{Label: "04 Jan", Amount: money.Zero},
+		},
+		BalanceHistory: []model.ChartValue{
+			{Label: "01 Jan", Amount: money.NewFromInt(330)},
+			{Label: "02 Jan", Amount: money.NewFromInt(830)},
+			{Label: "03 Jan", Amount: money.NewFromInt(800)},
+			{Label: "04 Jan", Amount: money.NewFromInt(800)},
+		},
+	}
+
+	actual, err := model.BuildStatisticsChartData(balance, operations, categories, period, now)
+	assert.NoError(t, err)
+
+	assertChartValues := func(expected, actual []model.ChartValue) {
+		assert.Len(t, actual, len(expected))
+		for i := range expected {
+			assert.Equal(t, expected[i].Label, actual[i].Label)
+			assert.True(t, expected[i].Amount.Equal(actual[i].Amount), "%s: expected %s, got %s", expected[i].Label, expected[i].Amount, actual[i].Amount)
+		}
+	}
+	assertChartValues(expected.SpendingByCategory, actual.SpendingByCategory)
+	assertChartValues(expected.DailySpending, actual.DailySpending)
+	assertChartValues(expected.BalanceHistory, actual.BalanceHistory)
+}
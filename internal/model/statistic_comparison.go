@@ -0,0 +1,252 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/VladPetriv/finance_bot/pkg/money"
+)
+
+// maxBiggestMovers limits the number of categories shown as the biggest movers.
+const maxBiggestMovers = 3
+
+// PeriodOperations represents operations created during the period.
+type PeriodOperations struct {
+	Period     Period
+	Operations []Operation
+}
+
+// ComparisonMessageBuilder is responsible for building a formatted message which compares
+// balance statistics of two periods by operation types and categories.
+type ComparisonMessageBuilder struct {
+	balance    *Balance
+	categories []Category
+
+	buffer strings.Builder
+}
+
+// NewComparisonMessageBuilder creates a new instance of ComparisonMessageBuilder with the provided balance and categories.
+func NewComparisonMessageBuilder(balance *Balance, categories []Category) *ComparisonMessageBuilder {
+	return &ComparisonMessageBuilder{
+		balance:    balance,
+		categories: categories,
+	}
+}
+
+// Build generates a formatted message string which compares statistics of the current period with the compared one.
+// All changes are calculated relative to the compared period.
+func (b *ComparisonMessageBuilder) Build(current, compared PeriodOperations) (string, error) {
+	currentStats, err := calculateOperationsStatistics(current.Operations)
+	if err != nil {
+		return "", fmt.Errorf("error calculating statistics of current period: %w", err)
+	}
+
+	comparedStats, err := calculateOperationsStatistics(compared.Operations)
+	if err != nil {
+		return "", fmt.Errorf("error calculating statistics of compared period: %w", err)
+	}
+
+	incomingChanges, err := b.calculateCategoryChanges(OperationTypeIncoming, currentStats, comparedStats)
+	if err != nil {
+		return "", fmt.Errorf("error calculating incoming categories changes: %w", err)
+	}
+
+	spendingChanges, err := b.calculateCategoryChanges(OperationTypeSpending, currentStats, comparedStats)
+	if err != nil {
+		return "", fmt.Errorf("error calculating spending categories changes: %w", err)
+	}
+
+	b.addHeader(current.Period, compared.Period)
+
+	b.buffer.WriteString("📈 Summary:\n")
+	b.addOperationTypeComparison("📥 Incoming Operations", currentStats.IncomingTotal, comparedStats.IncomingTotal, incomingChanges)
+	b.addOperationTypeComparison("💸 Spending Operations", currentStats.SpendingTotal, comparedStats.SpendingTotal, spendingChanges)
+	b.addOperationTypeComparison("➡️ Transfers In", currentStats.TransferInTotal, comparedStats.TransferInTotal, nil)
+	b.addOperationTypeComparison("⬅️ Transfers Out", currentStats.TransferOutTotal, comparedStats.TransferOutTotal, nil)
+
+	b.addBiggestMovers(append(incomingChanges, spendingChanges...))
+
+	return b.buffer.String(), nil
+}
+
+func (b *ComparisonMessageBuilder) addHeader(current, compared Period) {
+	template := `📊 Balance Comparison: *%s*
+%s
+📅 Compared With: _%s - %s_
+
+`
+	b.buffer.WriteString(fmt.Sprintf(
+		template,
+		b.balance.Name,
+		formatPeriod(current),
+		compared.From.Format(dateFormat), compared.To.Format(dateFormat),
+	))
+}
+
+func (b *ComparisonMessageBuilder) addOperationTypeComparison(title string, current, compared money.Money, categoryChanges []categoryChange) {
+	b.buffer.WriteString(fmt.Sprintf("%s: %s\n", title, b.formatChange(current, compared)))
+
+	for _, change := range categoryChanges {
+		b.buffer.WriteString(fmt.Sprintf("			- %s: %s\n", change.Title, b.formatChange(change.Current, change.Compared)))
+	}
+}
+
+func (b *ComparisonMessageBuilder) addBiggestMovers(changes []categoryChange) {
+	movers := make([]categoryChange, 0, len(changes))
+	for _, change := range changes {
+		if !change.Difference.Equal(money.Zero) {
+			movers = append(movers, change)
+		}
+	}
+	if len(movers) == 0 {
+		return
+	}
+
+	sort.SliceStable(movers, func(i, j int) bool {
+		return movers[i].AbsoluteDifference.GreaterThan(movers[j].AbsoluteDifference)
+	})
+	if len(movers) > maxBiggestMovers {
+		movers = movers[:maxBiggestMovers]
+	}
+
+	b.buffer.WriteString("\n🚀 Biggest Movers:\n")
+	for _, mover := range movers {
+		icon := "🔺"
+		if mover.Difference.LessThan(money.Zero) {
+			icon = "🔻"
+		}
+
+		operationTypeIcon := "📥"
+		if mover.OperationType == OperationTypeSpending {
+			operationTypeIcon = "💸"
+		}
+
+		b.buffer.WriteString(fmt.Sprintf(
+			"			%s %s %s: %s\n",
+			icon, operationTypeIcon, mover.Title, b.formatDifference(mover.Current, mover.Compared),
+		))
+	}
+}
+
+// formatChange returns both amounts with the difference between them, e.g. "`150.00$` vs `100.00$` *(+50.00$, +50.00%)*".
+func (b *ComparisonMessageBuilder) formatChange(current, compared money.Money) string {
+	return fmt.Sprintf(
+		"%s vs %s %s",
+		formatAmount(current, b.balance.GetCurrency()),
+		formatAmount(compared, b.balance.GetCurrency()),
+		b.formatDifference(current, compared),
+	)
+}
+
+func (b *ComparisonMessageBuilder) formatDifference(current, compared money.Money) string {
+	difference := calculateDifference(current, compared)
+
+	sign := ""
+	if difference.GreaterThan(money.Zero) {
+		sign = "+"
+	}
+	formattedDifference := sign + difference.Format(b.balance.GetCurrency().GetMoneyFormat())
+
+	// Percentage change can't be calculated when there were no operations in the compared period.
+	if compared.Equal(money.Zero) {
+		if current.Equal(money.Zero) {
+			return fmt.Sprintf("*(%s)*", formattedDifference)
+		}
+
+		return fmt.Sprintf("*(%s, new)*", formattedDifference)
+	}
+
+	percentage := calculatePercentage(difference, compared)
+	return fmt.Sprintf("*(%s, %s%s%%)*", formattedDifference, sign, percentage.StringFixed())
+}
+
+type categoryChange struct {
+	Title         string
+	OperationType OperationType
+	Current       money.Money
+	Compared      money.Money
+	// Difference is the current amount minus the compared one.
+	Difference         money.Money
+	AbsoluteDifference money.Money
+}
+
+// calculateCategoryChanges returns changes of top-level categories, amounts of sub-categories are included
+// into their parents. Categories which have operations only in one of the periods are included as well.
+func (b *ComparisonMessageBuilder) calculateCategoryChanges(
+	operationType OperationType, currentStats, comparedStats *operationsStatistics,
+) ([]categoryChange, error) {
+	currentAmounts, err := calculateCategoryAmounts(currentStats, operationType, b.categories)
+	if err != nil {
+		return nil, err
+	}
+
+	comparedAmounts, err := calculateCategoryAmounts(comparedStats, operationType, b.categories)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]categoryChange, 0)
+	for _, node := range BuildCategoryTree(b.categories) {
+		current, currentOK := currentAmounts[node.Title]
+		compared, comparedOK := comparedAmounts[node.Title]
+		if !currentOK && !comparedOK {
+			continue
+		}
+		if !currentOK {
+			current = money.Zero
+		}
+		if !comparedOK {
+			compared = money.Zero
+		}
+
+		difference := calculateDifference(current, compared)
+		absoluteDifference := money.Zero
+		absoluteDifference.Inc(difference)
+		if absoluteDifference.LessThan(money.Zero) {
+			absoluteDifference.Mul(money.NewFromInt(-1))
+		}
+
+		changes = append(changes, categoryChange{
+			Title:              node.Title,
+			OperationType:      operationType,
+			Current:            current,
+			Compared:           compared,
+			Difference:         difference,
+			AbsoluteDifference: absoluteDifference,
+		})
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Current.GreaterThan(changes[j].Current)
+	})
+
+	return changes, nil
+}
+
+func calculateCategoryAmounts(stats *operationsStatistics, operationType OperationType, categories []Category) (map[string]money.Money, error) {
+	total := stats.IncomingTotal
+	if operationType == OperationTypeSpending {
+		total = stats.SpendingTotal
+	}
+
+	categoriesStatistics, err := calculateCategoryStatistics(total, stats.OperationsByType[operationType], categories)
+	if err != nil {
+		return nil, err
+	}
+
+	amounts := make(map[string]money.Money, len(categoriesStatistics))
+	for _, categoryStatistics := range categoriesStatistics {
+		amounts[categoryStatistics.Title] = categoryStatistics.Amount
+	}
+
+	return amounts, nil
+}
+
+func calculateDifference(current, compared money.Money) money.Money {
+	difference := money.Zero
+	difference.Inc(current)
+	difference.Sub(compared)
+
+	return difference
+}
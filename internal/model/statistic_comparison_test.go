@@ -0,0 +1,79 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComparisonMessageBuilder_Build(t *testing.T) {
+	t.Parallel()
+
+	balance := &model.Balance{
+		Name:     "Main Balance",
+		Amount:   "1000.00",
+		Currency: model.Currency{Symbol: "$"},
+	}
+	categories := []model.Category{
+		{ID: "salary", Title: "Salary"},
+		{ID: "food", Title: "Food"},
+		{ID: "restaurants", Title: "Restaurants", ParentID: "food"},
+		{ID: "taxi", Title: "Taxi"},
+		{ID: "gifts", Title: "Gifts"},
+	}
+
+	current := model.PeriodOperations{
+		Period: model.NewMonthPeriod(2025, time.February),
+		Operations: []model.Operation{
+			{Type: model.OperationTypeIncoming, Amount: "1500.00", CategoryID: "salary"},
+			{Type: model.OperationTypeSpending, Amount: "200.00", CategoryID: "food"},
+			{Type: model.OperationTypeSpending, Amount: "100.00", CategoryID: "restaurants"},
+			{Type: model.OperationTypeSpending, Amount: "50.00", CategoryID: "gifts"},
+			{Type: model.OperationTypeTransferOut, Amount: "100.00"},
+		},
+	}
+	compared := model.PeriodOperations{
+		Period: model.NewMonthPeriod(2025, time.January),
+		Operations: []model.Operation{
+			{Type: model.OperationTypeIncoming, Amount: "1000.00", CategoryID: "salary"},
+			{Type: model.OperationTypeSpending, Amount: "200.00", CategoryID: "food"},
+			{Type: model.OperationTypeSpending, Amount: "80.00", CategoryID: "taxi"},
+			{Type: model.OperationTypeTransferOut, Amount: "100.00"},
+		},
+	}
+
+	expected := "📊 Balance Comparison: *Main Balance*\n" +
+		"📅 Period: _01 Feb 2025 - 28 Feb 2025_\n" +
+		"📅 Compared With: _01 Jan 2025 - 31 Jan 2025_\n" +
+		"\n" +
+		"📈 Summary:\n" +
+		"📥 Incoming Operations: `1500.00$` vs `1000.00$` *(+500.00$, +50.00%)*\n" +
+		"			- Salary: `1500.00$` vs `1000.00$` *(+500.00$, +50.00%)*\n" +
+		"💸 Spending Operations: `350.00$` vs `280.00$` *(+70.00$, +25.00%)*\n" +
+		"			- Food: `300.00$` vs `200.00$` *(+100.00$, +50.00%)*\n" +
+		"			- Gifts: `50.00$` vs `0.00$` *(+50.00$, new)*\n" +
+		"			- Taxi: `0.00$` vs `80.00$` *(-80.00$, -100.00%)*\n" +
+		"➡️ Transfers In: `0.00$` vs `0.00$` *(0.00$)*\n" +
+		"⬅️ Transfers Out: `100.00$` vs `100.00$` *(0.00$, 0.00%)*\n" +
+		"\n" +
+		"🚀 Biggest Movers:\n" +
+		"			🔺 📥 Salary: *(+500.00$, +50.00%)*\n" +
+		"			🔺 💸 Food: *(+100.00$, +50.00%)*\n" +
+		"			🔻 💸 Taxi: *(-80.00$, -100.00%)*\n"
+
+	actual, err := model.NewComparisonMessageBuilder(balance, categories).Build(current, compared)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestComparisonMessageBuilder_Build_InvalidAmount(t *testing.T) {
+	t.Parallel()
+
+	_, err := model.NewComparisonMessageBuilder(&model.Balance{}, nil).Build(
+		model.PeriodOperations{Operations: []model.Operation{{Type: model.OperationTypeIncoming, Amount: "invalid"}}},
+		model.PeriodOperations{},
+	)
+	assert.Error(t, err)
+}
@@ -106,6 +106,8 @@ func Test_calculateCategoryStatistics(t *testing.T) {
 	amount100, _ := money.NewFromString("100.00")
 	amount70, _ := money.NewFromString("70.00")
 	amount30, _ := money.NewFromString("30.00")
+	amount50, _ := money.NewFromString("50.00")
+	amount10, _ := money.NewFromString("10.00")
 
 	type args struct {
 		totalAmount money.Money
@@ -153,6 +155,42 @@ func Test_calculateCategoryStatistics(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "positive: sub-categories are rolled up into parent category",
+			args: args{
+				totalAmount: amount100,
+				operations: []Operation{
+					{CategoryID: "2", Amount: "50.00"},
+					{CategoryID: "3", Amount: "10.00"},
+					{CategoryID: "1", Amount: "10.00"},
+					{CategoryID: "4", Amount: "30.00"},
+				},
+				categories: []Category{
+					{ID: "1", Title: "Food"},
+					{ID: "2", Title: "Groceries", ParentID: "1"},
+					{ID: "3", Title: "Restaurants", ParentID: "1"},
+					{ID: "4", Title: "Transport"},
+				},
+			},
+			expected: expected{
+				stats: []categoryStatistics{
+					{
+						Title:      "Food",
+						Amount:     amount70,
+						Percentage: amount70,
+						Subcategories: []categoryStatistics{
+							{Title: "Groceries", Amount: amount50, Percentage: amount50},
+							{Title: "Restaurants", Amount: amount10, Percentage: amount10},
+						},
+					},
+					{
+						Title:      "Transport",
+						Amount:     amount30,
+						Percentage: amount30,
+					},
+				},
+			},
+		},
 		{
 			desc: "positive: empty operations",
 			args: args{
@@ -202,6 +240,13 @@ func Test_calculateCategoryStatistics(t *testing.T) {
 				assert.Equal(t, tc.expected.stats[i].Title, actual[i].Title)
 				assert.Equal(t, tc.expected.stats[i].Amount.StringFixed(), actual[i].Amount.StringFixed())
 				assert.Equal(t, tc.expected.stats[i].Percentage.StringFixed(), actual[i].Percentage.StringFixed())
+				assert.Equal(t, len(tc.expected.stats[i].Subcategories), len(actual[i].Subcategories))
+
+				for j := range tc.expected.stats[i].Subcategories {
+					assert.Equal(t, tc.expected.stats[i].Subcategories[j].Title, actual[i].Subcategories[j].Title)
+					assert.Equal(t, tc.expected.stats[i].Subcategories[j].Amount.StringFixed(), actual[i].Subcategories[j].Amount.StringFixed())
+					assert.Equal(t, tc.expected.stats[i].Subcategories[j].Percentage.StringFixed(), actual[i].Subcategories[j].Percentage.StringFixed())
+				}
 			}
 		})
 	}
@@ -261,7 +306,7 @@ func TestStatisticsMessageBuilder_Build(t *testing.T) {
 🔄 Transfers Operations *(2)*:
 		 ➡️ In: `+"`75.00$`"+` *(1)*
 			⬅️ Out: `+"`25.00$`"+` *(1)*
-	`, time.Date(time.Now().Year(), time.Now().Month(), 1, 0, 0, 0, 0, time.UTC).Format(dateFormat), time.Now().Format(dateFormat)),
+	`, "01 Mar 2024", "31 Mar 2024"),
 			},
 		},
 		{
@@ -294,7 +339,7 @@ func TestStatisticsMessageBuilder_Build(t *testing.T) {
 🔄 Transfers Operations *(0)*:
 		 ➡️ In: `+"`0.00$`"+` *(0)*
 			⬅️ Out: `+"`0.00$`"+` *(0)*
-	`, time.Date(time.Now().Year(), time.Now().Month(), 1, 0, 0, 0, 0, time.UTC).Format(dateFormat), time.Now().Format(dateFormat)),
+	`, "01 Mar 2024", "31 Mar 2024"),
 			},
 		},
 		{
@@ -327,7 +372,7 @@ func TestStatisticsMessageBuilder_Build(t *testing.T) {
 🔄 Transfers Operations *(0)*:
 		 ➡️ In: `+"`0.00$`"+` *(0)*
 			⬅️ Out: `+"`0.00$`"+` *(0)*
-	`, time.Date(time.Now().Year(), time.Now().Month(), 1, 0, 0, 0, 0, time.UTC).Format(dateFormat), time.Now().Format(dateFormat)),
+	`, "01 Mar 2024", "31 Mar 2024"),
 			},
 		},
 		{
@@ -354,7 +399,7 @@ func TestStatisticsMessageBuilder_Build(t *testing.T) {
 🔄 Transfers Operations *(1)*:
 		 ➡️ In: `+"`75.00$`"+` *(1)*
 			⬅️ Out: `+"`0.00$`"+` *(0)*
-	`, time.Date(time.Now().Year(), time.Now().Month(), 1, 0, 0, 0, 0, time.UTC).Format(dateFormat), time.Now().Format(dateFormat)),
+	`, "01 Mar 2024", "31 Mar 2024"),
 			},
 		},
 		{
@@ -381,7 +426,7 @@ func TestStatisticsMessageBuilder_Build(t *testing.T) {
 🔄 Transfers Operations *(1)*:
 		 ➡️ In: `+"`0.00$`"+` *(0)*
 			⬅️ Out: `+"`75.00$`"+` *(1)*
-	`, time.Date(time.Now().Year(), time.Now().Month(), 1, 0, 0, 0, 0, time.UTC).Format(dateFormat), time.Now().Format(dateFormat)),
+	`, "01 Mar 2024", "31 Mar 2024"),
 			},
 		},
 		{
@@ -407,7 +452,7 @@ func TestStatisticsMessageBuilder_Build(t *testing.T) {
 🔄 Transfers Operations *(0)*:
 		 ➡️ In: `+"`0.00$`"+` *(0)*
 			⬅️ Out: `+"`0.00$`"+` *(0)*
-	`, time.Date(time.Now().Year(), time.Now().Month(), 1, 0, 0, 0, 0, time.UTC).Format(dateFormat), time.Now().Format(dateFormat)),
+	`, "01 Mar 2024", "31 Mar 2024"),
 			},
 		},
 		{
@@ -434,7 +479,7 @@ func TestStatisticsMessageBuilder_Build(t *testing.T) {
 			t.Parallel()
 
 			builder := NewStatisticsMessageBuilder(tc.args.balance, tc.args.operations, tc.args.categories)
-			message, err := builder.Build(convertToMonth(int(time.Now().Month())))
+			message, err := builder.Build(NewMonthPeriod(2024, time.March))
 
 			if tc.expected.err {
 				assert.Error(t, err)
@@ -446,34 +491,3 @@ func TestStatisticsMessageBuilder_Build(t *testing.T) {
 		})
 	}
 }
-
-func convertToMonth(monthIndex int) Month {
-	switch monthIndex {
-	case 1:
-		return MonthJanuary
-	case 2:
-		return MonthFebruary
-	case 3:
-		return MonthMarch
-	case 4:
-		return MonthApril
-	case 5:
-		return MonthMay
-	case 6:
-		return MonthJune
-	case 7:
-		return MonthJuly
-	case 8:
-		return MonthAugust
-	case 9:
-		return MonthSeptember
-	case 10:
-		return MonthOctober
-	case 11:
-		return MonthNovember
-	case 12:
-		return MonthDecember
-	default:
-		return MonthJanuary
-	}
-}
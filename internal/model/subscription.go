@@ -3,6 +3,8 @@ package model
 import (
 	"fmt"
 	"time"
+
+	"github.com/VladPetriv/finance_bot/pkg/money"
 )
 
 // BalanceSubscription represents a subscription for an internal user balance.
@@ -10,11 +12,24 @@ type BalanceSubscription struct {
 	ID         string `db:"id"`
 	BalanceID  string `db:"balance_id"`
 	CategoryID string `db:"category_id"`
+	// CurrencyID represents the currency in which subscription is charged. When it's empty, the balance currency is used.
+	CurrencyID string `db:"currency_id"`
 
 	Name   string             `db:"name"`
 	Amount string             `db:"amount"`
 	Period SubscriptionPeriod `db:"period"`
 
+	// NotificationLeadTime overrides the lead time from user settings. When it's nil, the user setting is used.
+	NotificationLeadTime *NotificationLeadTime `db:"notification_lead_time"`
+
+	// TrialEndAt represents the date when the free trial converts into a paid subscription.
+	// When it's nil, the subscription doesn't have a trial.
+	TrialEndAt *time.Time `db:"trial_end_at"`
+	// AmountAfterTrial represents the amount charged once the free trial ends. When it's empty, the subscription amount is charged.
+	AmountAfterTrial string `db:"amount_after_trial"`
+	// TrialReminderSent is true when the user was already reminded about the trial end.
+	TrialReminderSent bool `db:"trial_reminder_sent"`
+
 	StartAt   time.Time `db:"start_at"`
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
@@ -32,12 +47,84 @@ func (b BalanceSubscription) GetName() string {
 
 // GetDetails returns the balance subscription details in string format.
 func (b BalanceSubscription) GetDetails() string {
+	reminder := NotificationLeadTimeDefaultLabel
+	if b.NotificationLeadTime != nil {
+		reminder = b.NotificationLeadTime.String()
+	}
+
+	details := fmt.Sprintf(
+		"Subscription Details:\nName: %s\nAmount: %s\nPeriod: %s\nStart At: %s\nReminder: %s",
+		b.Name, b.Amount, b.Period, b.StartAt.Format("2006-01-02 15:04"), reminder,
+	)
+	if b.TrialEndAt != nil {
+		details += fmt.Sprintf("\nFree Trial Ends At: %s", b.TrialEndAt.Format("2006-01-02 15:04"))
+	}
+	if b.AmountAfterTrial != "" {
+		details += fmt.Sprintf("\nAmount After Trial: %s", b.AmountAfterTrial)
+	}
+
+	return details
+}
+
+// GetChargeAmount returns the amount that is charged for each subscription payment.
+func (b BalanceSubscription) GetChargeAmount() string {
+	if b.TrialEndAt != nil && b.AmountAfterTrial != "" {
+		return b.AmountAfterTrial
+	}
+
+	return b.Amount
+}
+
+// IsInTrial checks if the provided date is covered by the subscription free trial.
+func (b BalanceSubscription) IsInTrial(date time.Time) bool {
+	return b.TrialEndAt != nil && date.Before(*b.TrialEndAt)
+}
+
+// GetBillingStartDate returns the date of the first charge, payments are not charged until the free trial ends.
+func (b BalanceSubscription) GetBillingStartDate() time.Time {
+	if b.IsInTrial(b.StartAt) {
+		return *b.TrialEndAt
+	}
+
+	return b.StartAt
+}
+
+// IsTrialReminderDue checks if the user should be reminded that the free trial converts into a paid subscription.
+// The reminder is due starting from the day that is lead time days before the trial end, until the end of the trial end day,
+// so the same day reminder is sent on the day when the trial ends.
+func (b BalanceSubscription) IsTrialReminderDue(leadTime NotificationLeadTime, now time.Time) bool {
+	if b.TrialEndAt == nil || b.TrialReminderSent {
+		return false
+	}
+
+	trialEndDay := time.Date(b.TrialEndAt.Year(), b.TrialEndAt.Month(), b.TrialEndAt.Day(), 0, 0, 0, 0, time.UTC)
+	reminderDay := trialEndDay.AddDate(0, 0, -int(leadTime))
+
+	return !now.Before(reminderDay) && now.Before(trialEndDay.AddDate(0, 0, 1))
+}
+
+// GetTrialReminderMessage returns the message that reminds the user about the free trial end.
+func (b BalanceSubscription) GetTrialReminderMessage(currency Currency) string {
+	amount, _ := money.NewFromString(b.GetChargeAmount())
+
 	return fmt.Sprintf(
-		"Subscription Details:\nName: %s\nAmount: %s\nPeriod: %s\nStart At: %s",
-		b.Name, b.Amount, b.Period, b.StartAt.Format("2006-01-02 15:04"),
+		"⏳ Free trial of \"%s\" ends on %s.\n\nAfter that you'll be charged %s %s. Cancel the subscription now if you don't want to keep it.",
+		b.Name, b.TrialEndAt.Format("02 Jan 2006"), amount.Format(currency.GetMoneyFormat()), b.Period,
 	)
 }
 
+// BalanceSubscriptionCurrencyDefaultLabel represents the label of the option that resets subscription currency to the balance one.
+const BalanceSubscriptionCurrencyDefaultLabel = "Balance currency 💳"
+
+// GetNotificationLeadTime returns the subscription lead time or the fallback one when the subscription doesn't override it.
+func (b BalanceSubscription) GetNotificationLeadTime(fallback NotificationLeadTime) NotificationLeadTime {
+	if b.NotificationLeadTime != nil {
+		return *b.NotificationLeadTime
+	}
+
+	return fallback
+}
+
 // GetDeletionMessage returns the deletion message for the balance subscription.
 func (b BalanceSubscription) GetDeletionMessage() string {
 	return fmt.Sprintf(
@@ -48,6 +135,22 @@ func (b BalanceSubscription) GetDeletionMessage() string {
 	)
 }
 
+// SubscriptionCharge represents the amount that is charged from the balance for a subscription payment.
+type SubscriptionCharge struct {
+	// Amount represents the charged amount in the balance currency.
+	Amount money.Money
+	// OriginalAmount, OriginalCurrency and ExchangeRate are set only when the subscription
+	// is charged in a currency different from the balance one.
+	OriginalAmount   money.Money
+	OriginalCurrency *Currency
+	ExchangeRate     *money.Money
+}
+
+// IsConverted returns true if the charge was converted from the subscription currency into the balance currency.
+func (s SubscriptionCharge) IsConverted() bool {
+	return s.OriginalCurrency != nil && s.ExchangeRate != nil
+}
+
 // SubscriptionPeriod represents the period of a subscription.
 type SubscriptionPeriod string
 
@@ -101,4 +204,81 @@ type ScheduledOperation struct {
 	SubscriptionID string    `db:"subscription_id"`
 	Notified       bool      `db:"notified"`
 	CreationDate   time.Time `db:"creation_date"`
+
+	SnoozedUntil *time.Time `db:"snoozed_until"`
+}
+
+// IsNotificationDue checks if the user should be notified about the scheduled operation at the given time.
+// The notification is due starting from the day that is lead time days before the creation date,
+// unless the notification was snoozed and the snooze time is not reached yet.
+func (s ScheduledOperation) IsNotificationDue(leadTime NotificationLeadTime, now time.Time) bool {
+	if s.SnoozedUntil != nil && now.Before(*s.SnoozedUntil) {
+		return false
+	}
+
+	creationDay := time.Date(s.CreationDate.Year(), s.CreationDate.Month(), s.CreationDate.Day(), 0, 0, 0, 0, time.UTC)
+	notificationDay := creationDay.AddDate(0, 0, -int(leadTime))
+
+	return !now.Before(notificationDay)
+}
+
+// DaysUntilCreation returns the number of calendar days left until the scheduled operation creation date.
+func (s ScheduledOperation) DaysUntilCreation(now time.Time) int {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	creationDay := time.Date(s.CreationDate.Year(), s.CreationDate.Month(), s.CreationDate.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(creationDay.Sub(today).Hours() / 24)
+}
+
+// NotificationLeadTime represents the number of days before the subscription payment when the user is notified about it.
+type NotificationLeadTime int
+
+const (
+	// NotificationLeadTimeSameDay represents a notification on the day of the payment.
+	NotificationLeadTimeSameDay NotificationLeadTime = 0
+	// NotificationLeadTimeOneDay represents a notification one day before the payment.
+	NotificationLeadTimeOneDay NotificationLeadTime = 1
+	// NotificationLeadTimeThreeDays represents a notification three days before the payment.
+	NotificationLeadTimeThreeDays NotificationLeadTime = 3
+	// NotificationLeadTimeSevenDays represents a notification seven days before the payment.
+	NotificationLeadTimeSevenDays NotificationLeadTime = 7
+
+	// MaxNotificationLeadTime represents the biggest lead time that could be chosen by the user.
+	MaxNotificationLeadTime = NotificationLeadTimeSevenDays
+	// DefaultNotificationLeadTime represents the lead time that is used when user didn't change it.
+	DefaultNotificationLeadTime = NotificationLeadTimeOneDay
+)
+
+// NotificationLeadTimeDefaultLabel represents the label of the option that resets subscription lead time to the user settings one.
+const NotificationLeadTimeDefaultLabel = "Default from settings ⚙️"
+
+// AvailableNotificationLeadTimes is a list of lead times that user can choose from.
+var AvailableNotificationLeadTimes = []NotificationLeadTime{
+	NotificationLeadTimeSameDay,
+	NotificationLeadTimeOneDay,
+	NotificationLeadTimeThreeDays,
+	NotificationLeadTimeSevenDays,
+}
+
+// String returns the human readable label of the lead time.
+func (n NotificationLeadTime) String() string {
+	switch n {
+	case NotificationLeadTimeSameDay:
+		return "Same day"
+	case NotificationLeadTimeOneDay:
+		return "1 day before"
+	default:
+		return fmt.Sprintf("%d days before", n)
+	}
+}
+
+// ParseNotificationLeadTime parses a lead time from its label.
+func ParseNotificationLeadTime(value string) (NotificationLeadTime, error) {
+	for _, leadTime := range AvailableNotificationLeadTimes {
+		if leadTime.String() == value {
+			return leadTime, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid notification lead time: %s", value)
 }
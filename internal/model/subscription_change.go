@@ -0,0 +1,98 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/pkg/money"
+)
+
+// BalanceSubscriptionChange represents a change of the balance subscription amount, period or currency.
+type BalanceSubscriptionChange struct {
+	ID             string `db:"id"`
+	SubscriptionID string `db:"subscription_id"`
+
+	PreviousAmount string             `db:"previous_amount"`
+	NewAmount      string             `db:"new_amount"`
+	PreviousPeriod SubscriptionPeriod `db:"previous_period"`
+	NewPeriod      SubscriptionPeriod `db:"new_period"`
+	// PreviousCurrencyID and NewCurrencyID are empty when the subscription is charged in the balance currency.
+	PreviousCurrencyID string `db:"previous_currency_id"`
+	NewCurrencyID      string `db:"new_currency_id"`
+
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// GetBalanceSubscriptionChange compares the subscription before and after the update and returns the change of its price.
+// Returns nil when neither amount, period nor currency were changed.
+func GetBalanceSubscriptionChange(previous, updated BalanceSubscription) *BalanceSubscriptionChange {
+	if previous.Amount == updated.Amount && previous.Period == updated.Period && previous.CurrencyID == updated.CurrencyID {
+		return nil
+	}
+
+	return &BalanceSubscriptionChange{
+		SubscriptionID: updated.ID,
+		PreviousAmount: previous.Amount,
+		NewAmount:      updated.Amount,
+		PreviousPeriod: previous.Period,
+		NewPeriod:      updated.Period,
+
+		PreviousCurrencyID: previous.CurrencyID,
+		NewCurrencyID:      updated.CurrencyID,
+	}
+}
+
+// String returns the change in human readable format.
+func (b BalanceSubscriptionChange) String() string {
+	change := fmt.Sprintf(
+		"%s: %s %s → %s %s",
+		b.CreatedAt.Format(dateFormat), b.PreviousAmount, b.PreviousPeriod, b.NewAmount, b.NewPeriod,
+	)
+	if b.IsCurrencyChanged() {
+		change += " (currency changed)"
+	}
+
+	return change
+}
+
+// IsCurrencyChanged checks if the subscription started to be charged in another currency after the change.
+func (b BalanceSubscriptionChange) IsCurrencyChanged() bool {
+	return b.PreviousCurrencyID != b.NewCurrencyID
+}
+
+// IsPriceIncrease checks if the subscription became more expensive after the change.
+// Amounts are normalized to yearly ones, so changes of the period are compared correctly.
+// Amounts in different currencies are not comparable, so a change of the currency is never treated as an increase.
+func (b BalanceSubscriptionChange) IsPriceIncrease() bool {
+	if b.IsCurrencyChanged() {
+		return false
+	}
+
+	previousAmount, err := money.NewFromString(b.PreviousAmount)
+	if err != nil {
+		return false
+	}
+	newAmount, err := money.NewFromString(b.NewAmount)
+	if err != nil {
+		return false
+	}
+
+	newYearlyAmount := b.NewPeriod.GetYearlyAmount(newAmount)
+	return newYearlyAmount.GreaterThan(b.PreviousPeriod.GetYearlyAmount(previousAmount))
+}
+
+// BuildBalanceSubscriptionChangesHistory returns the history of subscription changes in string format.
+func BuildBalanceSubscriptionChangesHistory(changes []BalanceSubscriptionChange) string {
+	if len(changes) == 0 {
+		return "Price History: no changes yet."
+	}
+
+	var buffer strings.Builder
+	buffer.WriteString("Price History:")
+	for _, change := range changes {
+		buffer.WriteString("\n- " + change.String())
+	}
+
+	return buffer.String()
+}
@@ -0,0 +1,177 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBalanceSubscriptionChange(t *testing.T) {
+	t.Parallel()
+
+	previous := model.BalanceSubscription{
+		ID:     "subscription_id",
+		Name:   "Internet",
+		Amount: "20.00",
+		Period: model.SubscriptionPeriodMonthly,
+	}
+
+	testCases := [...]struct {
+		desc     string
+		updated  model.BalanceSubscription
+		expected *model.BalanceSubscriptionChange
+	}{
+		{
+			desc: "amount changed",
+			updated: model.BalanceSubscription{
+				ID:     "subscription_id",
+				Name:   "Internet",
+				Amount: "25.00",
+				Period: model.SubscriptionPeriodMonthly,
+			},
+			expected: &model.BalanceSubscriptionChange{
+				SubscriptionID: "subscription_id",
+				PreviousAmount: "20.00",
+				NewAmount:      "25.00",
+				PreviousPeriod: model.SubscriptionPeriodMonthly,
+				NewPeriod:      model.SubscriptionPeriodMonthly,
+			},
+		},
+		{
+			desc: "period changed",
+			updated: model.BalanceSubscription{
+				ID:     "subscription_id",
+				Name:   "Internet",
+				Amount: "20.00",
+				Period: model.SubscriptionPeriodYearly,
+			},
+			expected: &model.BalanceSubscriptionChange{
+				SubscriptionID: "subscription_id",
+				PreviousAmount: "20.00",
+				NewAmount:      "20.00",
+				PreviousPeriod: model.SubscriptionPeriodMonthly,
+				NewPeriod:      model.SubscriptionPeriodYearly,
+			},
+		},
+		{
+			desc: "currency changed",
+			updated: model.BalanceSubscription{
+				ID:         "subscription_id",
+				CurrencyID: "usd_id",
+				Name:       "Internet",
+				Amount:     "20.00",
+				Period:     model.SubscriptionPeriodMonthly,
+			},
+			expected: &model.BalanceSubscriptionChange{
+				SubscriptionID: "subscription_id",
+				PreviousAmount: "20.00",
+				NewAmount:      "20.00",
+				PreviousPeriod: model.SubscriptionPeriodMonthly,
+				NewPeriod:      model.SubscriptionPeriodMonthly,
+				NewCurrencyID:  "usd_id",
+			},
+		},
+		{
+			desc: "nothing changed except name",
+			updated: model.BalanceSubscription{
+				ID:     "subscription_id",
+				Name:   "Home Internet",
+				Amount: "20.00",
+				Period: model.SubscriptionPeriodMonthly,
+			},
+			expected: nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, model.GetBalanceSubscriptionChange(previous, tc.updated))
+		})
+	}
+}
+
+func TestBalanceSubscriptionChange_IsPriceIncrease(t *testing.T) {
+	t.Parallel()
+
+	testCases := [...]struct {
+		desc     string
+		change   model.BalanceSubscriptionChange
+		expected bool
+	}{
+		{
+			desc: "amount increased",
+			change: model.BalanceSubscriptionChange{
+				PreviousAmount: "20.00", NewAmount: "25.00",
+				PreviousPeriod: model.SubscriptionPeriodMonthly, NewPeriod: model.SubscriptionPeriodMonthly,
+			},
+			expected: true,
+		},
+		{
+			desc: "amount decreased",
+			change: model.BalanceSubscriptionChange{
+				PreviousAmount: "25.00", NewAmount: "20.00",
+				PreviousPeriod: model.SubscriptionPeriodMonthly, NewPeriod: model.SubscriptionPeriodMonthly,
+			},
+			expected: false,
+		},
+		{
+			desc: "switched to yearly period with discount",
+			change: model.BalanceSubscriptionChange{
+				PreviousAmount: "10.00", NewAmount: "100.00",
+				PreviousPeriod: model.SubscriptionPeriodMonthly, NewPeriod: model.SubscriptionPeriodYearly,
+			},
+			expected: false,
+		},
+		{
+			desc: "switched to weekly period with the same amount",
+			change: model.BalanceSubscriptionChange{
+				PreviousAmount: "10.00", NewAmount: "10.00",
+				PreviousPeriod: model.SubscriptionPeriodMonthly, NewPeriod: model.SubscriptionPeriodWeekly,
+			},
+			expected: true,
+		},
+		{
+			desc: "switched to another currency with bigger amount",
+			change: model.BalanceSubscriptionChange{
+				PreviousAmount: "10.00", NewAmount: "400.00",
+				PreviousPeriod: model.SubscriptionPeriodMonthly, NewPeriod: model.SubscriptionPeriodMonthly,
+				PreviousCurrencyID: "usd_id", NewCurrencyID: "uah_id",
+			},
+			expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.change.IsPriceIncrease())
+		})
+	}
+}
+
+func TestBuildBalanceSubscriptionChangesHistory(t *testing.T) {
+	t.Parallel()
+
+	changes := []model.BalanceSubscriptionChange{
+		{
+			PreviousAmount: "20.00", NewAmount: "25.00",
+			PreviousPeriod: model.SubscriptionPeriodMonthly, NewPeriod: model.SubscriptionPeriodMonthly,
+			CreatedAt: time.Date(2025, time.February, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			PreviousAmount: "25.00", NewAmount: "250.00",
+			PreviousPeriod: model.SubscriptionPeriodMonthly, NewPeriod: model.SubscriptionPeriodYearly,
+			CreatedAt: time.Date(2025, time.June, 15, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	assert.Equal(t, "Price History: no changes yet.", model.BuildBalanceSubscriptionChangesHistory(nil))
+	assert.Equal(
+		t,
+		"Price History:\n- 01 Feb 2025: 20.00 monthly → 25.00 monthly\n- 15 Jun 2025: 25.00 monthly → 250.00 yearly",
+		model.BuildBalanceSubscriptionChangesHistory(changes),
+	)
+}
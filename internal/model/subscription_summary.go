@@ -0,0 +1,177 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/pkg/money"
+)
+
+// UpcomingChargesPeriodInDays represents the number of days ahead for which upcoming subscription charges are shown.
+const UpcomingChargesPeriodInDays = 30
+
+var (
+	monthsPerYear = money.NewFromInt(12)
+	weeksPerYear  = money.NewFromInt(52)
+)
+
+// GetMonthlyAmount normalizes the amount charged once per period to the average amount charged per month.
+func (s SubscriptionPeriod) GetMonthlyAmount(amount money.Money) money.Money {
+	monthlyAmount := s.GetYearlyAmount(amount)
+	monthlyAmount.Div(monthsPerYear)
+
+	return monthlyAmount
+}
+
+// GetYearlyAmount normalizes the amount charged once per period to the amount charged per year.
+func (s SubscriptionPeriod) GetYearlyAmount(amount money.Money) money.Money {
+	yearlyAmount := money.Zero
+	yearlyAmount.Inc(amount)
+
+	switch s {
+	case SubscriptionPeriodWeekly:
+		yearlyAmount.Mul(weeksPerYear)
+	case SubscriptionPeriodMonthly:
+		yearlyAmount.Mul(monthsPerYear)
+	}
+
+	return yearlyAmount
+}
+
+// SubscriptionSummaryItem represents a subscription with its amount converted into the summary currency.
+type SubscriptionSummaryItem struct {
+	Subscription  BalanceSubscription
+	CategoryTitle string
+	Amount        money.Money
+}
+
+// UpcomingSubscriptionCharge represents a scheduled subscription charge with the amount converted into the summary currency.
+type UpcomingSubscriptionCharge struct {
+	Name   string
+	Amount money.Money
+	Date   time.Time
+}
+
+// SubscriptionPriceIncrease represents a subscription with its price increases ordered by creation date.
+type SubscriptionPriceIncrease struct {
+	Name    string
+	Changes []BalanceSubscriptionChange
+}
+
+// SubscriptionSummary contains the data required to build subscriptions cost overview.
+type SubscriptionSummary struct {
+	Currency Currency
+
+	Items           []SubscriptionSummaryItem
+	UpcomingCharges []UpcomingSubscriptionCharge
+	// PriceIncreases contains subscriptions that became more expensive during the current year.
+	PriceIncreases []SubscriptionPriceIncrease
+	// NotConvertedSubscriptions contains names of subscriptions that were excluded from totals,
+	// because their amount couldn't be converted into the summary currency.
+	NotConvertedSubscriptions []string
+}
+
+type subscriptionSummaryCategory struct {
+	title         string
+	monthlyAmount money.Money
+	yearlyAmount  money.Money
+	items         []SubscriptionSummaryItem
+}
+
+// BuildMessage returns the subscriptions summary in markdown format.
+func (s SubscriptionSummary) BuildMessage() string {
+	var buffer strings.Builder
+
+	monthlyTotal, yearlyTotal := money.Zero, money.Zero
+	categoriesByTitle := make(map[string]*subscriptionSummaryCategory)
+	for _, item := range s.Items {
+		monthlyAmount := item.Subscription.Period.GetMonthlyAmount(item.Amount)
+		yearlyAmount := item.Subscription.Period.GetYearlyAmount(item.Amount)
+		monthlyTotal.Inc(monthlyAmount)
+		yearlyTotal.Inc(yearlyAmount)
+
+		category, ok := categoriesByTitle[item.CategoryTitle]
+		if !ok {
+			category = &subscriptionSummaryCategory{
+				title:         item.CategoryTitle,
+				monthlyAmount: money.Zero,
+				yearlyAmount:  money.Zero,
+			}
+			categoriesByTitle[item.CategoryTitle] = category
+		}
+
+		category.monthlyAmount.Inc(monthlyAmount)
+		category.yearlyAmount.Inc(yearlyAmount)
+		category.items = append(category.items, item)
+	}
+
+	categories := make([]*subscriptionSummaryCategory, 0, len(categoriesByTitle))
+	for _, category := range categoriesByTitle {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].monthlyAmount.Equal(categories[j].monthlyAmount) {
+			return categories[i].title < categories[j].title
+		}
+
+		return categories[i].monthlyAmount.GreaterThan(categories[j].monthlyAmount)
+	})
+
+	buffer.WriteString(fmt.Sprintf("📋 Subscriptions Summary *(%s)*\n", s.Currency.Code))
+	buffer.WriteString(fmt.Sprintf("💰 Monthly: %s\n", formatAmount(monthlyTotal, s.Currency)))
+	buffer.WriteString(fmt.Sprintf("📅 Yearly: %s\n", formatAmount(yearlyTotal, s.Currency)))
+
+	if len(categories) > 0 {
+		buffer.WriteString("\n🏷️ By Category:\n")
+	}
+	for _, category := range categories {
+		buffer.WriteString(fmt.Sprintf(
+			"	- %s: %s / month, %s / year\n",
+			category.title, formatAmount(category.monthlyAmount, s.Currency), formatAmount(category.yearlyAmount, s.Currency),
+		))
+		for _, item := range category.items {
+			buffer.WriteString(fmt.Sprintf(
+				"		• %s: %s %s\n",
+				item.Subscription.Name, formatAmount(item.Amount, s.Currency), item.Subscription.Period,
+			))
+		}
+	}
+
+	buffer.WriteString(fmt.Sprintf("\n⏳ Upcoming Charges (next %d days):\n", UpcomingChargesPeriodInDays))
+	if len(s.UpcomingCharges) == 0 {
+		buffer.WriteString("	No charges expected.\n")
+	}
+	for _, charge := range s.UpcomingCharges {
+		buffer.WriteString(fmt.Sprintf(
+			"	- %s: %s — %s\n",
+			charge.Date.Format(dateFormat), charge.Name, formatAmount(charge.Amount, s.Currency),
+		))
+	}
+
+	if len(s.PriceIncreases) > 0 {
+		buffer.WriteString("\n📈 Price Increases This Year:\n")
+	}
+	for _, priceIncrease := range s.PriceIncreases {
+		if len(priceIncrease.Changes) == 0 {
+			continue
+		}
+
+		firstChange, lastChange := priceIncrease.Changes[0], priceIncrease.Changes[len(priceIncrease.Changes)-1]
+		buffer.WriteString(fmt.Sprintf(
+			"	- %s: %d time(s), %s %s → %s %s\n",
+			priceIncrease.Name, len(priceIncrease.Changes),
+			firstChange.PreviousAmount, firstChange.PreviousPeriod, lastChange.NewAmount, lastChange.NewPeriod,
+		))
+	}
+
+	if len(s.NotConvertedSubscriptions) > 0 {
+		buffer.WriteString(fmt.Sprintf(
+			"\n⚠️ Not included in totals, because the amount couldn't be converted: %s\n",
+			strings.Join(s.NotConvertedSubscriptions, ", "),
+		))
+	}
+
+	return buffer.String()
+}
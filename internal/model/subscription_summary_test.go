@@ -0,0 +1,104 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/VladPetriv/finance_bot/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscriptionPeriod_GetMonthlyAndYearlyAmount(t *testing.T) {
+	t.Parallel()
+
+	testCases := [...]struct {
+		desc            string
+		period          model.SubscriptionPeriod
+		amount          money.Money
+		expectedMonthly string
+		expectedYearly  string
+	}{
+		{
+			desc:            "weekly subscription",
+			period:          model.SubscriptionPeriodWeekly,
+			amount:          money.NewFromInt(3),
+			expectedMonthly: "13.00",
+			expectedYearly:  "156.00",
+		},
+		{
+			desc:            "monthly subscription",
+			period:          model.SubscriptionPeriodMonthly,
+			amount:          money.NewFromFloat(9.99),
+			expectedMonthly: "9.99",
+			expectedYearly:  "119.88",
+		},
+		{
+			desc:            "yearly subscription",
+			period:          model.SubscriptionPeriodYearly,
+			amount:          money.NewFromInt(120),
+			expectedMonthly: "10.00",
+			expectedYearly:  "120.00",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			monthlyAmount := tc.period.GetMonthlyAmount(tc.amount)
+			yearlyAmount := tc.period.GetYearlyAmount(tc.amount)
+
+			assert.Equal(t, tc.expectedMonthly, monthlyAmount.StringFixed())
+			assert.Equal(t, tc.expectedYearly, yearlyAmount.StringFixed())
+		})
+	}
+}
+
+func TestSubscriptionSummary_BuildMessage(t *testing.T) {
+	t.Parallel()
+
+	summary := model.SubscriptionSummary{
+		Currency: model.Currency{Code: "USD", Symbol: "$"},
+		Items: []model.SubscriptionSummaryItem{
+			{
+				Subscription:  model.BalanceSubscription{Name: "Netflix", Period: model.SubscriptionPeriodMonthly},
+				CategoryTitle: "Entertainment",
+				Amount:        money.NewFromInt(10),
+			},
+			{
+				Subscription:  model.BalanceSubscription{Name: "Domain", Period: model.SubscriptionPeriodYearly},
+				CategoryTitle: "Work",
+				Amount:        money.NewFromInt(24),
+			},
+		},
+		UpcomingCharges: []model.UpcomingSubscriptionCharge{
+			{Name: "Netflix", Amount: money.NewFromInt(10), Date: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		PriceIncreases: []model.SubscriptionPriceIncrease{
+			{
+				Name: "Internet",
+				Changes: []model.BalanceSubscriptionChange{
+					{PreviousAmount: "20.00", NewAmount: "25.00", PreviousPeriod: model.SubscriptionPeriodMonthly, NewPeriod: model.SubscriptionPeriodMonthly},
+					{PreviousAmount: "25.00", NewAmount: "30.00", PreviousPeriod: model.SubscriptionPeriodMonthly, NewPeriod: model.SubscriptionPeriodMonthly},
+				},
+			},
+		},
+		NotConvertedSubscriptions: []string{"Spotify"},
+	}
+
+	expected := "📋 Subscriptions Summary *(USD)*\n" +
+		"💰 Monthly: `$12.00`\n" +
+		"📅 Yearly: `$144.00`\n" +
+		"\n🏷️ By Category:\n" +
+		"	- Entertainment: `$10.00` / month, `$120.00` / year\n" +
+		"		• Netflix: `$10.00` monthly\n" +
+		"	- Work: `$2.00` / month, `$24.00` / year\n" +
+		"		• Domain: `$24.00` yearly\n" +
+		"\n⏳ Upcoming Charges (next 30 days):\n" +
+		"	- 01 Mar 2025: Netflix — `$10.00`\n" +
+		"\n📈 Price Increases This Year:\n" +
+		"	- Internet: 2 time(s), 20.00 monthly → 30.00 monthly\n" +
+		"\n⚠️ Not included in totals, because the amount couldn't be converted: Spotify\n"
+
+	assert.Equal(t, expected, summary.BuildMessage())
+}
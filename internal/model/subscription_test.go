@@ -102,3 +102,264 @@ func TestCalculateScheduledOperationBillingDates(t *testing.T) {
 		})
 	}
 }
+
+func TestScheduledOperation_IsNotificationDue(t *testing.T) {
+	t.Parallel()
+
+	creationDate := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	snoozedUntil := time.Date(2025, time.March, 8, 18, 0, 0, 0, time.UTC)
+
+	testCases := [...]struct {
+		desc               string
+		scheduledOperation model.ScheduledOperation
+		leadTime           model.NotificationLeadTime
+		now                time.Time
+		expected           bool
+	}{
+		{
+			desc:               "notification is due on the same day",
+			scheduledOperation: model.ScheduledOperation{CreationDate: creationDate},
+			leadTime:           model.NotificationLeadTimeSameDay,
+			now:                time.Date(2025, time.March, 10, 0, 1, 0, 0, time.UTC),
+			expected:           true,
+		},
+		{
+			desc:               "notification with same day lead time is not due a day before",
+			scheduledOperation: model.ScheduledOperation{CreationDate: creationDate},
+			leadTime:           model.NotificationLeadTimeSameDay,
+			now:                time.Date(2025, time.March, 9, 23, 59, 0, 0, time.UTC),
+			expected:           false,
+		},
+		{
+			desc:               "notification is due one day before",
+			scheduledOperation: model.ScheduledOperation{CreationDate: creationDate},
+			leadTime:           model.NotificationLeadTimeOneDay,
+			now:                time.Date(2025, time.March, 9, 10, 0, 0, 0, time.UTC),
+			expected:           true,
+		},
+		{
+			desc:               "notification is due seven days before",
+			scheduledOperation: model.ScheduledOperation{CreationDate: creationDate},
+			leadTime:           model.NotificationLeadTimeSevenDays,
+			now:                time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC),
+			expected:           true,
+		},
+		{
+			desc:               "notification is not due more than three days before",
+			scheduledOperation: model.ScheduledOperation{CreationDate: creationDate},
+			leadTime:           model.NotificationLeadTimeThreeDays,
+			now:                time.Date(2025, time.March, 6, 23, 0, 0, 0, time.UTC),
+			expected:           false,
+		},
+		{
+			desc:               "snoozed notification is not due until snooze time",
+			scheduledOperation: model.ScheduledOperation{CreationDate: creationDate, SnoozedUntil: &snoozedUntil},
+			leadTime:           model.NotificationLeadTimeThreeDays,
+			now:                time.Date(2025, time.March, 8, 10, 0, 0, 0, time.UTC),
+			expected:           false,
+		},
+		{
+			desc:               "snoozed notification is due after snooze time",
+			scheduledOperation: model.ScheduledOperation{CreationDate: creationDate, SnoozedUntil: &snoozedUntil},
+			leadTime:           model.NotificationLeadTimeThreeDays,
+			now:                time.Date(2025, time.March, 8, 18, 0, 0, 0, time.UTC),
+			expected:           true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual := tc.scheduledOperation.IsNotificationDue(tc.leadTime, tc.now)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestParseNotificationLeadTime(t *testing.T) {
+	t.Parallel()
+
+	testCases := [...]struct {
+		desc          string
+		input         string
+		expected      model.NotificationLeadTime
+		expectedError bool
+	}{
+		{
+			desc:     "parsed same day lead time",
+			input:    "Same day",
+			expected: model.NotificationLeadTimeSameDay,
+		},
+		{
+			desc:     "parsed one day lead time",
+			input:    "1 day before",
+			expected: model.NotificationLeadTimeOneDay,
+		},
+		{
+			desc:     "parsed seven days lead time",
+			input:    "7 days before",
+			expected: model.NotificationLeadTimeSevenDays,
+		},
+		{
+			desc:          "received error for unknown lead time",
+			input:         "2 days before",
+			expectedError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := model.ParseNotificationLeadTime(tc.input)
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestBalanceSubscription_GetBillingStartDate(t *testing.T) {
+	t.Parallel()
+
+	startAt := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	trialEndAt := time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	testCases := [...]struct {
+		desc                string
+		balanceSubscription model.BalanceSubscription
+		expected            time.Time
+	}{
+		{
+			desc:                "subscription without trial is billed from start date",
+			balanceSubscription: model.BalanceSubscription{StartAt: startAt},
+			expected:            startAt,
+		},
+		{
+			desc:                "subscription with trial is billed from trial end",
+			balanceSubscription: model.BalanceSubscription{StartAt: startAt, TrialEndAt: &trialEndAt},
+			expected:            trialEndAt,
+		},
+		{
+			desc:                "subscription that starts after trial end is billed from start date",
+			balanceSubscription: model.BalanceSubscription{StartAt: trialEndAt.AddDate(0, 0, 1), TrialEndAt: &trialEndAt},
+			expected:            trialEndAt.AddDate(0, 0, 1),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.balanceSubscription.GetBillingStartDate())
+		})
+	}
+}
+
+func TestBalanceSubscription_IsTrialReminderDue(t *testing.T) {
+	t.Parallel()
+
+	trialEndAt := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	testCases := [...]struct {
+		desc                string
+		balanceSubscription model.BalanceSubscription
+		leadTime            model.NotificationLeadTime
+		now                 time.Time
+		expected            bool
+	}{
+		{
+			desc:                "reminder is not due for subscription without trial",
+			balanceSubscription: model.BalanceSubscription{},
+			leadTime:            model.NotificationLeadTimeThreeDays,
+			now:                 time.Date(2025, time.March, 9, 0, 0, 0, 0, time.UTC),
+			expected:            false,
+		},
+		{
+			desc:                "reminder is due lead time days before trial end",
+			balanceSubscription: model.BalanceSubscription{TrialEndAt: &trialEndAt},
+			leadTime:            model.NotificationLeadTimeThreeDays,
+			now:                 time.Date(2025, time.March, 7, 10, 0, 0, 0, time.UTC),
+			expected:            true,
+		},
+		{
+			desc:                "reminder is not due earlier than lead time",
+			balanceSubscription: model.BalanceSubscription{TrialEndAt: &trialEndAt},
+			leadTime:            model.NotificationLeadTimeThreeDays,
+			now:                 time.Date(2025, time.March, 6, 23, 0, 0, 0, time.UTC),
+			expected:            false,
+		},
+		{
+			desc:                "reminder is not due when it was already sent",
+			balanceSubscription: model.BalanceSubscription{TrialEndAt: &trialEndAt, TrialReminderSent: true},
+			leadTime:            model.NotificationLeadTimeThreeDays,
+			now:                 time.Date(2025, time.March, 8, 10, 0, 0, 0, time.UTC),
+			expected:            false,
+		},
+		{
+			desc:                "same day reminder is due on the trial end day",
+			balanceSubscription: model.BalanceSubscription{TrialEndAt: &trialEndAt},
+			leadTime:            model.NotificationLeadTimeSameDay,
+			now:                 time.Date(2025, time.March, 10, 10, 0, 0, 0, time.UTC),
+			expected:            true,
+		},
+		{
+			desc:                "same day reminder is not due before the trial end day",
+			balanceSubscription: model.BalanceSubscription{TrialEndAt: &trialEndAt},
+			leadTime:            model.NotificationLeadTimeSameDay,
+			now:                 time.Date(2025, time.March, 9, 23, 0, 0, 0, time.UTC),
+			expected:            false,
+		},
+		{
+			desc:                "reminder is not due after trial end day",
+			balanceSubscription: model.BalanceSubscription{TrialEndAt: &trialEndAt},
+			leadTime:            model.NotificationLeadTimeThreeDays,
+			now:                 time.Date(2025, time.March, 11, 0, 0, 0, 0, time.UTC),
+			expected:            false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.balanceSubscription.IsTrialReminderDue(tc.leadTime, tc.now))
+		})
+	}
+}
+
+func TestBalanceSubscription_GetChargeAmount(t *testing.T) {
+	t.Parallel()
+
+	trialEndAt := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	testCases := [...]struct {
+		desc                string
+		balanceSubscription model.BalanceSubscription
+		expected            string
+	}{
+		{
+			desc:                "subscription without trial is charged with its amount",
+			balanceSubscription: model.BalanceSubscription{Amount: "9.99"},
+			expected:            "9.99",
+		},
+		{
+			desc:                "subscription with trial and without amount after trial is charged with its amount",
+			balanceSubscription: model.BalanceSubscription{Amount: "9.99", TrialEndAt: &trialEndAt},
+			expected:            "9.99",
+		},
+		{
+			desc:                "subscription with trial is charged with amount after trial",
+			balanceSubscription: model.BalanceSubscription{Amount: "9.99", AmountAfterTrial: "14.99", TrialEndAt: &trialEndAt},
+			expected:            "14.99",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.balanceSubscription.GetChargeAmount())
+		})
+	}
+}
@@ -44,6 +44,16 @@ type UserSettings struct {
 	AIParserEnabled                 bool `db:"ai_parser_enabled"`
 	NotifyAboutSubscriptionPayments bool `db:"notify_about_subscription_payments"`
 
+	SubscriptionNotificationLeadTime NotificationLeadTime `db:"subscription_notification_lead_time"`
+	// BaseCurrencyCode represents the currency in which the net worth is calculated, empty when it's not chosen yet.
+	BaseCurrencyCode string `db:"base_currency_code"`
+
+	DigestFrequency DigestFrequency `db:"digest_frequency"`
+	DigestSendTime  DigestSendTime  `db:"digest_send_time"`
+	// LastDigestSentAt represents the moment when the digest was sent last time or its settings were changed,
+	// it's used to prevent sending the same digest twice. Nil when the digest was never sent.
+	LastDigestSentAt *time.Time `db:"last_digest_sent_at"`
+
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
 }
@@ -64,11 +74,27 @@ func (u *UserSettings) GetDetails() string {
 		notifyStatus = "Enabled"
 	}
 
+	baseCurrency := "Not set"
+	if u.BaseCurrencyCode != "" {
+		baseCurrency = u.BaseCurrencyCode
+	}
+
+	digest := u.DigestFrequency.String()
+	if u.DigestFrequency != DigestFrequencyOff && u.DigestFrequency != "" {
+		digest = fmt.Sprintf("%s at %s", u.DigestFrequency, u.DigestSendTime)
+	}
+
 	return fmt.Sprintf(`⚙️ *User Settings*
 
 🤖 AI Parser: %s %s
-🔔 Subscription Notifications: %s %s`,
+🔔 Subscription Notifications: %s %s
+⏰ Notification Lead Time: %s
+💱 Base Currency: %s
+📬 Digest: %s`,
 		aiParserIcon, aiParserStatus,
 		notifyIcon, notifyStatus,
+		u.SubscriptionNotificationLeadTime,
+		baseCurrency,
+		digest,
 	)
 }
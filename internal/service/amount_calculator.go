@@ -81,7 +81,7 @@ func calculateUpdatedTranferOperation(opts calculateTransferOperationOptions) {
 
 		switch opts.operationType {
 		case model.OperationTypeTransferIn:
-			updatedTransferOutAmount, _ := money.NewFromString(opts.updatedOperationAmount.StringFixed())
+			updatedTransferOutAmount, _ := money.NewFromString(opts.updatedOperationAmount.String())
 			updatedTransferOutAmount.Div(*opts.exchangeRate)
 			opts.balanceFrom.Sub(updatedTransferOutAmount)
 			opts.transferAmountOut.Set(updatedTransferOutAmount)
@@ -89,7 +89,7 @@ func calculateUpdatedTranferOperation(opts calculateTransferOperationOptions) {
 			opts.balanceTo.Inc(opts.updatedOperationAmount)
 			opts.transferAmountIn.Set(opts.updatedOperationAmount)
 		case model.OperationTypeTransferOut:
-			updatedTransferInAmount, _ := money.NewFromString(opts.updatedOperationAmount.StringFixed())
+			updatedTransferInAmount, _ := money.NewFromString(opts.updatedOperationAmount.String())
 			updatedTransferInAmount.Mul(*opts.exchangeRate)
 			opts.balanceTo.Inc(updatedTransferInAmount)
 			opts.transferAmountIn.Set(updatedTransferInAmount)
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/VladPetriv/finance_bot/pkg/errs"
 	"github.com/VladPetriv/finance_bot/pkg/money"
@@ -12,6 +13,7 @@ type APIs struct {
 	Messenger         Messenger
 	Prompter          Prompter
 	CurrencyExchanger CurrencyExchanger
+	CurrencyCatalog   CurrencyCatalog
 }
 
 // Messenger handles messaging operations between the application and messaging platform.
@@ -24,6 +26,10 @@ type Messenger interface {
 	SendWithKeyboard(opts SendWithKeyboardOptions) error
 	// UpdateMessage updates a message with new text and keyboard.
 	UpdateMessage(opts UpdateMessageOptions) error
+	// SendDocument sends a file as a document to the specified chat.
+	SendDocument(opts SendDocumentOptions) error
+	// SendPhoto sends an image as a photo to the specified chat.
+	SendPhoto(opts SendPhotoOptions) error
 
 	// Close closes the underlying connection to the messaging platform.
 	Close() error
@@ -50,6 +56,24 @@ type UpdateMessageOptions struct {
 	UpdatedMessage        string
 }
 
+// SendDocumentOptions represents options for sending a document.
+type SendDocumentOptions struct {
+	ChatID   int
+	FileName string
+	Content  []byte
+	Caption  string
+	Keyboard []KeyboardRow
+}
+
+// SendPhotoOptions represents options for sending a photo.
+type SendPhotoOptions struct {
+	ChatID   int
+	FileName string
+	Content  []byte
+	Caption  string
+	Keyboard []KeyboardRow
+}
+
 // KeyboardRow represents keyboard row with buttons.
 type KeyboardRow struct {
 	Buttons []string
@@ -80,12 +104,20 @@ type Message interface {
 	GetSenderName() string
 }
 
-// CurrencyExchanger handles currency exchange rates and supported currencies listing
+// CurrencyExchanger handles currency exchange rates
 type CurrencyExchanger interface {
-	// FetchCurrencies returns a list of available currencies.
-	FetchCurrencies() ([]Currency, error)
 	// GetExchangeRate returns the exchange rate for the specified currency.
 	GetExchangeRate(baseCurrency, targetCurrency string) (*money.Money, error)
+	// GetHistoricalExchangeRate returns the exchange rate for the specified currency on the provided date.
+	GetHistoricalExchangeRate(baseCurrency, targetCurrency string, date time.Time) (*money.Money, error)
+}
+
+// CurrencyCatalog provides the full list of currencies together with their names and symbols.
+// It never falls back to other providers, since their lists are usually incomplete
+// and refreshing the catalog from them would deprecate currencies which are still supported.
+type CurrencyCatalog interface {
+	// FetchCurrencies returns a list of available currencies.
+	FetchCurrencies() ([]Currency, error)
 }
 
 // Currency represents a structure that contains currency name, code and symbol.
@@ -98,6 +130,9 @@ type Currency struct {
 // ErrCurrencyExchangeRateNotFound happens when the CurrencyExchanger cannot find the exchange rate for the specified currency.
 var ErrCurrencyExchangeRateNotFound = errs.New("currency exchange rate not found")
 
+// ErrHistoricalExchangeRateNotFound happens when the exchange rate of the requested past date is neither stored nor provided by the currency exchanger.
+var ErrHistoricalExchangeRateNotFound = errs.New("currency exchange rate for the requested date not found")
+
 // Prompter executes prompts and returns generated responses
 type Prompter interface {
 	// Execute processes the prompt and returns the response
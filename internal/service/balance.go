@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"time"
 
 	"github.com/VladPetriv/finance_bot/internal/model"
 	"github.com/VladPetriv/finance_bot/pkg/errs"
@@ -70,16 +69,16 @@ func (h handlerService) handleEnterBalanceAmountFlowStepForCreate(ctx context.Co
 	logger := h.logger.With().Str("name", "handlerService.handleEnterBalanceAmountFlowStepForCreate").Logger()
 	logger.Debug().Any("opts", opts).Msg("got args")
 
-	parsedAmount, err := money.NewFromString(opts.message.GetText())
+	parsedAmount, err := money.NewFromExpression(opts.message.GetText())
 	if err != nil {
 		logger.Error().Err(err).Msg("convert option amount to money type")
 		return "", ErrInvalidAmountFormat
 	}
 
-	opts.stateMetaData.Add(model.BalanceAmountMetadataKey, parsedAmount.StringFixed())
+	opts.stateMetaData.Add(model.BalanceAmountMetadataKey, parsedAmount.String())
 	opts.stateMetaData.Add(model.PageMetadataKey, firstPage)
 
-	currenciesKeyboard, err := h.getCurrenciesKeyboard(ctx, firstPage)
+	currenciesKeyboard, err := h.getCurrenciesKeyboard(ctx, opts.user.ID, firstPage)
 	if err != nil {
 		logger.Error().Err(err).Msg("get currencies keyboard for balance")
 		return "", fmt.Errorf("get currencies keyboard for balance: %w", err)
@@ -101,7 +100,7 @@ func (h handlerService) handleEnterBalanceCurrencyFlowStepForCreate(ctx context.
 		nextPage := calculateNextPage(messageText, opts.stateMetaData)
 		opts.stateMetaData.Add(model.PageMetadataKey, nextPage)
 
-		currenciesKeyboard, err := h.getCurrenciesKeyboard(ctx, nextPage)
+		currenciesKeyboard, err := h.getCurrenciesKeyboard(ctx, opts.user.ID, nextPage)
 		if err != nil {
 			logger.Error().Err(err).Msg("get currencies keyboard for balance")
 			return "", fmt.Errorf("get currencies keyboard for balance: %w", err)
@@ -117,7 +116,8 @@ func (h handlerService) handleEnterBalanceCurrencyFlowStepForCreate(ctx context.
 	}
 
 	currency, err := h.stores.Currency.Get(ctx, GetCurrencyFilter{
-		ID: messageText,
+		ID:     messageText,
+		UserID: opts.user.ID,
 	})
 	if err != nil {
 		logger.Error().Err(err).Msg("get currency from store")
@@ -146,12 +146,18 @@ func (h handlerService) handleEnterBalanceCurrencyFlowStepForCreate(ctx context.
 		return "", fmt.Errorf("balance amount not found")
 	}
 
+	parsedBalanceAmount, err := money.NewFromString(balanceAmount)
+	if err != nil {
+		logger.Error().Err(err).Msg("parse balance amount")
+		return "", fmt.Errorf("parse balance amount: %w", err)
+	}
+
 	balance := model.Balance{
 		ID:         balanceID,
 		UserID:     opts.user.ID,
 		CurrencyID: messageText,
 		Name:       balanceName,
-		Amount:     balanceAmount,
+		Amount:     currency.StringifyAmount(parsedBalanceAmount),
 	}
 
 	err = h.stores.Balance.Create(ctx, &balance)
@@ -173,37 +179,36 @@ func (h handlerService) handleEnterBalanceCurrencyFlowStepForCreate(ctx context.
 	})
 }
 
-const maxMonthsPerRow = 3
-
 func (h handlerService) handleGetBalanceFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
 	logger := h.logger.With().Str("name", "handlerService.handleGetBalanceFlowStep").Logger()
 	logger.Debug().Any("opts", opts).Msg("got args")
 
-	availableMonths := model.Months[:time.Now().Month()]
-
 	err := h.showCancelButton(opts.message.GetChatID(), "")
 	if err != nil {
 		return "", fmt.Errorf("show cancle button: %w", err)
 	}
 
-	return model.ChooseMonthBalanceStatisticsFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+	return model.ChoosePeriodTypeFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
 		ChatID:         opts.message.GetChatID(),
-		Message:        "Please select a month to view your balance statistics:",
-		InlineKeyboard: getInlineKeyboardRows(availableMonths, maxMonthsPerRow),
+		Message:        "Please select a period to view your balance statistics:",
+		InlineKeyboard: periodTypeKeyboard,
 	})
 }
 
-func (h handlerService) handleChooseMonthBalanceStatisticsFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
-	logger := h.logger.With().Str("name", "handlerService.handleChooseMonthBalanceStatisticsFlowStep").Logger()
+func (h handlerService) handleChoosePeriodFlowStepForGetBalance(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChoosePeriodFlowStepForGetBalance").Logger()
 	logger.Debug().Any("opts", opts).Msg("got args")
 
-	opts.stateMetaData.Add(model.MonthForBalanceStatisticsKey, opts.message.GetText())
-	return model.ChooseBalanceFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
-		ChatID:                opts.message.GetChatID(),
-		MessageID:             opts.message.GetMessageID(),
-		InlineMessageID:       opts.message.GetInlineMessageID(),
-		UpdatedMessage:        "Select a balance to view information:",
-		UpdatedInlineKeyboard: getInlineKeyboardRows(opts.user.Balances, 2),
+	_, err := getPeriodFromMessage(opts)
+	if err != nil {
+		logger.Info().Err(err).Msg("invalid period")
+		return "", err
+	}
+
+	return model.ChooseBalanceFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:         opts.message.GetChatID(),
+		Message:        "Select a balance to view information:",
+		InlineKeyboard: getInlineKeyboardRows(opts.user.Balances, 2),
 	})
 }
 
@@ -232,15 +237,16 @@ func (h handlerService) handleChooseBalanceFlowStepForGetBalance(ctx context.Con
 		return "", fmt.Errorf("list categories from store: %w", err)
 	}
 
-	monthForBalanceStatistics, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.MonthForBalanceStatisticsKey)
-	if !ok {
-		logger.Error().Msg("month for balance statistics not found in metadata")
-		return "", fmt.Errorf("month for balance statistics not found")
+	period, err := getPeriodFromMetadata(opts.stateMetaData)
+	if err != nil {
+		logger.Error().Err(err).Msg("get period from metadata")
+		return "", fmt.Errorf("get period from metadata: %w", err)
 	}
 
 	operations, err := h.stores.Operation.List(ctx, ListOperationsFilter{
-		BalanceID: balance.ID,
-		Month:     model.Month(monthForBalanceStatistics),
+		BalanceID:     balance.ID,
+		CreatedAtFrom: period.From,
+		CreatedAtTo:   period.To,
 	})
 	if err != nil {
 		logger.Error().Err(err).Msg("list operations from store")
@@ -249,13 +255,13 @@ func (h handlerService) handleChooseBalanceFlowStepForGetBalance(ctx context.Con
 
 	outputMessage, err := model.
 		NewStatisticsMessageBuilder(balance, operations, categories).
-		Build(model.Month(monthForBalanceStatistics))
+		Build(period)
 	if err != nil {
 		logger.Error().Err(err).Msg("build statistic message")
 		return "", fmt.Errorf("build statistic message: %w", err)
 	}
 
-	return model.EndFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+	err = h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
 		ChatID:                  opts.message.GetChatID(),
 		MessageID:               opts.message.GetMessageID(),
 		InlineMessageID:         opts.message.GetInlineMessageID(),
@@ -263,6 +269,18 @@ func (h handlerService) handleChooseBalanceFlowStepForGetBalance(ctx context.Con
 		FormatMessageInMarkDown: true,
 		UpdatedKeyboard:         balanceKeyboardRows,
 	})
+	if err != nil {
+		logger.Error().Err(err).Msg("update message")
+		return "", fmt.Errorf("update message: %w", err)
+	}
+
+	// Telegram doesn't allow to attach both reply and inline keyboards to the same message,
+	// so the chart button is sent separately. It works after the flow is finished.
+	return model.EndFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:         opts.message.GetChatID(),
+		Message:        "Want to see these statistics on charts?",
+		InlineKeyboard: getBalanceStatisticsChartKeyboard(balance.ID, period),
+	})
 }
 
 func (h handlerService) handleUpdateBalanceFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
@@ -347,7 +365,7 @@ func (h handlerService) handleChooseUpdateBalanceOptionFlowStep(ctx context.Cont
 		})
 	case model.BotUpdateBalanceCurrencyCommand:
 		opts.stateMetaData.Add(model.PageMetadataKey, firstPage)
-		currenciesKeyboard, err := h.getCurrenciesKeyboard(ctx, firstPage)
+		currenciesKeyboard, err := h.getCurrenciesKeyboard(ctx, opts.user.ID, firstPage)
 		if err != nil {
 			logger.Error().Err(err).Msg("get currencies keyboard for balance")
 			return "", fmt.Errorf("get currencies keyboard for balance: %w", err)
@@ -449,7 +467,7 @@ func (h handlerService) handleEnterBalanceAmountFlowStepForUpdate(ctx context.Co
 
 	if opts.state.Flow == model.StartFlow {
 		opts.stateMetaData.Add(model.PageMetadataKey, firstPage)
-		currenciesKeyboard, err := h.getCurrenciesKeyboard(ctx, firstPage)
+		currenciesKeyboard, err := h.getCurrenciesKeyboard(ctx, opts.user.ID, firstPage)
 		if err != nil {
 			logger.Error().Err(err).Msg("get currencies keyboard for balance")
 			return "", fmt.Errorf("get currencies keyboard for balance: %w", err)
@@ -482,7 +500,7 @@ func (h handlerService) handleEnterBalanceCurrencyFlowStepForUpdate(ctx context.
 		nextPage := calculateNextPage(messageText, opts.stateMetaData)
 		opts.stateMetaData.Add(model.PageMetadataKey, nextPage)
 
-		currenciesKeyboard, err := h.getCurrenciesKeyboard(ctx, nextPage)
+		currenciesKeyboard, err := h.getCurrenciesKeyboard(ctx, opts.user.ID, nextPage)
 		if err != nil {
 			logger.Error().Err(err).Msg("get currencies keyboard for balance")
 			return "", fmt.Errorf("get currencies keyboard for balance: %w", err)
@@ -498,7 +516,8 @@ func (h handlerService) handleEnterBalanceCurrencyFlowStepForUpdate(ctx context.
 	}
 
 	currency, err := h.stores.Currency.Get(ctx, GetCurrencyFilter{
-		ID: messageText,
+		ID:     messageText,
+		UserID: opts.user.ID,
 	})
 	if err != nil {
 		logger.Error().Err(err).Msg("check if currency exists in store")
@@ -572,7 +591,8 @@ func (h handlerService) updateBalance(ctx context.Context, opts updateBalanceOpt
 	logger.Debug().Any("opts", opts).Msg("got args")
 
 	balance, err := h.stores.Balance.Get(ctx, GetBalanceFilter{
-		BalanceID: opts.balanceID,
+		BalanceID:       opts.balanceID,
+		PreloadCurrency: true,
 	})
 	if err != nil {
 		logger.Error().Err(err).Msg("get balance from store")
@@ -588,13 +608,13 @@ func (h handlerService) updateBalance(ctx context.Context, opts updateBalanceOpt
 	case model.EnterBalanceNameFlowStep:
 		balance.Name = opts.data
 	case model.EnterBalanceAmountFlowStep:
-		price, err := money.NewFromString(opts.data)
+		price, err := money.NewFromExpression(opts.data)
 		if err != nil {
 			logger.Error().Err(err).Msg("convert option amount to money type")
 			return nil, ErrInvalidAmountFormat
 		}
 
-		balance.Amount = price.StringFixed()
+		balance.Amount = balance.GetCurrency().StringifyAmount(price)
 	case model.EnterBalanceCurrencyFlowStep:
 		balance.CurrencyID = opts.data
 	}
@@ -724,3 +744,30 @@ func (h handlerService) handleChooseBalanceFlowStepForDelete(ctx context.Context
 		UpdatedMessage:  "Balance and all its operations have been deleted!",
 	})
 }
+
+// getBalanceFromMetadata returns the balance which name was saved into the state metadata on previous steps.
+func (h handlerService) getBalanceFromMetadata(ctx context.Context, opts flowProcessingOptions) (*model.Balance, error) {
+	logger := h.logger.With().Str("name", "handlerService.getBalanceFromMetadata").Logger()
+
+	balanceName, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.BalanceNameMetadataKey)
+	if !ok {
+		logger.Error().Msg("balance name not found in metadata")
+		return nil, fmt.Errorf("balance name not found in metadata")
+	}
+
+	balance, err := h.stores.Balance.Get(ctx, GetBalanceFilter{
+		Name:            balanceName,
+		PreloadCurrency: true,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get balance from store")
+		return nil, fmt.Errorf("get balance from store: %w", err)
+	}
+	if balance == nil {
+		logger.Error().Msg("balance not found")
+		return nil, fmt.Errorf("balance not found")
+	}
+	logger.Debug().Any("balance", balance).Msg("got balance")
+
+	return balance, nil
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/VladPetriv/finance_bot/pkg/chart"
+)
+
+// getBalanceStatisticsChartKeyboard returns the keyboard with a button for drawing charts of balance statistics.
+func getBalanceStatisticsChartKeyboard(balanceID string, period model.Period) []InlineKeyboardRow {
+	return []InlineKeyboardRow{
+		{
+			Buttons: []InlineKeyboardButton{
+				{
+					Text: "📊 Chart",
+					// Telegram limits callback data to 64 bytes, so spaces are removed from the custom period ID,
+					// it's still parsed into the same period.
+					Data: fmt.Sprintf(
+						"%s%s:%s",
+						model.BalanceStatisticsChartCallbackPrefix, balanceID, strings.ReplaceAll(period.GetID(), " ", ""),
+					),
+				},
+			},
+		},
+	}
+}
+
+func (h handlerService) HandleBalanceStatisticsChartAction(ctx context.Context, msg Message) error {
+	logger := h.logger.With().Str("name", "handlerService.HandleBalanceStatisticsChartAction").Logger()
+	logger.Debug().Any("msg", msg).Msg("got args")
+
+	balanceID, periodID, ok := strings.Cut(strings.TrimPrefix(msg.GetText(), model.BalanceStatisticsChartCallbackPrefix), ":")
+	if !ok {
+		logger.Error().Msg("invalid balance statistics chart callback data")
+		return fmt.Errorf("invalid balance statistics chart callback data")
+	}
+
+	period, err := model.ParsePeriod(periodID)
+	if err != nil {
+		logger.Error().Err(err).Msg("parse period")
+		return fmt.Errorf("parse period: %w", err)
+	}
+
+	balance, err := h.stores.Balance.Get(ctx, GetBalanceFilter{
+		BalanceID:       balanceID,
+		PreloadCurrency: true,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get balance from store")
+		return fmt.Errorf("get balance from store: %w", err)
+	}
+	if balance == nil {
+		logger.Info().Msg("balance not found")
+		return ErrBalanceNotFound
+	}
+
+	// Make sure that user can see charts only of his own balances.
+	user, err := h.stores.User.Get(ctx, GetUserFilter{
+		BalanceID: balance.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get user from store")
+		return fmt.Errorf("get user from store: %w", err)
+	}
+	if user == nil || user.Username != msg.GetSenderName() {
+		logger.Info().Msg("balance does not belong to the user")
+		return ErrBalanceNotFound
+	}
+
+	categories, err := h.stores.Category.List(ctx, &ListCategoriesFilter{
+		UserID: user.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list categories from store")
+		return fmt.Errorf("list categories from store: %w", err)
+	}
+
+	// Operations created after the period are needed to restore the balance amount at the end of each day.
+	operations, err := h.stores.Operation.List(ctx, ListOperationsFilter{
+		BalanceID:     balance.ID,
+		CreatedAtFrom: period.From,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list operations from store")
+		return fmt.Errorf("list operations from store: %w", err)
+	}
+
+	chartData, err := model.BuildStatisticsChartData(balance, operations, categories, period, time.Now())
+	if err != nil {
+		logger.Error().Err(err).Msg("build statistics chart data")
+		return fmt.Errorf("build statistics chart data: %w", err)
+	}
+
+	charts := []struct {
+		caption string
+		title   string
+		draw    func(title string, values []chart.Value) ([]byte, error)
+		values  []model.ChartValue
+	}{
+		{
+			caption: "🍩 Spending by categories",
+			title:   "Spending by categories",
+			draw:    chart.Donut,
+			values:  chartData.SpendingByCategory,
+		},
+		{
+			caption: "📊 Daily spending",
+			title:   "Daily spending",
+			draw:    chart.Bars,
+			values:  chartData.DailySpending,
+		},
+		{
+			caption: "📈 Balance over time",
+			title:   "Balance over time",
+			draw:    chart.Line,
+			values:  chartData.BalanceHistory,
+		},
+	}
+
+	err = h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:          msg.GetChatID(),
+		MessageID:       msg.GetMessageID(),
+		InlineMessageID: msg.GetInlineMessageID(),
+		UpdatedMessage:  fmt.Sprintf("📊 Charts of %s balance for %s:", balance.Name, period.GetName()),
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("update message")
+		return fmt.Errorf("update message: %w", err)
+	}
+
+	var sentChartsCount int
+	for _, c := range charts {
+		content, err := c.draw(fmt.Sprintf("%s: %s (%s)", c.title, balance.Name, period.GetName()), getChartValues(c.values))
+		if err != nil {
+			if errors.Is(err, chart.ErrNoValues) {
+				continue
+			}
+
+			logger.Error().Err(err).Str("caption", c.caption).Msg("draw chart")
+			return fmt.Errorf("draw chart: %w", err)
+		}
+
+		err = h.apis.Messenger.SendPhoto(SendPhotoOptions{
+			ChatID:   msg.GetChatID(),
+			FileName: "chart.png",
+			Content:  content,
+			Caption:  c.caption,
+		})
+		if err != nil {
+			logger.Error().Err(err).Msg("send photo")
+			return fmt.Errorf("send photo: %w", err)
+		}
+
+		sentChartsCount++
+	}
+
+	if sentChartsCount == 0 {
+		return h.apis.Messenger.SendMessage(msg.GetChatID(), "There is nothing to draw, no operations were found for the period.")
+	}
+
+	return nil
+}
+
+func getChartValues(values []model.ChartValue) []chart.Value {
+	chartValues := make([]chart.Value, 0, len(values))
+	for _, value := range values {
+		chartValues = append(chartValues, chart.Value{
+			Label: value.Label,
+			Value: value.Amount.Float64(),
+		})
+	}
+
+	return chartValues
+}
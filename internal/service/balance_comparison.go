@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+)
+
+func (h handlerService) handleCompareBalancePeriodsFlowStep(_ context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleCompareBalancePeriodsFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	err := h.showCancelButton(opts.message.GetChatID(), "")
+	if err != nil {
+		logger.Error().Err(err).Msg("show cancel button")
+		return "", fmt.Errorf("show cancel button: %w", err)
+	}
+
+	return model.ChooseBalanceFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:         opts.message.GetChatID(),
+		Message:        "Select a balance to compare its statistics:",
+		InlineKeyboard: getInlineKeyboardRows(opts.user.Balances, 2),
+	})
+}
+
+func (h handlerService) handleChooseBalanceFlowStepForCompareBalancePeriods(_ context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseBalanceFlowStepForCompareBalancePeriods").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	opts.stateMetaData.Add(model.BalanceNameMetadataKey, opts.message.GetText())
+
+	return model.ChoosePeriodTypeFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                opts.message.GetChatID(),
+		MessageID:             opts.message.GetMessageID(),
+		InlineMessageID:       opts.message.GetInlineMessageID(),
+		UpdatedMessage:        "Please select a period:",
+		UpdatedInlineKeyboard: periodTypeKeyboard,
+	})
+}
+
+func (h handlerService) handleChoosePeriodTypeFlowStepForCompareBalancePeriods(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChoosePeriodTypeFlowStepForCompareBalancePeriods").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	// The keyboard for choosing the compared period contains shortcuts with the previous period
+	// and the same period last year, which are already complete periods.
+	_, err := model.ParsePeriod(opts.message.GetText())
+	if err == nil {
+		return h.handleChoosePeriodFlowStepForCompareBalancePeriods(ctx, opts)
+	}
+
+	return h.handleChoosePeriodTypeFlowStep(ctx, opts)
+}
+
+func (h handlerService) handleChoosePeriodFlowStepForCompareBalancePeriods(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChoosePeriodFlowStepForCompareBalancePeriods").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	_, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.PeriodMetadataKey)
+	if !ok {
+		period, err := getPeriodFromMessage(opts)
+		if err != nil {
+			logger.Info().Err(err).Msg("invalid period")
+			return "", err
+		}
+
+		previousPeriod, lastYearPeriod := period.GetPrevious(), period.GetSamePeriodLastYear()
+		keyboard := append([]InlineKeyboardRow{
+			{
+				Buttons: []InlineKeyboardButton{
+					{
+						Text: "Previous: " + previousPeriod.GetName(),
+						Data: previousPeriod.GetID(),
+					},
+				},
+			},
+			{
+				Buttons: []InlineKeyboardButton{
+					{
+						Text: "Last Year: " + lastYearPeriod.GetName(),
+						Data: lastYearPeriod.GetID(),
+					},
+				},
+			},
+		}, periodTypeKeyboard...)
+
+		return model.ChoosePeriodTypeFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+			ChatID:         opts.message.GetChatID(),
+			Message:        fmt.Sprintf("Please select a period to compare %s with:", period.GetName()),
+			InlineKeyboard: keyboard,
+		})
+	}
+
+	comparedPeriod, err := model.ParsePeriod(opts.message.GetText())
+	if err != nil {
+		logger.Info().Err(err).Msg("invalid compared period")
+		return "", ErrInvalidPeriod
+	}
+
+	period, err := getPeriodFromMetadata(opts.stateMetaData)
+	if err != nil {
+		logger.Error().Err(err).Msg("get period from metadata")
+		return "", fmt.Errorf("get period from metadata: %w", err)
+	}
+
+	balance, err := h.getBalanceFromMetadata(ctx, opts)
+	if err != nil {
+		logger.Error().Err(err).Msg("get balance from metadata")
+		return "", fmt.Errorf("get balance from metadata: %w", err)
+	}
+
+	categories, err := h.stores.Category.List(ctx, &ListCategoriesFilter{
+		UserID: opts.user.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list categories from store")
+		return "", fmt.Errorf("list categories from store: %w", err)
+	}
+
+	periodsOperations := make([]model.PeriodOperations, 0, 2)
+	for _, p := range []model.Period{period, comparedPeriod} {
+		operations, err := h.stores.Operation.List(ctx, ListOperationsFilter{
+			BalanceID:     balance.ID,
+			CreatedAtFrom: p.From,
+			CreatedAtTo:   p.To,
+		})
+		if err != nil {
+			logger.Error().Err(err).Msg("list operations from store")
+			return "", fmt.Errorf("list operations from store: %w", err)
+		}
+
+		periodsOperations = append(periodsOperations, model.PeriodOperations{
+			Period:     p,
+			Operations: operations,
+		})
+	}
+
+	outputMessage, err := model.
+		NewComparisonMessageBuilder(balance, categories).
+		Build(periodsOperations[0], periodsOperations[1])
+	if err != nil {
+		logger.Error().Err(err).Msg("build comparison message")
+		return "", fmt.Errorf("build comparison message: %w", err)
+	}
+
+	return model.EndFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:                  opts.message.GetChatID(),
+		Message:                 outputMessage,
+		FormatMessageInMarkDown: true,
+		Keyboard:                balanceKeyboardRows,
+	})
+}
@@ -3,8 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
-	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/VladPetriv/finance_bot/internal/model"
@@ -53,12 +53,18 @@ func (h *handlerService) handleChooseBalanceFlowStepForCreateBalanceSubscription
 		return model.EndFlowStep, ErrCategoriesNotFound
 	}
 
+	// Subscriptions are always charged as spending operations.
+	categories = model.FilterCategoriesByOperationType(model.GetActiveCategories(categories), model.OperationTypeSpending)
+	if len(categories) == 0 {
+		return model.EndFlowStep, ErrCategoriesForOperationTypeNotFound
+	}
+
 	return model.ChooseCategoryFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
 		ChatID:                opts.message.GetChatID(),
 		MessageID:             opts.message.GetMessageID(),
 		InlineMessageID:       opts.message.GetInlineMessageID(),
 		UpdatedMessage:        "Choose category for your subscription:",
-		UpdatedInlineKeyboard: getInlineKeyboardRows(categories, 3),
+		UpdatedInlineKeyboard: getInlineKeyboardRows(model.GetTopLevelCategories(categories), 3),
 	})
 }
 
@@ -67,7 +73,8 @@ func (h *handlerService) handleChooseCategoryFlowStepForCreateBalanceSubscriptio
 	logger.Debug().Any("opts", opts).Msg("got args")
 
 	category, err := h.stores.Category.Get(ctx, GetCategoryFilter{
-		Title: opts.message.GetText(),
+		UserID: opts.user.ID,
+		Title:  opts.message.GetText(),
 	})
 	if err != nil {
 		logger.Error().Err(err).Msg("get category from store")
@@ -77,6 +84,25 @@ func (h *handlerService) handleChooseCategoryFlowStepForCreateBalanceSubscriptio
 		return model.EndFlowStep, ErrCategoryNotFound
 	}
 
+	err = h.checkCategoryCanBeChosen(ctx, opts.user.ID, category, model.OperationTypeSpending)
+	if err != nil {
+		if errs.IsExpected(err) {
+			logger.Info().Err(err).Msg(err.Error())
+			return "", err
+		}
+		logger.Error().Err(err).Msg("check category can be chosen")
+		return "", fmt.Errorf("check category can be chosen: %w", err)
+	}
+
+	subcategoriesShown, err := h.showSubcategoriesIfNeeded(ctx, opts, category, model.OperationTypeSpending)
+	if err != nil {
+		logger.Error().Err(err).Msg("show sub-categories")
+		return "", fmt.Errorf("show sub-categories: %w", err)
+	}
+	if subcategoriesShown {
+		return model.ChooseCategoryFlowStep, nil
+	}
+
 	opts.stateMetaData.Add(model.CategoryIDMetadataKey, category.ID)
 	return model.EnterBalanceSubscriptionNameFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
 		ChatID:          opts.message.GetChatID(),
@@ -91,23 +117,83 @@ func (h *handlerService) handleEnterBalanceSubscriptionNameFlowStep(ctx context.
 	logger.Debug().Any("opts", opts).Msg("got args")
 
 	opts.stateMetaData.Add(model.BalanceSubscriptionNameMetadataKey, opts.message.GetText())
-	return model.EnterBalanceSubscriptionAmountFlowStep, h.apis.Messenger.SendMessage(opts.message.GetChatID(), "Enter balance subscription amount:")
+	return model.EnterBalanceSubscriptionAmountFlowStep, h.apis.Messenger.SendMessage(
+		opts.message.GetChatID(), "Enter balance subscription amount:",
+	)
 }
 
 func (h *handlerService) handleEnterBalanceSubscriptionAmountFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
 	logger := h.logger.With().Str("name", "handlerService.handleEnterBalanceSubscriptionAmountToFlowStep").Logger()
 	logger.Debug().Any("opts", opts).Msg("got args")
 
-	parsedAmount, err := money.NewFromString(opts.message.GetText())
+	parsedAmount, err := money.NewFromExpression(opts.message.GetText())
 	if err != nil {
 		return "", ErrInvalidAmountFormat
 	}
 
 	opts.stateMetaData.Add(model.BalanceSubscriptionAmountMetadataKey, parsedAmount.String())
-	return model.ChooseBalanceSubscriptionFrequencyFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+	opts.stateMetaData.Add(model.PageMetadataKey, firstPage)
+
+	currenciesKeyboard, err := h.getBalanceSubscriptionCurrenciesKeyboard(ctx, opts.user.ID, firstPage)
+	if err != nil {
+		logger.Error().Err(err).Msg("get currencies keyboard for balance subscription")
+		return "", fmt.Errorf("get currencies keyboard for balance subscription: %w", err)
+	}
+
+	return model.ChooseBalanceSubscriptionCurrencyFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
 		ChatID:         opts.message.GetChatID(),
-		Message:        "Choose subscription frequency:",
-		InlineKeyboard: balanceSubscriptionFrequencyKeyboard,
+		Message:        "Select currency in which the subscription is charged:",
+		InlineKeyboard: currenciesKeyboard,
+	})
+}
+
+func (h *handlerService) handleChooseBalanceSubscriptionCurrencyFlowStepForCreate(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseBalanceSubscriptionCurrencyFlowStepForCreate").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	messageText := opts.message.GetText()
+	if isPaginationNeeded(messageText) {
+		nextPage := calculateNextPage(messageText, opts.stateMetaData)
+		opts.stateMetaData.Add(model.PageMetadataKey, nextPage)
+
+		currenciesKeyboard, err := h.getBalanceSubscriptionCurrenciesKeyboard(ctx, opts.user.ID, nextPage)
+		if err != nil {
+			logger.Error().Err(err).Msg("get currencies keyboard for balance subscription")
+			return "", fmt.Errorf("get currencies keyboard for balance subscription: %w", err)
+		}
+
+		return model.ChooseBalanceSubscriptionCurrencyFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+			ChatID:                opts.message.GetChatID(),
+			MessageID:             opts.message.GetMessageID(),
+			InlineMessageID:       opts.message.GetInlineMessageID(),
+			UpdatedMessage:        "Select currency in which the subscription is charged:",
+			UpdatedInlineKeyboard: currenciesKeyboard,
+		})
+	}
+
+	if messageText != model.BalanceSubscriptionCurrencyDefaultLabel {
+		currency, err := h.stores.Currency.Get(ctx, GetCurrencyFilter{
+			ID:     messageText,
+			UserID: opts.user.ID,
+		})
+		if err != nil {
+			logger.Error().Err(err).Msg("get currency from store")
+			return "", fmt.Errorf("get currency from store: %w", err)
+		}
+		if currency == nil {
+			logger.Info().Msg("currency not found")
+			return model.ChooseBalanceSubscriptionCurrencyFlowStep, ErrCurrencyNotFound
+		}
+
+		opts.stateMetaData.Add(model.CurrencyIDMetadataKey, currency.ID)
+	}
+
+	return model.ChooseBalanceSubscriptionFrequencyFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                opts.message.GetChatID(),
+		MessageID:             opts.message.GetMessageID(),
+		InlineMessageID:       opts.message.GetInlineMessageID(),
+		UpdatedMessage:        "Choose subscription frequency:",
+		UpdatedInlineKeyboard: balanceSubscriptionFrequencyKeyboard,
 	})
 }
 
@@ -135,76 +221,242 @@ func (h *handlerService) handleChooseBalanceSubscriptionFrequencyFlowStep(_ cont
 	)
 }
 
-const balanceSubscriptionTimeFormat = "02/01/2006"
+const (
+	balanceSubscriptionTimeFormat = "02/01/2006"
+
+	skipBalanceSubscriptionAmountAfterTrialData = "skip_amount_after_trial"
+)
 
 func (h *handlerService) handleEnterStartAtDateForBalanceSubscriptionFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
 	logger := h.logger.With().Str("name", "handlerService.handleEnterStartAtDateForBalanceSubscriptionFlowStep").Logger()
 	logger.Debug().Any("opts", opts).Msg("got args")
 
-	parsedStartAtTime, err := time.Parse(balanceSubscriptionTimeFormat, opts.message.GetText())
+	_, err := time.Parse(balanceSubscriptionTimeFormat, opts.message.GetText())
 	if err != nil {
 		logger.Error().Err(err).Msg("parse operation date")
 		return "", ErrInvalidDateFormat
 	}
 
-	balanceID, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.BalanceIDMetadataKey)
+	opts.stateMetaData.Add(model.BalanceSubscriptionStartAtMetadataKey, opts.message.GetText())
+	return model.ConfirmBalanceSubscriptionTrialFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:         opts.message.GetChatID(),
+		Message:        "Does this subscription start with a free trial?",
+		InlineKeyboard: confirmationInlineKeyboardRows,
+	})
+}
+
+func (h *handlerService) handleConfirmBalanceSubscriptionTrialFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleConfirmBalanceSubscriptionTrialFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	withTrial, err := strconv.ParseBool(opts.message.GetText())
+	if err != nil {
+		logger.Error().Err(err).Msg("parse callback data to bool")
+		return "", fmt.Errorf("parse callback data to bool: %w", err)
+	}
+	if withTrial {
+		return model.EnterBalanceSubscriptionTrialEndDateFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+			ChatID:          opts.message.GetChatID(),
+			MessageID:       opts.message.GetMessageID(),
+			InlineMessageID: opts.message.GetInlineMessageID(),
+			UpdatedMessage:  "Enter the date when free trial ends and the subscription will be charged:\nUse format: DD/MM/YYYY\nExample: 01/01/2025:",
+		})
+	}
+
+	balanceSubscription, err := h.createBalanceSubscriptionFromMetadata(ctx, opts.stateMetaData)
+	if err != nil {
+		logger.Error().Err(err).Msg("create balance subscription from metadata")
+		return "", fmt.Errorf("create balance subscription from metadata: %w", err)
+	}
+
+	return model.EndFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:   opts.message.GetChatID(),
+		Message:  fmt.Sprintf("Balance subscription successfully created!\n\n%s", balanceSubscription.GetDetails()),
+		Keyboard: balanceSubscriptionKeyboardRows,
+	})
+}
+
+func (h *handlerService) handleEnterBalanceSubscriptionTrialEndDateFlowStep(_ context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleEnterBalanceSubscriptionTrialEndDateFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	trialEndAt, err := time.Parse(balanceSubscriptionTimeFormat, opts.message.GetText())
+	if err != nil {
+		logger.Error().Err(err).Msg("parse trial end date")
+		return "", ErrInvalidDateFormat
+	}
+
+	balanceSubscriptionStartAt, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.BalanceSubscriptionStartAtMetadataKey)
+	if !ok {
+		logger.Error().Msg("balance subscription start at not found in metadata")
+		return "", fmt.Errorf("balance subscription start at not found in metadata")
+	}
+
+	startAt, err := time.Parse(balanceSubscriptionTimeFormat, balanceSubscriptionStartAt)
+	if err != nil {
+		logger.Error().Err(err).Msg("parse subscription start at")
+		return "", fmt.Errorf("parse subscription start at: %w", err)
+	}
+
+	if !trialEndAt.After(startAt) {
+		logger.Info().Any("trialEndAt", trialEndAt).Any("startAt", startAt).Msg("trial ends before subscription start")
+		return "", ErrInvalidTrialEndDate
+	}
+
+	opts.stateMetaData.Add(model.BalanceSubscriptionTrialEndAtMetadataKey, opts.message.GetText())
+	return model.EnterBalanceSubscriptionAmountAfterTrialFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:  opts.message.GetChatID(),
+		Message: "Enter the amount that will be charged after the free trial ends:",
+		InlineKeyboard: []InlineKeyboardRow{
+			{
+				Buttons: []InlineKeyboardButton{
+					{
+						Text: "Same as subscription amount ⏭️",
+						Data: skipBalanceSubscriptionAmountAfterTrialData,
+					},
+				},
+			},
+		},
+	})
+}
+
+func (h *handlerService) handleEnterBalanceSubscriptionAmountAfterTrialFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleEnterBalanceSubscriptionAmountAfterTrialFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	if opts.message.GetText() != skipBalanceSubscriptionAmountAfterTrialData {
+		parsedAmount, err := money.NewFromExpression(opts.message.GetText())
+		if err != nil {
+			return "", ErrInvalidAmountFormat
+		}
+
+		opts.stateMetaData.Add(model.BalanceSubscriptionAmountAfterTrialMetadataKey, parsedAmount.String())
+	}
+
+	balanceSubscription, err := h.createBalanceSubscriptionFromMetadata(ctx, opts.stateMetaData)
+	if err != nil {
+		logger.Error().Err(err).Msg("create balance subscription from metadata")
+		return "", fmt.Errorf("create balance subscription from metadata: %w", err)
+	}
+
+	return model.EndFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:   opts.message.GetChatID(),
+		Message:  fmt.Sprintf("Balance subscription successfully created!\n\n%s", balanceSubscription.GetDetails()),
+		Keyboard: balanceSubscriptionKeyboardRows,
+	})
+}
+
+// createBalanceSubscriptionFromMetadata creates balance subscription from the details collected during the creation flow.
+// When trial end date is collected, the subscription is not charged until the free trial ends.
+func (h *handlerService) createBalanceSubscriptionFromMetadata(ctx context.Context, metaData model.Metadata) (*model.BalanceSubscription, error) {
+	logger := h.logger.With().Str("name", "handlerService.createBalanceSubscriptionFromMetadata").Logger()
+	logger.Debug().Any("metaData", metaData).Msg("got args")
+
+	balanceID, ok := model.GetTypedFromMetadata[string](metaData, model.BalanceIDMetadataKey)
 	if !ok {
 		logger.Error().Msg("balance id not found in metadata")
-		return "", fmt.Errorf("balance id not found in metadata")
+		return nil, fmt.Errorf("balance id not found in metadata")
 	}
 
-	categoryID, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.CategoryIDMetadataKey)
+	categoryID, ok := model.GetTypedFromMetadata[string](metaData, model.CategoryIDMetadataKey)
 	if !ok {
 		logger.Error().Msg("category id not found in metadata")
-		return "", fmt.Errorf("category id not found in metadata")
+		return nil, fmt.Errorf("category id not found in metadata")
 	}
 
-	balanceSubscriptionName, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.BalanceSubscriptionNameMetadataKey)
+	balanceSubscriptionName, ok := model.GetTypedFromMetadata[string](metaData, model.BalanceSubscriptionNameMetadataKey)
 	if !ok {
 		logger.Error().Msg("balance subscription name not found in metadata")
-		return "", fmt.Errorf("balance subscription name not found in metadata")
+		return nil, fmt.Errorf("balance subscription name not found in metadata")
 	}
 
-	balanceSubscriptionAmount, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.BalanceSubscriptionAmountMetadataKey)
+	balanceSubscriptionAmount, ok := model.GetTypedFromMetadata[string](metaData, model.BalanceSubscriptionAmountMetadataKey)
 	if !ok {
 		logger.Error().Msg("balance subscription amount not found in metadata")
-		return "", fmt.Errorf("balance subscription amount not found in metadata")
+		return nil, fmt.Errorf("balance subscription amount not found in metadata")
 	}
 
-	balanceSubscriptionPeriod, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.BalanceSubscriptionPeriodMetadataKey)
+	// Currency is not set when the subscription is charged in the balance currency.
+	currencyID, _ := model.GetTypedFromMetadata[string](metaData, model.CurrencyIDMetadataKey)
+
+	balanceSubscriptionPeriod, ok := model.GetTypedFromMetadata[string](metaData, model.BalanceSubscriptionPeriodMetadataKey)
 	if !ok {
 		logger.Error().Msg("balance subscription period not found in metadata")
-		return "", fmt.Errorf("balance subscription period not found in metadata")
+		return nil, fmt.Errorf("balance subscription period not found in metadata")
 	}
 
 	period, err := model.ParseSubscriptionPeriod(balanceSubscriptionPeriod)
 	if err != nil {
-		return "", fmt.Errorf("parse subscription period: %w", err)
+		return nil, fmt.Errorf("parse subscription period: %w", err)
+	}
+
+	balanceSubscriptionStartAt, ok := model.GetTypedFromMetadata[string](metaData, model.BalanceSubscriptionStartAtMetadataKey)
+	if !ok {
+		logger.Error().Msg("balance subscription start at not found in metadata")
+		return nil, fmt.Errorf("balance subscription start at not found in metadata")
+	}
+
+	startAt, err := time.Parse(balanceSubscriptionTimeFormat, balanceSubscriptionStartAt)
+	if err != nil {
+		return nil, fmt.Errorf("parse subscription start at: %w", err)
+	}
+
+	// Trial end date and amount after trial are collected only for subscriptions that start with a free trial.
+	var trialEndAt *time.Time
+	balanceSubscriptionTrialEndAt, ok := model.GetTypedFromMetadata[string](metaData, model.BalanceSubscriptionTrialEndAtMetadataKey)
+	if ok {
+		parsedTrialEndAt, err := time.Parse(balanceSubscriptionTimeFormat, balanceSubscriptionTrialEndAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse subscription trial end at: %w", err)
+		}
+		trialEndAt = &parsedTrialEndAt
+	}
+	balanceSubscriptionAmountAfterTrial, _ := model.GetTypedFromMetadata[string](metaData, model.BalanceSubscriptionAmountAfterTrialMetadataKey)
+
+	parsedAmount, err := money.NewFromString(balanceSubscriptionAmount)
+	if err != nil {
+		return nil, fmt.Errorf("parse subscription amount: %w", err)
 	}
 
 	balanceSubscription := model.BalanceSubscription{
 		ID:         uuid.NewString(),
 		BalanceID:  balanceID,
 		CategoryID: categoryID,
+		CurrencyID: currencyID,
 		Name:       balanceSubscriptionName,
-		Amount:     balanceSubscriptionAmount,
 		Period:     period,
-		StartAt:    parsedStartAtTime,
+		TrialEndAt: trialEndAt,
+		StartAt:    startAt,
+	}
+
+	balanceSubscription.Amount, err = h.stringifyBalanceSubscriptionAmount(ctx, balanceSubscription, parsedAmount)
+	if err != nil {
+		logger.Error().Err(err).Msg("stringify balance subscription amount")
+		return nil, fmt.Errorf("stringify balance subscription amount: %w", err)
+	}
+
+	if balanceSubscriptionAmountAfterTrial != "" {
+		parsedAmountAfterTrial, err := money.NewFromString(balanceSubscriptionAmountAfterTrial)
+		if err != nil {
+			return nil, fmt.Errorf("parse subscription amount after trial: %w", err)
+		}
+
+		balanceSubscription.AmountAfterTrial, err = h.stringifyBalanceSubscriptionAmount(ctx, balanceSubscription, parsedAmountAfterTrial)
+		if err != nil {
+			logger.Error().Err(err).Msg("stringify balance subscription amount after trial")
+			return nil, fmt.Errorf("stringify balance subscription amount after trial: %w", err)
+		}
 	}
 
 	err = h.stores.BalanceSubscription.Create(ctx, balanceSubscription)
 	if err != nil {
 		logger.Error().Err(err).Msg("create balance subscription in store")
-		return "", fmt.Errorf("create balance subscription in store: %w", err)
+		return nil, fmt.Errorf("create balance subscription in store: %w", err)
 	}
 
 	go h.services.BalanceSubscriptionEngine.ScheduleOperationsCreation(ctx, balanceSubscription)
 
-	return model.EndFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
-		ChatID:   opts.message.GetChatID(),
-		Message:  fmt.Sprintf("Balance subscription successfully created!\n\n%s", balanceSubscription.GetDetails()),
-		Keyboard: balanceSubscriptionKeyboardRows,
-	})
+	return &balanceSubscription, nil
 }
 
 // List Balance Subscriptions
@@ -384,12 +636,23 @@ func (h *handlerService) handleChooseBalanceSubscriptionToUpdateFlowStep(ctx con
 		return "", ErrBalanceSubscriptionNotFound
 	}
 
+	changes, err := h.stores.BalanceSubscription.ListChanges(ctx, ListBalanceSubscriptionChangesFilter{
+		SubscriptionIDs: []string{balanceSubscription.ID},
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list balance subscription changes from store")
+		return "", fmt.Errorf("list balance subscription changes from store: %w", err)
+	}
+
 	opts.stateMetaData.Add(model.BalanceSubscriptionIDMetadataKey, balanceSubscription.ID)
 	return model.ChooseUpdateBalanceSubscriptionOptionFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
-		ChatID:                opts.message.GetChatID(),
-		MessageID:             opts.message.GetMessageID(),
-		InlineMessageID:       opts.message.GetInlineMessageID(),
-		UpdatedMessage:        "Choose update balance subscription option:",
+		ChatID:          opts.message.GetChatID(),
+		MessageID:       opts.message.GetMessageID(),
+		InlineMessageID: opts.message.GetInlineMessageID(),
+		UpdatedMessage: fmt.Sprintf(
+			"%s\n\n%s\n\nChoose update balance subscription option:",
+			balanceSubscription.GetDetails(), model.BuildBalanceSubscriptionChangesHistory(changes),
+		),
 		UpdatedInlineKeyboard: updateBalanceSubscriptionOptionsKeyboard,
 	})
 }
@@ -447,10 +710,15 @@ func (h *handlerService) handleChooseUpdateBalanceSubscriptionOptionFlowStep(ctx
 		}
 
 		var currentCategory string
-		categoriesWithoutAlreadyUsedCategory := slices.DeleteFunc(categories, func(category model.Category) bool {
-			currentCategory = category.Title
-			return category.ID == balanceSubscription.CategoryID
-		})
+		for _, category := range categories {
+			if category.ID == balanceSubscription.CategoryID {
+				currentCategory = model.GetCategoryFullTitle(category, categories)
+			}
+		}
+
+		categoriesWithoutAlreadyUsedCategory := getCategoriesToChoose(
+			model.FilterCategoriesByOperationType(categories, model.OperationTypeSpending), balanceSubscription.CategoryID,
+		)
 
 		// User does not have enough categories to choose from
 		if len(categoriesWithoutAlreadyUsedCategory) == 0 {
@@ -489,6 +757,35 @@ func (h *handlerService) handleChooseUpdateBalanceSubscriptionOptionFlowStep(ctx
 			UpdatedMessage:          fmt.Sprintf("Select updated balance subscription frequency(Current: `%s`):", balanceSubscription.Period),
 			UpdatedInlineKeyboard:   balanceSubscriptionFrequencyKeyboard,
 		})
+	case model.BotUpdateBalanceSubscriptionNotificationLeadTimeCommand:
+		currentLeadTime := model.NotificationLeadTimeDefaultLabel
+		if balanceSubscription.NotificationLeadTime != nil {
+			currentLeadTime = balanceSubscription.NotificationLeadTime.String()
+		}
+
+		return model.ChooseBalanceSubscriptionNotificationLeadTimeFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+			ChatID:                  opts.message.GetChatID(),
+			MessageID:               opts.message.GetMessageID(),
+			InlineMessageID:         opts.message.GetInlineMessageID(),
+			FormatMessageInMarkDown: true,
+			UpdatedMessage:          fmt.Sprintf("Select when to be reminded about this subscription payment(Current: `%s`):", currentLeadTime),
+			UpdatedInlineKeyboard:   getNotificationLeadTimeKeyboard(true),
+		})
+	case model.BotUpdateBalanceSubscriptionCurrencyCommand:
+		opts.stateMetaData.Add(model.PageMetadataKey, firstPage)
+		currenciesKeyboard, err := h.getBalanceSubscriptionCurrenciesKeyboard(ctx, opts.user.ID, firstPage)
+		if err != nil {
+			logger.Error().Err(err).Msg("get currencies keyboard for balance subscription")
+			return "", fmt.Errorf("get currencies keyboard for balance subscription: %w", err)
+		}
+
+		return model.ChooseBalanceSubscriptionCurrencyFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+			ChatID:                opts.message.GetChatID(),
+			MessageID:             opts.message.GetMessageID(),
+			InlineMessageID:       opts.message.GetInlineMessageID(),
+			UpdatedMessage:        "Select currency in which the subscription is charged:",
+			UpdatedInlineKeyboard: currenciesKeyboard,
+		})
 
 	default:
 		return "", fmt.Errorf("received unknown update balance subscription option: %s", opts.message.GetText())
@@ -540,7 +837,7 @@ func (h *handlerService) handleEnterBalanceSubscriptionAmountFlowStepForUpdate(c
 	logger := h.logger.With().Str("name", "handlerService.handleEnterBalanceSubscriptionAmountFlowStepForUpdate").Logger()
 	logger.Debug().Any("opts", opts).Msg("got args")
 
-	parsedAmount, err := money.NewFromString(opts.message.GetText())
+	parsedAmount, err := money.NewFromExpression(opts.message.GetText())
 	if err != nil {
 		logger.Error().Err(err).Msg("parse input amount")
 		return "", ErrInvalidAmountFormat
@@ -564,7 +861,14 @@ func (h *handlerService) handleEnterBalanceSubscriptionAmountFlowStepForUpdate(c
 		return "", ErrBalanceSubscriptionNotFound
 	}
 
-	balanceSubscription.Amount = parsedAmount.StringFixed()
+	previousBalanceSubscription := *balanceSubscription
+	balanceSubscription.Amount, err = h.stringifyBalanceSubscriptionAmount(ctx, *balanceSubscription, parsedAmount)
+	if err != nil {
+		logger.Error().Err(err).Msg("stringify balance subscription amount")
+		return "", fmt.Errorf("stringify balance subscription amount: %w", err)
+	}
+	// Updated amount replaces the amount after trial, otherwise it won't be charged.
+	balanceSubscription.AmountAfterTrial = ""
 
 	err = h.stores.BalanceSubscription.Update(ctx, balanceSubscription)
 	if err != nil {
@@ -572,12 +876,18 @@ func (h *handlerService) handleEnterBalanceSubscriptionAmountFlowStepForUpdate(c
 		return "", fmt.Errorf("update balance subscription: %w", err)
 	}
 
+	err = h.recordBalanceSubscriptionChange(ctx, previousBalanceSubscription, *balanceSubscription)
+	if err != nil {
+		logger.Error().Err(err).Msg("record balance subscription change")
+		return "", fmt.Errorf("record balance subscription change: %w", err)
+	}
+
 	return model.ChooseUpdateBalanceSubscriptionOptionFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
 		ChatID:                  opts.message.GetChatID(),
 		FormatMessageInMarkDown: true,
 		Message: fmt.Sprintf(
 			"Balance subscription amount successfully updated!\nNew amount: `%s`\nPlease choose other update operation option or finish action by canceling it!",
-			parsedAmount.StringFixed(),
+			balanceSubscription.Amount,
 		),
 		InlineKeyboard: updateBalanceSubscriptionOptionsKeyboard,
 	})
@@ -588,7 +898,8 @@ func (h *handlerService) handleChooseCategoryFlowStepForBalanceSubscriptionUpdat
 	logger.Debug().Any("opts", opts).Msg("got args")
 
 	category, err := h.stores.Category.Get(ctx, GetCategoryFilter{
-		Title: opts.message.GetText(),
+		UserID: opts.user.ID,
+		Title:  opts.message.GetText(),
 	})
 	if err != nil {
 		logger.Error().Err(err).Msg("get category from store")
@@ -599,6 +910,25 @@ func (h *handlerService) handleChooseCategoryFlowStepForBalanceSubscriptionUpdat
 		return "", ErrCategoryNotFound
 	}
 
+	err = h.checkCategoryCanBeChosen(ctx, opts.user.ID, category, model.OperationTypeSpending)
+	if err != nil {
+		if errs.IsExpected(err) {
+			logger.Info().Err(err).Msg(err.Error())
+			return "", err
+		}
+		logger.Error().Err(err).Msg("check category can be chosen")
+		return "", fmt.Errorf("check category can be chosen: %w", err)
+	}
+
+	subcategoriesShown, err := h.showSubcategoriesIfNeeded(ctx, opts, category, model.OperationTypeSpending)
+	if err != nil {
+		logger.Error().Err(err).Msg("show sub-categories")
+		return "", fmt.Errorf("show sub-categories: %w", err)
+	}
+	if subcategoriesShown {
+		return model.ChooseCategoryFlowStep, nil
+	}
+
 	balanceSubscriptionID, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.BalanceSubscriptionIDMetadataKey)
 	if !ok {
 		logger.Error().Msg("balance subscription ID not found in metadata")
@@ -666,6 +996,7 @@ func (h *handlerService) handleChooseBalanceSubscriptionFrequencyFlowStepForUpda
 		return "", ErrBalanceSubscriptionNotFound
 	}
 
+	previousBalanceSubscription := *balanceSubscription
 	balanceSubscription.Period = period
 
 	err = h.stores.BalanceSubscription.Update(ctx, balanceSubscription)
@@ -674,6 +1005,12 @@ func (h *handlerService) handleChooseBalanceSubscriptionFrequencyFlowStepForUpda
 		return "", fmt.Errorf("update balance subscription: %w", err)
 	}
 
+	err = h.recordBalanceSubscriptionChange(ctx, previousBalanceSubscription, *balanceSubscription)
+	if err != nil {
+		logger.Error().Err(err).Msg("record balance subscription change")
+		return "", fmt.Errorf("record balance subscription change: %w", err)
+	}
+
 	return model.ChooseUpdateBalanceSubscriptionOptionFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
 		ChatID:                  opts.message.GetChatID(),
 		MessageID:               opts.message.GetMessageID(),
@@ -687,6 +1024,188 @@ func (h *handlerService) handleChooseBalanceSubscriptionFrequencyFlowStepForUpda
 	})
 }
 
+// stringifyBalanceSubscriptionAmount returns the amount rounded to the minor units of the currency in which the subscription is charged.
+func (h *handlerService) stringifyBalanceSubscriptionAmount(ctx context.Context, subscription model.BalanceSubscription, amount money.Money) (string, error) {
+	balance, err := h.stores.Balance.Get(ctx, GetBalanceFilter{
+		BalanceID:       subscription.BalanceID,
+		PreloadCurrency: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("get balance from store: %w", err)
+	}
+	if balance == nil {
+		return "", ErrBalanceNotFound
+	}
+
+	currency, err := h.getBalanceSubscriptionCurrency(ctx, subscription, *balance)
+	if err != nil {
+		return "", fmt.Errorf("get balance subscription currency: %w", err)
+	}
+
+	return currency.StringifyAmount(amount), nil
+}
+
+// recordBalanceSubscriptionChange saves the change of subscription amount, period or currency, so the price history is not lost after the update.
+func (h *handlerService) recordBalanceSubscriptionChange(ctx context.Context, previous, updated model.BalanceSubscription) error {
+	change := model.GetBalanceSubscriptionChange(previous, updated)
+	if change == nil {
+		return nil
+	}
+	change.ID = uuid.NewString()
+
+	err := h.stores.BalanceSubscription.CreateChange(ctx, *change)
+	if err != nil {
+		return fmt.Errorf("create balance subscription change in store: %w", err)
+	}
+
+	return nil
+}
+
+func (h *handlerService) handleChooseBalanceSubscriptionNotificationLeadTimeFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseBalanceSubscriptionNotificationLeadTimeFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	var leadTime *model.NotificationLeadTime
+	if opts.message.GetText() != model.NotificationLeadTimeDefaultLabel {
+		parsedLeadTime, err := model.ParseNotificationLeadTime(opts.message.GetText())
+		if err != nil {
+			logger.Error().Err(err).Msg("parse notification lead time from input")
+			return "", fmt.Errorf("parse notification lead time: %w", err)
+		}
+
+		leadTime = &parsedLeadTime
+	}
+
+	balanceSubscriptionID, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.BalanceSubscriptionIDMetadataKey)
+	if !ok {
+		logger.Error().Msg("balance subscription ID not found in metadata")
+		return "", fmt.Errorf("balance subscription ID not found in metadata")
+	}
+
+	balanceSubscription, err := h.stores.BalanceSubscription.Get(ctx, GetBalanceSubscriptionFilter{
+		ID: balanceSubscriptionID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get balance subscription from store")
+		return "", fmt.Errorf("get balance subscription from store: %w", err)
+	}
+	if balanceSubscription == nil {
+		logger.Info().Msg("balance subscription not found")
+		return "", ErrBalanceSubscriptionNotFound
+	}
+
+	balanceSubscription.NotificationLeadTime = leadTime
+
+	err = h.stores.BalanceSubscription.Update(ctx, balanceSubscription)
+	if err != nil {
+		logger.Error().Err(err).Msg("update balance subscription")
+		return "", fmt.Errorf("update balance subscription: %w", err)
+	}
+
+	return model.ChooseUpdateBalanceSubscriptionOptionFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                  opts.message.GetChatID(),
+		MessageID:               opts.message.GetMessageID(),
+		InlineMessageID:         opts.message.GetInlineMessageID(),
+		FormatMessageInMarkDown: true,
+		UpdatedMessage: fmt.Sprintf(
+			"Balance subscription reminder successfully updated!\nNew reminder: `%s`\nPlease choose other update operation option or finish action by canceling it!",
+			opts.message.GetText(),
+		),
+		UpdatedInlineKeyboard: updateBalanceSubscriptionOptionsKeyboard,
+	})
+}
+
+func (h *handlerService) handleChooseBalanceSubscriptionCurrencyFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseBalanceSubscriptionCurrencyFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	messageText := opts.message.GetText()
+	if isPaginationNeeded(messageText) {
+		nextPage := calculateNextPage(messageText, opts.stateMetaData)
+		opts.stateMetaData.Add(model.PageMetadataKey, nextPage)
+
+		currenciesKeyboard, err := h.getBalanceSubscriptionCurrenciesKeyboard(ctx, opts.user.ID, nextPage)
+		if err != nil {
+			logger.Error().Err(err).Msg("get currencies keyboard for balance subscription")
+			return "", fmt.Errorf("get currencies keyboard for balance subscription: %w", err)
+		}
+
+		return model.ChooseBalanceSubscriptionCurrencyFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+			ChatID:                opts.message.GetChatID(),
+			MessageID:             opts.message.GetMessageID(),
+			InlineMessageID:       opts.message.GetInlineMessageID(),
+			UpdatedMessage:        "Select currency in which the subscription is charged:",
+			UpdatedInlineKeyboard: currenciesKeyboard,
+		})
+	}
+
+	var currencyID, currencyName string
+	switch messageText {
+	case model.BalanceSubscriptionCurrencyDefaultLabel:
+		currencyName = model.BalanceSubscriptionCurrencyDefaultLabel
+	default:
+		currency, err := h.stores.Currency.Get(ctx, GetCurrencyFilter{
+			ID:     messageText,
+			UserID: opts.user.ID,
+		})
+		if err != nil {
+			logger.Error().Err(err).Msg("get currency from store")
+			return "", fmt.Errorf("get currency from store: %w", err)
+		}
+		if currency == nil {
+			logger.Info().Msg("currency not found")
+			return model.ChooseBalanceSubscriptionCurrencyFlowStep, ErrCurrencyNotFound
+		}
+
+		currencyID, currencyName = currency.ID, currency.GetName()
+	}
+
+	balanceSubscriptionID, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.BalanceSubscriptionIDMetadataKey)
+	if !ok {
+		logger.Error().Msg("balance subscription ID not found in metadata")
+		return "", fmt.Errorf("balance subscription ID not found in metadata")
+	}
+
+	balanceSubscription, err := h.stores.BalanceSubscription.Get(ctx, GetBalanceSubscriptionFilter{
+		ID: balanceSubscriptionID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get balance subscription from store")
+		return "", fmt.Errorf("get balance subscription from store: %w", err)
+	}
+	if balanceSubscription == nil {
+		logger.Info().Msg("balance subscription not found")
+		return "", ErrBalanceSubscriptionNotFound
+	}
+
+	previousBalanceSubscription := *balanceSubscription
+	balanceSubscription.CurrencyID = currencyID
+
+	err = h.stores.BalanceSubscription.Update(ctx, balanceSubscription)
+	if err != nil {
+		logger.Error().Err(err).Msg("update balance subscription")
+		return "", fmt.Errorf("update balance subscription: %w", err)
+	}
+
+	err = h.recordBalanceSubscriptionChange(ctx, previousBalanceSubscription, *balanceSubscription)
+	if err != nil {
+		logger.Error().Err(err).Msg("record balance subscription change")
+		return "", fmt.Errorf("record balance subscription change: %w", err)
+	}
+
+	return model.ChooseUpdateBalanceSubscriptionOptionFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                  opts.message.GetChatID(),
+		MessageID:               opts.message.GetMessageID(),
+		InlineMessageID:         opts.message.GetInlineMessageID(),
+		FormatMessageInMarkDown: true,
+		UpdatedMessage: fmt.Sprintf(
+			"Balance subscription currency successfully updated!\nNew currency: `%s`\nThe amount will be converted into the balance currency at the payment date.\nPlease choose other update operation option or finish action by canceling it!",
+			currencyName,
+		),
+		UpdatedInlineKeyboard: updateBalanceSubscriptionOptionsKeyboard,
+	})
+}
+
 // Delete Balance Subscriptions
 func (h *handlerService) handleDeleteBalanceSubscriptionFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
 	logger := h.logger.With().Str("name", "handlerService.handleDeleteBalanceSubscriptionFlowStep").Logger()
@@ -824,3 +1343,182 @@ func (h *handlerService) handleConfirmDeleteBalanceSubscriptionFlowStep(ctx cont
 		UpdatedMessage:  "Balance subscription successfully deleted!",
 	})
 }
+
+// scheduledOperationSnoozeDuration represents how long the reminder about subscription payment is postponed.
+const scheduledOperationSnoozeDuration = 24 * time.Hour
+
+func (h handlerService) HandleScheduledOperationAction(ctx context.Context, event model.Event, msg Message) error {
+	logger := h.logger.With().Str("name", "handlerService.HandleScheduledOperationAction").Logger()
+	logger.Debug().Any("event", event).Any("msg", msg).Msg("got args")
+
+	var scheduledOperationID string
+	switch event {
+	case model.SkipScheduledOperationEvent:
+		scheduledOperationID = strings.TrimPrefix(msg.GetText(), model.SkipScheduledOperationCallbackPrefix)
+	case model.SnoozeScheduledOperationEvent:
+		scheduledOperationID = strings.TrimPrefix(msg.GetText(), model.SnoozeScheduledOperationCallbackPrefix)
+	default:
+		return fmt.Errorf("unknown scheduled operation event: %s", event)
+	}
+
+	scheduledOperation, err := h.stores.BalanceSubscription.GetScheduledOperation(ctx, GetScheduledOperationFilter{
+		ID: scheduledOperationID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get scheduled operation from store")
+		return fmt.Errorf("get scheduled operation from store: %w", err)
+	}
+	if scheduledOperation == nil {
+		logger.Info().Msg("scheduled operation not found")
+		return ErrScheduledOperationNotFound
+	}
+
+	balanceSubscription, err := h.stores.BalanceSubscription.Get(ctx, GetBalanceSubscriptionFilter{
+		ID: scheduledOperation.SubscriptionID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get balance subscription from store")
+		return fmt.Errorf("get balance subscription from store: %w", err)
+	}
+	if balanceSubscription == nil {
+		logger.Info().Msg("balance subscription not found")
+		return ErrBalanceSubscriptionNotFound
+	}
+
+	// Make sure that user can manage only his own subscription payments.
+	user, err := h.stores.User.Get(ctx, GetUserFilter{
+		BalanceID: balanceSubscription.BalanceID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get user from store")
+		return fmt.Errorf("get user from store: %w", err)
+	}
+	if user == nil || user.Username != msg.GetSenderName() {
+		logger.Info().Msg("scheduled operation does not belong to the user")
+		return ErrScheduledOperationNotFound
+	}
+
+	var updatedMessage string
+	switch event {
+	case model.SkipScheduledOperationEvent:
+		err = h.skipScheduledOperation(ctx, *balanceSubscription, *scheduledOperation)
+		if err != nil {
+			logger.Error().Err(err).Msg("skip scheduled operation")
+			return fmt.Errorf("skip scheduled operation: %w", err)
+		}
+
+		updatedMessage = fmt.Sprintf(
+			"⏭️ Payment for subscription \"%s\" on %s was skipped.",
+			balanceSubscription.Name, scheduledOperation.CreationDate.Format(balanceSubscriptionTimeFormat),
+		)
+	case model.SnoozeScheduledOperationEvent:
+		now := time.Now()
+		if scheduledOperation.DaysUntilCreation(now) <= 0 {
+			logger.Info().Msg("scheduled operation charges today")
+			return ErrScheduledOperationCannotBeSnoozed
+		}
+
+		snoozedUntil := now.Add(scheduledOperationSnoozeDuration)
+		err = h.stores.BalanceSubscription.SnoozeScheduledOperation(ctx, scheduledOperation.ID, snoozedUntil)
+		if err != nil {
+			logger.Error().Err(err).Msg("snooze scheduled operation")
+			return fmt.Errorf("snooze scheduled operation: %w", err)
+		}
+
+		updatedMessage = fmt.Sprintf(
+			"⏰ Reminder about subscription \"%s\" payment snoozed until %s.",
+			balanceSubscription.Name, snoozedUntil.Format(operationTimeFormat),
+		)
+	}
+
+	return h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:          msg.GetChatID(),
+		MessageID:       msg.GetMessageID(),
+		InlineMessageID: msg.GetInlineMessageID(),
+		UpdatedMessage:  updatedMessage,
+	})
+}
+
+// skipScheduledOperation deletes the scheduled operation, so no operation will be created for this billing date.
+// When the skipped operation is the last scheduled one, the next billing date is scheduled first,
+// otherwise the subscription won't be extended anymore.
+func (h handlerService) skipScheduledOperation(ctx context.Context, balanceSubscription model.BalanceSubscription, scheduledOperation model.ScheduledOperation) error {
+	logger := h.logger.With().Str("name", "handlerService.skipScheduledOperation").Logger()
+	logger.Debug().Any("balanceSubscription", balanceSubscription).Any("scheduledOperation", scheduledOperation).Msg("got args")
+
+	scheduledOperations, err := h.stores.BalanceSubscription.ListScheduledOperation(ctx, ListScheduledOperation{
+		BalanceSubscriptionIDs: []string{balanceSubscription.ID},
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list scheduled operations from store")
+		return fmt.Errorf("list scheduled operations from store: %w", err)
+	}
+
+	if len(scheduledOperations) == 1 {
+		billingDates := model.CalculateScheduledOperationBillingDates(balanceSubscription.Period, scheduledOperation.CreationDate, 2)
+
+		err := h.stores.BalanceSubscription.CreateScheduledOperation(ctx, model.ScheduledOperation{
+			ID:             uuid.NewString(),
+			SubscriptionID: balanceSubscription.ID,
+			CreationDate:   billingDates[1],
+		})
+		if err != nil {
+			logger.Error().Err(err).Msg("create next scheduled operation in store")
+			return fmt.Errorf("create next scheduled operation in store: %w", err)
+		}
+	}
+
+	err = h.stores.BalanceSubscription.DeleteScheduledOperation(ctx, scheduledOperation.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("delete scheduled operation from store")
+		return fmt.Errorf("delete scheduled operation from store: %w", err)
+	}
+
+	return nil
+}
+
+func (h handlerService) HandleBalanceSubscriptionCancelAction(ctx context.Context, msg Message) error {
+	logger := h.logger.With().Str("name", "handlerService.HandleBalanceSubscriptionCancelAction").Logger()
+	logger.Debug().Any("msg", msg).Msg("got args")
+
+	balanceSubscription, err := h.stores.BalanceSubscription.Get(ctx, GetBalanceSubscriptionFilter{
+		ID: strings.TrimPrefix(msg.GetText(), model.CancelBalanceSubscriptionCallbackPrefix),
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get balance subscription from store")
+		return fmt.Errorf("get balance subscription from store: %w", err)
+	}
+	if balanceSubscription == nil {
+		logger.Info().Msg("balance subscription not found")
+		return ErrBalanceSubscriptionNotFound
+	}
+
+	// Make sure that user can cancel only his own subscriptions.
+	user, err := h.stores.User.Get(ctx, GetUserFilter{
+		BalanceID: balanceSubscription.BalanceID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get user from store")
+		return fmt.Errorf("get user from store: %w", err)
+	}
+	if user == nil || user.Username != msg.GetSenderName() {
+		logger.Info().Msg("balance subscription does not belong to the user")
+		return ErrBalanceSubscriptionNotFound
+	}
+
+	err = h.stores.BalanceSubscription.Delete(ctx, balanceSubscription.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("delete balance subscription from store")
+		return fmt.Errorf("delete balance subscription from store: %w", err)
+	}
+
+	return h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:          msg.GetChatID(),
+		MessageID:       msg.GetMessageID(),
+		InlineMessageID: msg.GetInlineMessageID(),
+		UpdatedMessage: fmt.Sprintf(
+			"❌ Subscription \"%s\" was canceled. Don't forget to cancel it at the service provider as well, no payments will be tracked anymore.",
+			balanceSubscription.Name,
+		),
+	})
+}
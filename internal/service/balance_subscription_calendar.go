@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+)
+
+// calendarExportPeriodInYears represents how far in the future scheduled payments are exported,
+// it matches the longest period for which the engine schedules operations.
+const calendarExportPeriodInYears = 2
+
+func (h *handlerService) handleExportBalanceSubscriptionsCalendarFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleExportBalanceSubscriptionsCalendarFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	balances, err := h.listUserBalancesWithCurrency(ctx, opts.user)
+	if err != nil {
+		logger.Error().Err(err).Msg("list user balances with currency")
+		return "", fmt.Errorf("list user balances with currency: %w", err)
+	}
+
+	balanceSubscriptions := make(map[string]model.BalanceSubscription)
+	balancesByID := make(map[string]model.Balance, len(balances))
+	for _, balance := range balances {
+		balancesByID[balance.ID] = balance
+
+		subscriptions, err := h.stores.BalanceSubscription.List(ctx, ListBalanceSubscriptionFilter{
+			BalanceID: balance.ID,
+		})
+		if err != nil {
+			logger.Error().Err(err).Msg("list balance subscriptions from store")
+			return "", fmt.Errorf("list balance subscriptions from store: %w", err)
+		}
+
+		for _, subscription := range subscriptions {
+			balanceSubscriptions[subscription.ID] = subscription
+		}
+	}
+	if len(balanceSubscriptions) == 0 {
+		return model.EndFlowStep, ErrNoBalanceSubscriptionsFound
+	}
+
+	subscriptionIDs := make([]string, 0, len(balanceSubscriptions))
+	for subscriptionID := range balanceSubscriptions {
+		subscriptionIDs = append(subscriptionIDs, subscriptionID)
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	scheduledOperations, err := h.stores.BalanceSubscription.ListScheduledOperation(ctx, ListScheduledOperation{
+		BetweenFilter: &BetweenFilter{
+			From: today,
+			To:   today.AddDate(calendarExportPeriodInYears, 0, 0),
+		},
+		BalanceSubscriptionIDs: subscriptionIDs,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list scheduled operations from store")
+		return "", fmt.Errorf("list scheduled operations from store: %w", err)
+	}
+	if len(scheduledOperations) == 0 {
+		return model.EndFlowStep, ErrUpcomingSubscriptionPaymentsNotFound
+	}
+
+	events := make([]model.SubscriptionPaymentCalendarEvent, 0, len(scheduledOperations))
+	for _, scheduledOperation := range scheduledOperations {
+		subscription := balanceSubscriptions[scheduledOperation.SubscriptionID]
+		balance := balancesByID[subscription.BalanceID]
+
+		currency, err := h.getBalanceSubscriptionCurrency(ctx, subscription, balance)
+		if err != nil {
+			logger.Error().Err(err).Msg("get balance subscription currency")
+			return "", fmt.Errorf("get balance subscription currency: %w", err)
+		}
+
+		events = append(events, model.SubscriptionPaymentCalendarEvent{
+			ScheduledOperation:  scheduledOperation,
+			BalanceSubscription: subscription,
+			BalanceName:         balance.Name,
+			CurrencyCode:        currency.Code,
+		})
+	}
+	slices.SortFunc(events, func(a, b model.SubscriptionPaymentCalendarEvent) int {
+		return a.ScheduledOperation.CreationDate.Compare(b.ScheduledOperation.CreationDate)
+	})
+
+	return model.EndFlowStep, h.apis.Messenger.SendDocument(SendDocumentOptions{
+		ChatID:   opts.message.GetChatID(),
+		FileName: model.SubscriptionPaymentsCalendarFileName,
+		Content:  []byte(model.BuildSubscriptionPaymentsCalendar(events, now)),
+		Caption:  fmt.Sprintf("📆 Exported %d upcoming subscription payments. Open the file to add them to your calendar.", len(events)),
+		Keyboard: balanceSubscriptionKeyboardRows,
+	})
+}
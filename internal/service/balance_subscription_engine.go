@@ -15,9 +15,10 @@ import (
 )
 
 type balanceSubscriptionEngine struct {
-	logger *logger.Logger
-	stores Stores
-	apis   APIs
+	logger   *logger.Logger
+	stores   Stores
+	apis     APIs
+	currency CurrencyService
 
 	operationCreationInterval               time.Duration
 	extendingScheduledOperationsInterval    time.Duration
@@ -25,11 +26,12 @@ type balanceSubscriptionEngine struct {
 }
 
 // NewBalanceSubscriptionEngine creates a new instance of balanceSubscriptionEngine.
-func NewBalanceSubscriptionEngine(config *config.Config, logger *logger.Logger, stores Stores, apis APIs) *balanceSubscriptionEngine {
+func NewBalanceSubscriptionEngine(config *config.Config, logger *logger.Logger, stores Stores, apis APIs, currency CurrencyService) *balanceSubscriptionEngine {
 	return &balanceSubscriptionEngine{
 		logger:                                  logger,
 		stores:                                  stores,
 		apis:                                    apis,
+		currency:                                currency,
 		operationCreationInterval:               config.App.OperationCreationInterval,
 		extendingScheduledOperationsInterval:    config.App.ExtendingScheduledOperationsInterval,
 		notifyAboutSubscriptionPaymentsInterval: config.App.NotifyAboutSubscriptionPaymentsInterval,
@@ -41,7 +43,7 @@ func (b *balanceSubscriptionEngine) ScheduleOperationsCreation(ctx context.Conte
 	logger.Debug().Any("balanceSubscription", balanceSubscription).Msg("got args")
 
 	maxBillingDates := getMaxBillingDatesFromSubscriptionPeriod(balanceSubscription.Period)
-	billingDates := model.CalculateScheduledOperationBillingDates(balanceSubscription.Period, balanceSubscription.StartAt, maxBillingDates)
+	billingDates := model.CalculateScheduledOperationBillingDates(balanceSubscription.Period, balanceSubscription.GetBillingStartDate(), maxBillingDates)
 
 	err := b.createScheduledOperations(ctx, billingDates, balanceSubscription)
 	if err != nil {
@@ -194,8 +196,14 @@ func (b *balanceSubscriptionEngine) createOperation(ctx context.Context, id stri
 		return ErrBalanceSubscriptionNotFound
 	}
 
+	if balanceSubscription.IsInTrial(scheduledOperation.CreationDate) {
+		logger.Info().Msg("scheduled operation is covered by free trial, skip charge")
+		return b.skipTrialScheduledOperation(ctx, *balanceSubscription, scheduledOperation)
+	}
+
 	balance, err := b.stores.Balance.Get(ctx, GetBalanceFilter{
-		BalanceID: balanceSubscription.BalanceID,
+		BalanceID:       balanceSubscription.BalanceID,
+		PreloadCurrency: true,
 	})
 	if err != nil {
 		logger.Error().Err(err).Msg("get balance from store")
@@ -206,6 +214,13 @@ func (b *balanceSubscriptionEngine) createOperation(ctx context.Context, id stri
 		return ErrBalanceNotFound
 	}
 
+	charge, err := b.calculateSubscriptionCharge(ctx, *balanceSubscription, balance, scheduledOperation.CreationDate)
+	if err != nil {
+		logger.Error().Err(err).Msg("calculate subscription charge")
+		return fmt.Errorf("calculate subscription charge: %w", err)
+	}
+	logger.Debug().Any("charge", charge).Msg("calculated subscription charge")
+
 	category, err := b.stores.Category.Get(ctx, GetCategoryFilter{
 		ID: balanceSubscription.CategoryID,
 	})
@@ -218,28 +233,35 @@ func (b *balanceSubscriptionEngine) createOperation(ctx context.Context, id stri
 		return ErrCategoryNotFound
 	}
 
-	err = b.stores.Operation.Create(ctx, &model.Operation{
+	operation := &model.Operation{
 		ID:                    uuid.NewString(),
 		BalanceID:             balance.ID,
 		CategoryID:            category.ID,
 		BalanceSubscriptionID: balanceSubscription.ID,
 		Type:                  model.OperationTypeSpending,
-		Amount:                balanceSubscription.Amount,
+		Amount:                balanceSubscription.GetChargeAmount(),
 		Description:           fmt.Sprintf("Subscprition payment for: %s", balanceSubscription.Name),
 		CreatedAt:             time.Now(),
 		UpdatedAt:             time.Now(),
-	})
+	}
+	if charge.IsConverted() {
+		operation.Amount = balance.GetCurrency().StringifyAmount(charge.Amount)
+		operation.OriginalAmount = balanceSubscription.GetChargeAmount()
+		operation.OriginalCurrency = charge.OriginalCurrency.Code
+		operation.ExchangeRate = charge.ExchangeRate.String()
+	}
+
+	err = b.stores.Operation.Create(ctx, operation)
 	if err != nil {
 		logger.Error().Err(err).Msg("create operation")
 		return fmt.Errorf("create operation: %w", err)
 	}
 
-	subscriptionAmount, _ := money.NewFromString(balanceSubscription.Amount)
 	balanceAmount, _ := money.NewFromString(balance.Amount)
 
-	calculateSpendingOperation(&balanceAmount, subscriptionAmount)
+	calculateSpendingOperation(&balanceAmount, charge.Amount)
 
-	balance.Amount = balanceAmount.StringFixed()
+	balance.Amount = balance.GetCurrency().StringifyAmount(balanceAmount)
 	logger.Debug().Any("calculatedBalanceAmount", balance.Amount).Msg("reduced balance amount with subscription amount")
 
 	err = b.stores.Balance.Update(ctx, balance)
@@ -257,6 +279,42 @@ func (b *balanceSubscriptionEngine) createOperation(ctx context.Context, id stri
 	return nil
 }
 
+// skipTrialScheduledOperation deletes the scheduled operation that is covered by free trial.
+// When it was the last scheduled operation, the billing is scheduled again starting from the trial end,
+// otherwise the subscription won't be extended anymore.
+func (b *balanceSubscriptionEngine) skipTrialScheduledOperation(ctx context.Context, balanceSubscription model.BalanceSubscription, scheduledOperation model.ScheduledOperation) error {
+	logger := b.logger.With().Str("name", "balanceSubscriptionEngine.skipTrialScheduledOperation").Logger()
+	logger.Debug().Any("balanceSubscription", balanceSubscription).Any("scheduledOperation", scheduledOperation).Msg("got args")
+
+	err := b.stores.BalanceSubscription.DeleteScheduledOperation(ctx, scheduledOperation.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("delete scheduled operation")
+		return fmt.Errorf("delete scheduled operation: %w", err)
+	}
+
+	scheduledOperations, err := b.stores.BalanceSubscription.ListScheduledOperation(ctx, ListScheduledOperation{
+		BalanceSubscriptionIDs: []string{balanceSubscription.ID},
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list scheduled operations from store")
+		return fmt.Errorf("list scheduled operations from store: %w", err)
+	}
+	if len(scheduledOperations) != 0 {
+		return nil
+	}
+
+	maxBillingDates := getMaxBillingDatesFromSubscriptionPeriod(balanceSubscription.Period)
+	billingDates := model.CalculateScheduledOperationBillingDates(balanceSubscription.Period, balanceSubscription.GetBillingStartDate(), maxBillingDates)
+
+	err = b.createScheduledOperations(ctx, billingDates, balanceSubscription)
+	if err != nil {
+		logger.Error().Err(err).Msg("create scheduled operations")
+		return fmt.Errorf("create scheduled operations: %w", err)
+	}
+
+	return nil
+}
+
 func (b *balanceSubscriptionEngine) NotifyAboutSubscriptionPayment(ctx context.Context) {
 	logger := b.logger.With().Str("name", "balanceSubscriptionEngine.NotifyAboutSubscriptionPayment").Logger()
 
@@ -286,12 +344,14 @@ func (b *balanceSubscriptionEngine) NotifyAboutSubscriptionPayment(ctx context.C
 			}
 			logger.Debug().Any("balanceSubscriptions", balanceSubscriptions).Msg("got balance subscriptions")
 
+			// Scheduled operations are fetched for the biggest possible lead time,
+			// the exact notification day is checked for each of them separately.
 			now := time.Now()
-			nextDay := now.Day() + 1
+			lastNotificationDay := now.AddDate(0, 0, int(model.MaxNotificationLeadTime))
 			scheduledOperations, err := b.stores.BalanceSubscription.ListScheduledOperation(ctx, ListScheduledOperation{
 				BetweenFilter: &BetweenFilter{
-					From: time.Date(now.Year(), now.Month(), nextDay, 0, 0, 0, 0, time.UTC),
-					To:   time.Date(now.Year(), now.Month(), nextDay, 23, 59, 59, 999999999, time.UTC),
+					From: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+					To:   time.Date(lastNotificationDay.Year(), lastNotificationDay.Month(), lastNotificationDay.Day(), 23, 59, 59, 999999999, time.UTC),
 				},
 				BalanceSubscriptionIDs: extractIDs(balanceSubscriptions, func(bs model.BalanceSubscription) string {
 					return bs.ID
@@ -340,7 +400,8 @@ func (b *balanceSubscriptionEngine) notifyUserAboutSubscriptionPayment(ctx conte
 	logger.Debug().Any("opts", opts).Msg("got args")
 
 	user, err := b.stores.User.Get(ctx, GetUserFilter{
-		BalanceID: opts.balanceSubscription.BalanceID,
+		BalanceID:       opts.balanceSubscription.BalanceID,
+		PreloadSettings: true,
 	})
 	if err != nil {
 		logger.Error().Err(err).Msg("get user from store")
@@ -352,6 +413,23 @@ func (b *balanceSubscriptionEngine) notifyUserAboutSubscriptionPayment(ctx conte
 	}
 	logger.Debug().Any("user", user).Msg("got user")
 
+	userLeadTime := model.DefaultNotificationLeadTime
+	if user.Settings != nil {
+		userLeadTime = user.Settings.SubscriptionNotificationLeadTime
+	}
+
+	if opts.balanceSubscription.IsInTrial(opts.scheduledOperation.CreationDate) {
+		logger.Debug().Msg("scheduled operation is covered by free trial, it won't be charged")
+		return nil
+	}
+
+	now := time.Now()
+	leadTime := opts.balanceSubscription.GetNotificationLeadTime(userLeadTime)
+	if !opts.scheduledOperation.IsNotificationDue(leadTime, now) {
+		logger.Debug().Any("leadTime", leadTime).Msg("notification is not due yet")
+		return nil
+	}
+
 	balance, err := b.stores.Balance.Get(ctx, GetBalanceFilter{
 		BalanceID:       opts.balanceSubscription.BalanceID,
 		PreloadCurrency: true,
@@ -366,7 +444,19 @@ func (b *balanceSubscriptionEngine) notifyUserAboutSubscriptionPayment(ctx conte
 	}
 	logger.Debug().Any("balance", balance).Msg("got balance")
 
-	err = b.apis.Messenger.SendMessage(user.ChatID, buildSubscriptionNotificationMessage(opts.balanceSubscription, balance))
+	charge, err := b.calculateSubscriptionCharge(ctx, opts.balanceSubscription, balance, opts.scheduledOperation.CreationDate)
+	if err != nil {
+		logger.Error().Err(err).Msg("calculate subscription charge")
+		return fmt.Errorf("calculate subscription charge: %w", err)
+	}
+
+	err = b.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID: user.ChatID,
+		Message: buildSubscriptionNotificationMessage(
+			opts.balanceSubscription, balance, charge, opts.scheduledOperation.DaysUntilCreation(now),
+		),
+		InlineKeyboard: getSubscriptionNotificationKeyboard(opts.scheduledOperation.ID),
+	})
 	if err != nil {
 		logger.Error().Err(err).Msg("send message to user")
 		return fmt.Errorf("send message to user: %w", err)
@@ -381,33 +471,244 @@ func (b *balanceSubscriptionEngine) notifyUserAboutSubscriptionPayment(ctx conte
 	return nil
 }
 
-func buildSubscriptionNotificationMessage(subscription model.BalanceSubscription, balance *model.Balance) string {
-	subscriptionAmount, _ := money.NewFromString(subscription.Amount)
+func (b *balanceSubscriptionEngine) NotifyAboutTrialEnd(ctx context.Context) {
+	logger := b.logger.With().Str("name", "balanceSubscriptionEngine.NotifyAboutTrialEnd").Logger()
+
+	ticker := time.NewTicker(b.notifyAboutSubscriptionPaymentsInterval)
+	defer ticker.Stop()
+
+	pool := worker.NewPool(5, b.notifyUserAboutTrialEnd)
+	pool.Start(ctx)
+	defer pool.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info().Msg("finished notifying users about trial end")
+			return
+		case <-ticker.C:
+			balanceSubscriptions, err := b.stores.BalanceSubscription.List(ctx, ListBalanceSubscriptionFilter{
+				SubscriptionsForUserWhoHasEnabledSubscriptionNotifications: true,
+				SubscriptionsWithPendingTrialReminder:                      true,
+			})
+			if err != nil {
+				logger.Error().Err(err).Msg("list balance subscriptions")
+				continue
+			}
+			logger.Debug().Any("balanceSubscriptions", balanceSubscriptions).Msg("got balance subscriptions with pending trial reminder")
+
+			for _, balanceSubscription := range balanceSubscriptions {
+				pool.AddJob(balanceSubscription.ID, balanceSubscription)
+			}
+		}
+	}
+}
+
+func (b *balanceSubscriptionEngine) notifyUserAboutTrialEnd(ctx context.Context, id string, balanceSubscription model.BalanceSubscription) error {
+	logger := b.logger.With().Str("name", "balanceSubscriptionEngine.notifyUserAboutTrialEnd").Logger()
+	logger.Debug().Any("balanceSubscription", balanceSubscription).Msg("got args")
+
+	user, err := b.stores.User.Get(ctx, GetUserFilter{
+		BalanceID:       balanceSubscription.BalanceID,
+		PreloadSettings: true,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get user from store")
+		return fmt.Errorf("get user from store: %w", err)
+	}
+	if user == nil {
+		logger.Warn().Msg("user during operation not found")
+		return ErrUserNotFound
+	}
+
+	userLeadTime := model.DefaultNotificationLeadTime
+	if user.Settings != nil {
+		userLeadTime = user.Settings.SubscriptionNotificationLeadTime
+	}
+
+	leadTime := balanceSubscription.GetNotificationLeadTime(userLeadTime)
+	if !balanceSubscription.IsTrialReminderDue(leadTime, time.Now()) {
+		logger.Debug().Any("leadTime", leadTime).Msg("trial reminder is not due yet")
+		return nil
+	}
+
+	currency, err := b.getBalanceSubscriptionCurrency(ctx, balanceSubscription)
+	if err != nil {
+		logger.Error().Err(err).Msg("get balance subscription currency")
+		return fmt.Errorf("get balance subscription currency: %w", err)
+	}
+
+	err = b.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:  user.ChatID,
+		Message: balanceSubscription.GetTrialReminderMessage(*currency),
+		InlineKeyboard: []InlineKeyboardRow{
+			{
+				Buttons: []InlineKeyboardButton{
+					{
+						Text: "Cancel subscription ❌",
+						Data: model.CancelBalanceSubscriptionCallbackPrefix + balanceSubscription.ID,
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("send message to user")
+		return fmt.Errorf("send message to user: %w", err)
+	}
+
+	err = b.stores.BalanceSubscription.MarkTrialReminderAsSent(ctx, balanceSubscription.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("mark trial reminder as sent")
+		return fmt.Errorf("mark trial reminder as sent: %w", err)
+	}
+
+	return nil
+}
+
+// getBalanceSubscriptionCurrency returns the currency in which subscription is charged.
+func (b *balanceSubscriptionEngine) getBalanceSubscriptionCurrency(ctx context.Context, balanceSubscription model.BalanceSubscription) (*model.Currency, error) {
+	if balanceSubscription.CurrencyID != "" {
+		currency, err := b.stores.Currency.Get(ctx, GetCurrencyFilter{
+			ID: balanceSubscription.CurrencyID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("get subscription currency from store: %w", err)
+		}
+		if currency == nil {
+			return nil, ErrCurrencyNotFound
+		}
+
+		return currency, nil
+	}
+
+	balance, err := b.stores.Balance.Get(ctx, GetBalanceFilter{
+		BalanceID:       balanceSubscription.BalanceID,
+		PreloadCurrency: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get balance from store: %w", err)
+	}
+	if balance == nil {
+		return nil, ErrBalanceNotFound
+	}
+
+	currency := balance.GetCurrency()
+	return &currency, nil
+}
+
+func getSubscriptionNotificationKeyboard(scheduledOperationID string) []InlineKeyboardRow {
+	return []InlineKeyboardRow{
+		{
+			Buttons: []InlineKeyboardButton{
+				{
+					Text: "Skip this payment ⏭️",
+					Data: model.SkipScheduledOperationCallbackPrefix + scheduledOperationID,
+				},
+				{
+					Text: "Snooze ⏰",
+					Data: model.SnoozeScheduledOperationCallbackPrefix + scheduledOperationID,
+				},
+			},
+		},
+	}
+}
+
+func buildSubscriptionNotificationMessage(subscription model.BalanceSubscription, balance *model.Balance, charge *model.SubscriptionCharge, daysUntilPayment int) string {
 	currentBalanceAmount, _ := money.NewFromString(balance.Amount)
 
 	remaningBalanceAmount, _ := money.NewFromString(balance.Amount)
-	remaningBalanceAmount.Sub(subscriptionAmount)
+	remaningBalanceAmount.Sub(charge.Amount)
 
-	symbol := balance.Currency.Symbol
+	format := balance.GetCurrency().GetMoneyFormat()
 
 	var balanceStatus string
 	switch {
 	case remaningBalanceAmount.Equal(money.Zero):
 		balanceStatus = "⚠️ Balance will be exactly zero after payment"
 	case remaningBalanceAmount.GreaterThan(money.Zero):
-		balanceStatus = fmt.Sprintf("✅ Balance after payment: %s%s", symbol, remaningBalanceAmount.StringFixed())
+		balanceStatus = fmt.Sprintf("✅ Balance after payment: %s", remaningBalanceAmount.Format(format))
 	case remaningBalanceAmount.LessThan(money.Zero):
-		deficitAmount, _ := money.NewFromString(subscription.Amount)
+		deficitAmount := charge.Amount
 		deficitAmount.Sub(currentBalanceAmount)
-		balanceStatus = fmt.Sprintf("❌ Insufficient funds! Need %s%s more", symbol, deficitAmount.StringFixed())
+		balanceStatus = fmt.Sprintf("❌ Insufficient funds! Need %s more", deficitAmount.Format(format))
+	}
+
+	var chargeTime string
+	switch daysUntilPayment {
+	case 0:
+		chargeTime = "today"
+	case 1:
+		chargeTime = "tomorrow"
+	default:
+		chargeTime = fmt.Sprintf("in %d days", daysUntilPayment)
+	}
+
+	amount := charge.Amount.Format(format)
+	if charge.IsConverted() {
+		amount = fmt.Sprintf(
+			"%s (≈ %s at rate %s)",
+			charge.OriginalAmount.Format(charge.OriginalCurrency.GetMoneyFormat()),
+			charge.Amount.Format(format), charge.ExchangeRate.String(),
+		)
 	}
 
 	return fmt.Sprintf(
-		"🔔 Your subscription payment \"%s\" charges tomorrow\n\n💰 Amount: %s%s\n📅 Period: %s\n💳 Current balance: %s%s\n%s",
-		subscription.Name,
-		symbol, subscriptionAmount.StringFixed(),
+		"🔔 Your subscription payment \"%s\" charges %s\n\n💰 Amount: %s\n📅 Period: %s\n💳 Current balance: %s\n%s",
+		subscription.Name, chargeTime,
+		amount,
 		subscription.Period,
-		symbol, currentBalanceAmount.StringFixed(),
+		currentBalanceAmount.Format(format),
 		balanceStatus,
 	)
 }
+
+// calculateSubscriptionCharge returns the amount that is charged from the balance for the subscription.
+// When the subscription currency differs from the balance one, the amount is converted with the exchange rate of the charge date.
+// Balance is expected to have preloaded currency.
+func (b *balanceSubscriptionEngine) calculateSubscriptionCharge(ctx context.Context, subscription model.BalanceSubscription, balance *model.Balance, date time.Time) (*model.SubscriptionCharge, error) {
+	subscriptionAmount, err := money.NewFromString(subscription.GetChargeAmount())
+	if err != nil {
+		return nil, fmt.Errorf("parse subscription amount: %w", err)
+	}
+
+	if subscription.CurrencyID == "" || subscription.CurrencyID == balance.CurrencyID {
+		return &model.SubscriptionCharge{
+			Amount: subscriptionAmount,
+		}, nil
+	}
+
+	subscriptionCurrency, err := b.stores.Currency.Get(ctx, GetCurrencyFilter{
+		ID: subscription.CurrencyID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get subscription currency from store: %w", err)
+	}
+	if subscriptionCurrency == nil {
+		return nil, ErrCurrencyNotFound
+	}
+
+	exchangeRate, err := b.currency.GetExchangeRate(ctx, GetExchangeRateOptions{
+		BaseCurrency:   subscriptionCurrency.Code,
+		TargetCurrency: balance.GetCurrency().Code,
+		Date:           date,
+		UserID:         balance.UserID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get exchange rate: %w", err)
+	}
+
+	if exchangeRate.Outdated {
+		b.logger.Warn().Str("subscriptionID", subscription.ID).Any("date", exchangeRate.Date).Msg("subscription charge is converted with outdated exchange rate")
+	}
+
+	convertedAmount := subscriptionAmount
+	convertedAmount.Mul(exchangeRate.Rate)
+
+	return &model.SubscriptionCharge{
+		Amount:           convertedAmount,
+		OriginalAmount:   subscriptionAmount,
+		OriginalCurrency: subscriptionCurrency,
+		ExchangeRate:     &exchangeRate.Rate,
+	}, nil
+}
@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/VladPetriv/finance_bot/pkg/money"
+)
+
+// allBalancesSummaryOption represents the option for building subscriptions summary across all user balances.
+const allBalancesSummaryOption = "All Balances 🌐"
+
+func (h *handlerService) handleGetBalanceSubscriptionsSummaryFlowStep(_ context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleGetBalanceSubscriptionsSummaryFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	err := h.showCancelButton(opts.message.GetChatID(), "")
+	if err != nil {
+		logger.Error().Err(err).Msg("show cancel button")
+		return "", fmt.Errorf("show cancel button: %w", err)
+	}
+
+	keyboard := getInlineKeyboardRows(opts.user.Balances, 2)
+	keyboard = append(keyboard, InlineKeyboardRow{
+		Buttons: []InlineKeyboardButton{{Text: allBalancesSummaryOption}},
+	})
+
+	return model.ChooseBalanceFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:         opts.message.GetChatID(),
+		Message:        "Choose balance for subscriptions summary:",
+		InlineKeyboard: keyboard,
+	})
+}
+
+func (h *handlerService) handleChooseBalanceFlowStepForBalanceSubscriptionsSummary(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseBalanceFlowStepForBalanceSubscriptionsSummary").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	baseCurrency, err := h.getUserBaseCurrency(ctx, opts.user)
+	if err != nil {
+		logger.Error().Err(err).Msg("get user base currency")
+		return "", fmt.Errorf("get user base currency: %w", err)
+	}
+
+	if opts.message.GetText() != allBalancesSummaryOption {
+		balance, err := h.stores.Balance.Get(ctx, GetBalanceFilter{
+			UserID:          opts.user.ID,
+			Name:            opts.message.GetText(),
+			PreloadCurrency: true,
+		})
+		if err != nil {
+			logger.Error().Err(err).Msg("get balance from store")
+			return "", fmt.Errorf("get balance from store: %w", err)
+		}
+		if balance == nil {
+			return model.EndFlowStep, ErrBalanceNotFound
+		}
+
+		currency := balance.GetCurrency()
+		if baseCurrency != nil {
+			currency = *baseCurrency
+		}
+
+		return h.sendBalanceSubscriptionsSummary(ctx, opts, []model.Balance{*balance}, currency)
+	}
+
+	balances, err := h.listUserBalancesWithCurrency(ctx, opts.user)
+	if err != nil {
+		logger.Error().Err(err).Msg("list user balances with currency")
+		return "", fmt.Errorf("list user balances with currency: %w", err)
+	}
+
+	currencies := getUniqueBalanceCurrencies(balances)
+	if len(currencies) == 0 {
+		return model.EndFlowStep, ErrBalanceNotFound
+	}
+	if baseCurrency != nil {
+		return h.sendBalanceSubscriptionsSummary(ctx, opts, balances, *baseCurrency)
+	}
+	if len(currencies) == 1 {
+		return h.sendBalanceSubscriptionsSummary(ctx, opts, balances, currencies[0])
+	}
+
+	return model.ChooseBalanceSubscriptionsSummaryCurrencyFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                opts.message.GetChatID(),
+		MessageID:             opts.message.GetMessageID(),
+		InlineMessageID:       opts.message.GetInlineMessageID(),
+		UpdatedMessage:        "Your balances use different currencies. Choose currency for the summary:",
+		UpdatedInlineKeyboard: getInlineKeyboardRows(currencies, 1),
+	})
+}
+
+func (h *handlerService) handleChooseBalanceSubscriptionsSummaryCurrencyFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseBalanceSubscriptionsSummaryCurrencyFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	balances, err := h.listUserBalancesWithCurrency(ctx, opts.user)
+	if err != nil {
+		logger.Error().Err(err).Msg("list user balances with currency")
+		return "", fmt.Errorf("list user balances with currency: %w", err)
+	}
+
+	currencies := getUniqueBalanceCurrencies(balances)
+	index := slices.IndexFunc(currencies, func(currency model.Currency) bool {
+		return currency.GetName() == opts.message.GetText()
+	})
+	if index == -1 {
+		return model.EndFlowStep, ErrCurrencyNotFound
+	}
+
+	return h.sendBalanceSubscriptionsSummary(ctx, opts, balances, currencies[index])
+}
+
+func (h *handlerService) sendBalanceSubscriptionsSummary(ctx context.Context, opts flowProcessingOptions, balances []model.Balance, currency model.Currency) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.sendBalanceSubscriptionsSummary").Logger()
+
+	summary, err := h.buildBalanceSubscriptionsSummary(ctx, opts.user.ID, balances, currency)
+	if err != nil {
+		logger.Error().Err(err).Msg("build balance subscriptions summary")
+		return "", fmt.Errorf("build balance subscriptions summary: %w", err)
+	}
+	if len(summary.Items) == 0 && len(summary.NotConvertedSubscriptions) == 0 {
+		return model.EndFlowStep, ErrNoBalanceSubscriptionsFound
+	}
+
+	return model.EndFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                  opts.message.GetChatID(),
+		MessageID:               opts.message.GetMessageID(),
+		InlineMessageID:         opts.message.GetInlineMessageID(),
+		UpdatedMessage:          summary.BuildMessage(),
+		FormatMessageInMarkDown: true,
+		UpdatedKeyboard:         balanceSubscriptionKeyboardRows,
+	})
+}
+
+// buildBalanceSubscriptionsSummary collects subscriptions of the provided balances with their amounts converted
+// into the target currency and upcoming charges for them. Subscriptions with failed conversion are reported separately.
+func (h *handlerService) buildBalanceSubscriptionsSummary(ctx context.Context, userID string, balances []model.Balance, currency model.Currency) (*model.SubscriptionSummary, error) {
+	logger := h.logger.With().Str("name", "handlerService.buildBalanceSubscriptionsSummary").Logger()
+	logger.Debug().Any("userID", userID).Any("balances", balances).Any("currency", currency).Msg("got args")
+
+	categories, err := h.stores.Category.List(ctx, &ListCategoriesFilter{
+		UserID: userID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list categories from store")
+		return nil, fmt.Errorf("list categories from store: %w", err)
+	}
+
+	categoryTitles := make(map[string]string, len(categories))
+	for _, category := range categories {
+		categoryTitles[category.ID] = category.Title
+	}
+
+	summary := model.SubscriptionSummary{
+		Currency: currency,
+	}
+	exchangeRates := make(map[string]money.Money)
+	convertedAmounts := make(map[string]money.Money)
+	subscriptionNames := make(map[string]string)
+
+	for _, balance := range balances {
+		balanceSubscriptions, err := h.stores.BalanceSubscription.List(ctx, ListBalanceSubscriptionFilter{
+			BalanceID: balance.ID,
+		})
+		if err != nil {
+			logger.Error().Err(err).Msg("list balance subscriptions from store")
+			return nil, fmt.Errorf("list balance subscriptions from store: %w", err)
+		}
+
+		for _, balanceSubscription := range balanceSubscriptions {
+			subscriptionNames[balanceSubscription.ID] = balanceSubscription.Name
+
+			amount, err := money.NewFromString(balanceSubscription.GetChargeAmount())
+			if err != nil {
+				logger.Error().Err(err).Msg("parse balance subscription amount")
+				return nil, fmt.Errorf("parse balance subscription amount: %w", err)
+			}
+
+			subscriptionCurrency, err := h.getBalanceSubscriptionCurrency(ctx, balanceSubscription, balance)
+			if err != nil {
+				logger.Error().Err(err).Msg("get balance subscription currency")
+				return nil, fmt.Errorf("get balance subscription currency: %w", err)
+			}
+
+			exchangeRate, err := h.getSummaryExchangeRate(ctx, exchangeRates, userID, subscriptionCurrency.Code, currency.Code)
+			if err != nil {
+				logger.Warn().Err(err).Str("subscriptionID", balanceSubscription.ID).Msg("convert balance subscription amount")
+				summary.NotConvertedSubscriptions = append(summary.NotConvertedSubscriptions, balanceSubscription.Name)
+				continue
+			}
+			amount.Mul(exchangeRate)
+
+			convertedAmounts[balanceSubscription.ID] = amount
+			summary.Items = append(summary.Items, model.SubscriptionSummaryItem{
+				Subscription:  balanceSubscription,
+				CategoryTitle: categoryTitles[balanceSubscription.CategoryID],
+				Amount:        amount,
+			})
+		}
+	}
+
+	priceIncreases, err := h.getBalanceSubscriptionsPriceIncreases(ctx, subscriptionNames)
+	if err != nil {
+		logger.Error().Err(err).Msg("get balance subscriptions price increases")
+		return nil, fmt.Errorf("get balance subscriptions price increases: %w", err)
+	}
+	summary.PriceIncreases = priceIncreases
+
+	if len(convertedAmounts) == 0 {
+		return &summary, nil
+	}
+
+	subscriptionIDs := make([]string, 0, len(convertedAmounts))
+	for subscriptionID := range convertedAmounts {
+		subscriptionIDs = append(subscriptionIDs, subscriptionID)
+	}
+
+	now := time.Now()
+	scheduledOperations, err := h.stores.BalanceSubscription.ListScheduledOperation(ctx, ListScheduledOperation{
+		BetweenFilter: &BetweenFilter{
+			From: now,
+			To:   now.AddDate(0, 0, model.UpcomingChargesPeriodInDays),
+		},
+		BalanceSubscriptionIDs: subscriptionIDs,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list scheduled operations from store")
+		return nil, fmt.Errorf("list scheduled operations from store: %w", err)
+	}
+
+	for _, scheduledOperation := range scheduledOperations {
+		summary.UpcomingCharges = append(summary.UpcomingCharges, model.UpcomingSubscriptionCharge{
+			Name:   subscriptionNames[scheduledOperation.SubscriptionID],
+			Amount: convertedAmounts[scheduledOperation.SubscriptionID],
+			Date:   scheduledOperation.CreationDate,
+		})
+	}
+	slices.SortFunc(summary.UpcomingCharges, func(a, b model.UpcomingSubscriptionCharge) int {
+		return a.Date.Compare(b.Date)
+	})
+
+	return &summary, nil
+}
+
+// getBalanceSubscriptionsPriceIncreases returns subscriptions that became more expensive since the beginning of the current year.
+func (h *handlerService) getBalanceSubscriptionsPriceIncreases(ctx context.Context, subscriptionNames map[string]string) ([]model.SubscriptionPriceIncrease, error) {
+	if len(subscriptionNames) == 0 {
+		return nil, nil
+	}
+
+	subscriptionIDs := make([]string, 0, len(subscriptionNames))
+	for subscriptionID := range subscriptionNames {
+		subscriptionIDs = append(subscriptionIDs, subscriptionID)
+	}
+
+	now := time.Now()
+	changes, err := h.stores.BalanceSubscription.ListChanges(ctx, ListBalanceSubscriptionChangesFilter{
+		SubscriptionIDs: subscriptionIDs,
+		CreatedAtFrom:   time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list balance subscription changes from store: %w", err)
+	}
+
+	var priceIncreases []model.SubscriptionPriceIncrease
+	priceIncreaseIndexes := make(map[string]int)
+	for _, change := range changes {
+		if !change.IsPriceIncrease() {
+			continue
+		}
+
+		index, ok := priceIncreaseIndexes[change.SubscriptionID]
+		if !ok {
+			index = len(priceIncreases)
+			priceIncreaseIndexes[change.SubscriptionID] = index
+			priceIncreases = append(priceIncreases, model.SubscriptionPriceIncrease{
+				Name: subscriptionNames[change.SubscriptionID],
+			})
+		}
+
+		priceIncreases[index].Changes = append(priceIncreases[index].Changes, change)
+	}
+
+	return priceIncreases, nil
+}
+
+// getSummaryExchangeRate returns exchange rate between currencies, reusing already fetched rates to avoid extra requests.
+func (h *handlerService) getSummaryExchangeRate(ctx context.Context, exchangeRates map[string]money.Money, userID, baseCurrency, targetCurrency string) (money.Money, error) {
+	if baseCurrency == targetCurrency {
+		return money.NewFromInt(1), nil
+	}
+
+	if exchangeRate, ok := exchangeRates[baseCurrency]; ok {
+		return exchangeRate, nil
+	}
+
+	exchangeRate, err := h.services.Currency.Convert(ctx, ConvertCurrencyOptions{
+		BaseCurrency:   baseCurrency,
+		TargetCurrency: targetCurrency,
+		Amount:         money.NewFromInt(1),
+		UserID:         userID,
+	})
+	if err != nil {
+		return money.Zero, fmt.Errorf("convert currency: %w", err)
+	}
+
+	exchangeRates[baseCurrency] = *exchangeRate
+	return *exchangeRate, nil
+}
+
+// getBalanceSubscriptionCurrency returns the currency in which subscription is charged, it's the balance currency unless subscription overrides it.
+// Balance is expected to have preloaded currency.
+func (h *handlerService) getBalanceSubscriptionCurrency(ctx context.Context, subscription model.BalanceSubscription, balance model.Balance) (model.Currency, error) {
+	if subscription.CurrencyID == "" || subscription.CurrencyID == balance.CurrencyID {
+		return balance.GetCurrency(), nil
+	}
+
+	currency, err := h.stores.Currency.Get(ctx, GetCurrencyFilter{
+		ID: subscription.CurrencyID,
+	})
+	if err != nil {
+		return model.Currency{}, fmt.Errorf("get currency from store: %w", err)
+	}
+	if currency == nil {
+		return model.Currency{}, ErrCurrencyNotFound
+	}
+
+	return *currency, nil
+}
+
+func (h *handlerService) listUserBalancesWithCurrency(ctx context.Context, user *model.User) ([]model.Balance, error) {
+	balances := make([]model.Balance, 0, len(user.Balances))
+	for _, userBalance := range user.Balances {
+		balance, err := h.stores.Balance.Get(ctx, GetBalanceFilter{
+			BalanceID:       userBalance.ID,
+			PreloadCurrency: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("get balance from store: %w", err)
+		}
+		if balance == nil {
+			continue
+		}
+
+		balances = append(balances, *balance)
+	}
+
+	return balances, nil
+}
+
+func getUniqueBalanceCurrencies(balances []model.Balance) []model.Currency {
+	currencies := make([]model.Currency, 0)
+	for _, balance := range balances {
+		currency := balance.GetCurrency()
+		if slices.ContainsFunc(currencies, func(c model.Currency) bool { return c.Code == currency.Code }) {
+			continue
+		}
+
+		currencies = append(currencies, currency)
+	}
+
+	return currencies
+}
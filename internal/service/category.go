@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/VladPetriv/finance_bot/internal/model"
 	"github.com/VladPetriv/finance_bot/pkg/errs"
@@ -39,11 +42,112 @@ func (h handlerService) handleEnterCategoryNameFlowStep(ctx context.Context, opt
 		return "", ErrCategoryAlreadyExists
 	}
 
-	err = h.stores.Category.Create(ctx, &model.Category{
-		ID:     uuid.NewString(),
+	opts.stateMetaData.Add(model.CategoryTitleMetadataKey, opts.message.GetText())
+	return model.ChooseCategoryKindFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:         opts.message.GetChatID(),
+		Message:        "Choose for which operations the category will be used:",
+		InlineKeyboard: categoryKindKeyboard,
+	})
+}
+
+func (h handlerService) handleChooseCategoryKindFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseCategoryKindFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	categoryKind, ok := model.GetCategoryKindFromCommand(opts.message.GetText())
+	if !ok {
+		logger.Error().Any("command", opts.message.GetText()).Msg("received unknown category kind")
+		return "", fmt.Errorf("received unknown category kind: %s", opts.message.GetText())
+	}
+	opts.stateMetaData.Add(model.CategoryKindMetadataKey, string(categoryKind))
+
+	categories, err := h.stores.Category.List(ctx, &ListCategoriesFilter{
+		UserID: opts.user.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list categories from store")
+		return "", fmt.Errorf("list categories from store: %w", err)
+	}
+
+	topLevelCategories := model.GetTopLevelCategories(model.GetActiveCategories(categories))
+	if len(topLevelCategories) == 0 {
+		return h.createCategory(ctx, opts, "")
+	}
+
+	keyboard := getInlineKeyboardRows(topLevelCategories, 3)
+	keyboard = append(keyboard, InlineKeyboardRow{
+		Buttons: []InlineKeyboardButton{
+			{
+				Text: model.BotWithoutParentCategoryCommand,
+			},
+		},
+	})
+
+	return model.ChooseParentCategoryFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                opts.message.GetChatID(),
+		MessageID:             opts.message.GetMessageID(),
+		InlineMessageID:       opts.message.GetInlineMessageID(),
+		UpdatedMessage:        "Choose parent category, if the new one is its sub-category:",
+		UpdatedInlineKeyboard: keyboard,
+	})
+}
+
+func (h handlerService) handleChooseParentCategoryFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseParentCategoryFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	if opts.message.GetText() == model.BotWithoutParentCategoryCommand {
+		return h.createCategory(ctx, opts, "")
+	}
+
+	parentCategory, err := h.stores.Category.Get(ctx, GetCategoryFilter{
 		UserID: opts.user.ID,
 		Title:  opts.message.GetText(),
 	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get parent category from store")
+		return "", fmt.Errorf("get parent category from store: %w", err)
+	}
+	if parentCategory == nil || parentCategory.IsSubcategory() {
+		logger.Info().Msg("parent category not found")
+		return "", ErrCategoryNotFound
+	}
+
+	return h.createCategory(ctx, opts, parentCategory.ID)
+}
+
+func (h handlerService) createCategory(ctx context.Context, opts flowProcessingOptions, parentID string) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.createCategory").Logger()
+	logger.Debug().Any("parentID", parentID).Msg("got args")
+
+	categoryTitle, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.CategoryTitleMetadataKey)
+	if !ok {
+		logger.Error().Msg("category title not found in metadata")
+		return "", fmt.Errorf("category title not found in metadata")
+	}
+
+	categoryKind, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.CategoryKindMetadataKey)
+	if !ok {
+		logger.Error().Msg("category kind not found in metadata")
+		return "", fmt.Errorf("category kind not found in metadata")
+	}
+
+	categories, err := h.stores.Category.List(ctx, &ListCategoriesFilter{
+		UserID: opts.user.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list categories from store")
+		return "", fmt.Errorf("list categories from store: %w", err)
+	}
+
+	err = h.stores.Category.Create(ctx, &model.Category{
+		ID:       uuid.NewString(),
+		UserID:   opts.user.ID,
+		Title:    categoryTitle,
+		ParentID: parentID,
+		Kind:     model.CategoryKind(categoryKind),
+		Position: model.GetNextCategoryPosition(parentID, categories),
+	})
 	if err != nil {
 		logger.Error().Err(err).Msg("create category in store")
 		return "", fmt.Errorf("create category in store: %w", err)
@@ -70,21 +174,228 @@ func (h handlerService) handleListCategoriesFlowStep(ctx context.Context, opts f
 		return "", fmt.Errorf("handle list categories flow step: %w", err)
 	}
 
-	outputMessage := "Categories: \n"
+	outputMessage := model.BuildCategoriesListMessage(categories)
+	logger.Debug().Any("outputMessage", outputMessage).Msg("built output message")
+
+	var listOptions []InlineKeyboardButton
+	if len(model.GetActiveCategories(categories)) > 1 {
+		listOptions = append(listOptions, InlineKeyboardButton{Text: model.BotReorderCategoriesCommand})
+	}
+	if len(model.GetArchivedCategories(categories)) > 0 {
+		listOptions = append(listOptions, InlineKeyboardButton{Text: model.BotUnarchiveCategoryCommand})
+	}
+	if len(listOptions) == 0 {
+		return model.EndFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+			ChatID:   opts.message.GetChatID(),
+			Message:  outputMessage,
+			Keyboard: categoryKeyboardRows,
+		})
+	}
+
+	err = h.showCancelButton(opts.message.GetChatID(), outputMessage)
+	if err != nil {
+		logger.Error().Err(err).Msg("show cancel button")
+		return "", fmt.Errorf("show cancel button: %w", err)
+	}
+
+	return model.ChooseCategoriesListOptionFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:  opts.message.GetChatID(),
+		Message: "You can change the order of categories or unarchive them:",
+		InlineKeyboard: []InlineKeyboardRow{
+			{
+				Buttons: listOptions,
+			},
+		},
+	})
+}
+
+func (h handlerService) handleChooseCategoriesListOptionFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseCategoriesListOptionFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	categories, err := h.listCategories(ctx, opts.user.ID)
+	if err != nil {
+		if errs.IsExpected(err) {
+			return model.EndFlowStep, err
+		}
 
-	for i, c := range categories {
-		i++
-		outputMessage += fmt.Sprintf("%d. %s\n", i, c.Title)
+		logger.Error().Err(err).Msg("list categories")
+		return "", fmt.Errorf("list categories: %w", err)
+	}
+
+	switch opts.message.GetText() {
+	case model.BotReorderCategoriesCommand:
+		activeCategories := model.GetCategoriesInListOrder(categories)
+		if len(activeCategories) < 2 {
+			logger.Info().Msg("not enough categories to reorder")
+			return "", ErrNotEnoughCategories
+		}
+
+		return model.ChooseCategoryFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+			ChatID:                opts.message.GetChatID(),
+			MessageID:             opts.message.GetMessageID(),
+			InlineMessageID:       opts.message.GetInlineMessageID(),
+			UpdatedMessage:        "Choose category to move:",
+			UpdatedInlineKeyboard: getInlineKeyboardRows(activeCategories, 2),
+		})
+	case model.BotUnarchiveCategoryCommand:
+		archivedCategories := model.GetArchivedCategories(categories)
+		if len(archivedCategories) == 0 {
+			logger.Info().Msg("archived categories not found")
+			return "", ErrArchivedCategoriesNotFound
+		}
+
+		return model.ChooseArchivedCategoryFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+			ChatID:                opts.message.GetChatID(),
+			MessageID:             opts.message.GetMessageID(),
+			InlineMessageID:       opts.message.GetInlineMessageID(),
+			UpdatedMessage:        "Choose category to unarchive:",
+			UpdatedInlineKeyboard: getInlineKeyboardRows(archivedCategories, 2),
+		})
+	default:
+		return "", fmt.Errorf("received unknown categories list option: %s", opts.message.GetText())
+	}
+}
+
+func (h handlerService) handleChooseCategoryFlowStepForReorder(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseCategoryFlowStepForReorder").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	category, err := h.stores.Category.Get(ctx, GetCategoryFilter{
+		UserID: opts.user.ID,
+		Title:  opts.message.GetText(),
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get category from store")
+		return "", fmt.Errorf("get category from store: %w", err)
+	}
+	if category == nil || category.Archived {
+		logger.Info().Msg("category not found")
+		return "", ErrCategoryNotFound
+	}
+
+	categories, err := h.listCategories(ctx, opts.user.ID)
+	if err != nil {
+		if errs.IsExpected(err) {
+			return model.EndFlowStep, err
+		}
+
+		logger.Error().Err(err).Msg("list categories")
+		return "", fmt.Errorf("list categories: %w", err)
+	}
+
+	opts.stateMetaData.Add(model.CategoryIDMetadataKey, category.ID)
+
+	// Sub-categories are ordered only inside of their parent category.
+	positionHint := "among top-level categories"
+	if category.IsSubcategory() {
+		positionHint = "among sub-categories of its parent"
+	}
+
+	return model.EnterCategoryPositionFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                  opts.message.GetChatID(),
+		MessageID:               opts.message.GetMessageID(),
+		InlineMessageID:         opts.message.GetInlineMessageID(),
+		FormatMessageInMarkDown: true,
+		UpdatedMessage: fmt.Sprintf(
+			"Enter new position of `%s` %s(from 1 to %d):",
+			category.Title, positionHint, model.CountSiblingCategories(*category, categories),
+		),
+	})
+}
+
+func (h handlerService) handleEnterCategoryPositionFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleEnterCategoryPositionFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	position, err := strconv.Atoi(strings.TrimSpace(opts.message.GetText()))
+	if err != nil {
+		logger.Info().Err(err).Msg("parse category position")
+		return "", ErrInvalidCategoryPosition
+	}
+
+	categoryID, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.CategoryIDMetadataKey)
+	if !ok {
+		logger.Error().Msg("category id not found in metadata")
+		return "", fmt.Errorf("category id not found in metadata")
+	}
+
+	categories, err := h.listCategories(ctx, opts.user.ID)
+	if err != nil {
+		if errs.IsExpected(err) {
+			return model.EndFlowStep, err
+		}
+
+		logger.Error().Err(err).Msg("list categories")
+		return "", fmt.Errorf("list categories: %w", err)
+	}
+
+	reorderedCategories, err := model.MoveCategory(categoryID, position, categories)
+	if err != nil {
+		logger.Info().Err(err).Msg("move category")
+		return "", ErrInvalidCategoryPosition
+	}
+
+	for _, category := range reorderedCategories {
+		err = h.stores.Category.Update(ctx, &category)
+		if err != nil {
+			logger.Error().Err(err).Msg("update category in store")
+			return "", fmt.Errorf("update category in store: %w", err)
+		}
+	}
+
+	updatedCategories, err := h.listCategories(ctx, opts.user.ID)
+	if err != nil {
+		if errs.IsExpected(err) {
+			return model.EndFlowStep, err
+		}
+
+		logger.Error().Err(err).Msg("list categories")
+		return "", fmt.Errorf("list categories: %w", err)
 	}
-	logger.Debug().Any("outputMessage", outputMessage).Msg("built output message")
 
 	return model.EndFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
 		ChatID:   opts.message.GetChatID(),
-		Message:  outputMessage,
+		Message:  fmt.Sprintf("Category moved!\n\n%s", model.BuildCategoriesListMessage(updatedCategories)),
 		Keyboard: categoryKeyboardRows,
 	})
 }
 
+func (h handlerService) handleChooseArchivedCategoryFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseArchivedCategoryFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	category, err := h.stores.Category.Get(ctx, GetCategoryFilter{
+		UserID: opts.user.ID,
+		Title:  opts.message.GetText(),
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get category from store")
+		return "", fmt.Errorf("get category from store: %w", err)
+	}
+	if category == nil || !category.Archived {
+		logger.Info().Msg("archived category not found")
+		return "", ErrCategoryNotFound
+	}
+
+	category.Archived = false
+
+	err = h.stores.Category.Update(ctx, category)
+	if err != nil {
+		logger.Error().Err(err).Msg("update category in store")
+		return "", fmt.Errorf("update category in store: %w", err)
+	}
+
+	return model.EndFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                  opts.message.GetChatID(),
+		MessageID:               opts.message.GetMessageID(),
+		InlineMessageID:         opts.message.GetInlineMessageID(),
+		FormatMessageInMarkDown: true,
+		UpdatedMessage:          fmt.Sprintf("Category `%s` unarchived!", category.Title),
+		UpdatedKeyboard:         categoryKeyboardRows,
+	})
+}
+
 func (h handlerService) handleUpdateCategoryFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
 	logger := h.logger.With().Str("name", "handlerService.handleUpdateCategoryFlowStep").Logger()
 	logger.Debug().Any("opts", opts).Msg("got args")
@@ -130,37 +441,122 @@ func (h handlerService) handleChooseCategoryFlowStepForUpdate(ctx context.Contex
 	}
 
 	opts.stateMetaData.Add(model.PreviousCategoryIDMetadataKey, category.ID)
-	return model.EnterUpdatedCategoryNameFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+	return model.ChooseUpdateCategoryOptionFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
 		ChatID:                  opts.message.GetChatID(),
 		MessageID:               opts.message.GetMessageID(),
 		InlineMessageID:         opts.message.GetInlineMessageID(),
 		FormatMessageInMarkDown: true,
-		UpdatedMessage:          fmt.Sprintf("Enter updated category name(Current: `%s`)", category.Title),
+		UpdatedMessage:          fmt.Sprintf("Category: `%s`\nKind: `%s`\nChoose what you want to update:", category.Title, category.GetKind().GetLabel()),
+		UpdatedInlineKeyboard:   updateCategoryOptionsKeyboard,
 	})
 }
 
-func (h handlerService) handleEnterUpdatedCategoryNameFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
-	logger := h.logger.With().Str("name", "handlerService.handleEnterUpdatedCategoryNameFlowStep").Logger()
+func (h handlerService) handleChooseUpdateCategoryOptionFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseUpdateCategoryOptionFlowStep").Logger()
 	logger.Debug().Any("opts", opts).Msg("got args")
 
-	previousCategoryID, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.PreviousCategoryIDMetadataKey)
+	category, err := h.getCategoryForUpdate(ctx, opts)
+	if err != nil {
+		if errs.IsExpected(err) {
+			return "", err
+		}
+
+		logger.Error().Err(err).Msg("get category for update")
+		return "", fmt.Errorf("get category for update: %w", err)
+	}
+
+	switch opts.message.GetText() {
+	case model.BotUpdateCategoryNameCommand:
+		return model.EnterUpdatedCategoryNameFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+			ChatID:                  opts.message.GetChatID(),
+			MessageID:               opts.message.GetMessageID(),
+			InlineMessageID:         opts.message.GetInlineMessageID(),
+			FormatMessageInMarkDown: true,
+			UpdatedMessage:          fmt.Sprintf("Enter updated category name(Current: `%s`)", category.Title),
+		})
+	case model.BotUpdateCategoryKindCommand:
+		return model.ChooseCategoryKindFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+			ChatID:                  opts.message.GetChatID(),
+			MessageID:               opts.message.GetMessageID(),
+			InlineMessageID:         opts.message.GetInlineMessageID(),
+			FormatMessageInMarkDown: true,
+			UpdatedMessage:          fmt.Sprintf("Choose updated category kind(Current: `%s`)", category.GetKind().GetLabel()),
+			UpdatedInlineKeyboard:   categoryKindKeyboard,
+		})
+	case model.BotArchiveCategoryCommand:
+		category.Archived = true
+
+		err = h.stores.Category.Update(ctx, category)
+		if err != nil {
+			logger.Error().Err(err).Msg("update category in store")
+			return "", fmt.Errorf("update category in store: %w", err)
+		}
+
+		return model.EndFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+			ChatID:                  opts.message.GetChatID(),
+			MessageID:               opts.message.GetMessageID(),
+			InlineMessageID:         opts.message.GetInlineMessageID(),
+			FormatMessageInMarkDown: true,
+			UpdatedMessage: fmt.Sprintf(
+				"Category `%s` archived!\nIt won't be offered for new operations, but stays in history and statistics. You can unarchive it from the categories list.",
+				category.Title,
+			),
+			UpdatedKeyboard: categoryKeyboardRows,
+		})
+	default:
+		return "", fmt.Errorf("received unknown update category option: %s", opts.message.GetText())
+	}
+}
+
+func (h handlerService) handleChooseCategoryKindFlowStepForUpdate(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseCategoryKindFlowStepForUpdate").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	categoryKind, ok := model.GetCategoryKindFromCommand(opts.message.GetText())
 	if !ok {
-		logger.Error().Msg("previous category id not found in metadata")
-		return "", fmt.Errorf("previous category id not found in metadata")
+		logger.Error().Any("command", opts.message.GetText()).Msg("received unknown category kind")
+		return "", fmt.Errorf("received unknown category kind: %s", opts.message.GetText())
 	}
 
-	category, err := h.stores.Category.Get(ctx, GetCategoryFilter{
-		ID: previousCategoryID,
-	})
+	category, err := h.getCategoryForUpdate(ctx, opts)
 	if err != nil {
-		logger.Error().Err(err).Msg("get category from store")
-		return "", fmt.Errorf("get category from store: %w", err)
+		if errs.IsExpected(err) {
+			return "", err
+		}
+
+		logger.Error().Err(err).Msg("get category for update")
+		return "", fmt.Errorf("get category for update: %w", err)
 	}
-	if category == nil {
-		logger.Info().Msg("category not found")
-		return "", ErrCategoryNotFound
+
+	category.Kind = categoryKind
+
+	err = h.stores.Category.Update(ctx, category)
+	if err != nil {
+		logger.Error().Err(err).Msg("update category in store")
+		return "", fmt.Errorf("update category in store: %w", err)
+	}
+
+	return model.EndFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:                  opts.message.GetChatID(),
+		FormatMessageInMarkDown: true,
+		Message:                 fmt.Sprintf("Category updated!\nNew kind: `%s`", category.GetKind().GetLabel()),
+		Keyboard:                categoryKeyboardRows,
+	})
+}
+
+func (h handlerService) handleEnterUpdatedCategoryNameFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleEnterUpdatedCategoryNameFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	category, err := h.getCategoryForUpdate(ctx, opts)
+	if err != nil {
+		if errs.IsExpected(err) {
+			return "", err
+		}
+
+		logger.Error().Err(err).Msg("get category for update")
+		return "", fmt.Errorf("get category for update: %w", err)
 	}
-	logger.Debug().Any("category", category).Msg("got category from store")
 
 	category.Title = opts.message.GetText()
 
@@ -178,6 +574,31 @@ func (h handlerService) handleEnterUpdatedCategoryNameFlowStep(ctx context.Conte
 	})
 }
 
+func (h handlerService) getCategoryForUpdate(ctx context.Context, opts flowProcessingOptions) (*model.Category, error) {
+	logger := h.logger.With().Str("name", "handlerService.getCategoryForUpdate").Logger()
+
+	previousCategoryID, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.PreviousCategoryIDMetadataKey)
+	if !ok {
+		logger.Error().Msg("previous category id not found in metadata")
+		return nil, fmt.Errorf("previous category id not found in metadata")
+	}
+
+	category, err := h.stores.Category.Get(ctx, GetCategoryFilter{
+		ID: previousCategoryID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get category from store")
+		return nil, fmt.Errorf("get category from store: %w", err)
+	}
+	if category == nil {
+		logger.Info().Msg("category not found")
+		return nil, ErrCategoryNotFound
+	}
+	logger.Debug().Any("category", category).Msg("got category from store")
+
+	return category, nil
+}
+
 func (h handlerService) handleDeleteCategoryFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
 	logger := h.logger.With().Str("name", "handlerService.handleDeleteCategoryFlowStep").Logger()
 	logger.Debug().Any("opts", opts).Msg("got args")
@@ -223,6 +644,38 @@ func (h handlerService) handleChooseCategoryFlowStepForDelete(ctx context.Contex
 	}
 	logger.Debug().Any("category", category).Msg("got category from store")
 
+	operationsCount, subscriptionsCount, err := h.countCategoryUsage(ctx, category.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("count category usage")
+		return "", fmt.Errorf("count category usage: %w", err)
+	}
+
+	// Operations and subscriptions can't point to the missing category, so they should be moved to another one.
+	if operationsCount > 0 || subscriptionsCount > 0 {
+		replacementCategories, err := h.listOtherCategories(ctx, opts.user.ID, category.ID)
+		if err != nil {
+			if errs.IsExpected(err) {
+				return model.EndFlowStep, err
+			}
+
+			logger.Error().Err(err).Msg("list other categories")
+			return "", fmt.Errorf("list other categories: %w", err)
+		}
+
+		opts.stateMetaData.Add(model.CategoryIDMetadataKey, category.ID)
+		return model.ChooseReplacementCategoryFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+			ChatID:                  opts.message.GetChatID(),
+			MessageID:               opts.message.GetMessageID(),
+			InlineMessageID:         opts.message.GetInlineMessageID(),
+			FormatMessageInMarkDown: true,
+			UpdatedMessage: fmt.Sprintf(
+				"Category `%s` is used by %d operation(s) and %d subscription(s).\nChoose category which will replace it:",
+				category.Title, operationsCount, subscriptionsCount,
+			),
+			UpdatedInlineKeyboard: getInlineKeyboardRows(replacementCategories, 3),
+		})
+	}
+
 	err = h.stores.Category.Delete(ctx, category.ID)
 	if err != nil {
 		logger.Error().Err(err).Msg("delete category in store")
@@ -237,6 +690,226 @@ func (h handlerService) handleChooseCategoryFlowStepForDelete(ctx context.Contex
 	})
 }
 
+func (h handlerService) handleChooseReplacementCategoryFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseReplacementCategoryFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	sourceCategory, targetCategory, err := h.mergeCategories(ctx, opts)
+	if err != nil {
+		if errs.IsExpected(err) {
+			return "", err
+		}
+
+		logger.Error().Err(err).Msg("merge categories")
+		return "", fmt.Errorf("merge categories: %w", err)
+	}
+
+	return model.EndFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                  opts.message.GetChatID(),
+		MessageID:               opts.message.GetMessageID(),
+		InlineMessageID:         opts.message.GetInlineMessageID(),
+		FormatMessageInMarkDown: true,
+		UpdatedKeyboard:         categoryKeyboardRows,
+		UpdatedMessage: fmt.Sprintf(
+			"Category `%s` deleted successfully!\nAll its operations and subscriptions moved to `%s`.",
+			sourceCategory.Title, targetCategory.Title,
+		),
+	})
+}
+
+func (h handlerService) handleMergeCategoriesFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleMergeCategoriesFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	categories, err := h.listCategories(ctx, opts.user.ID)
+	if err != nil {
+		if errs.IsExpected(err) {
+			return model.EndFlowStep, err
+		}
+
+		logger.Error().Err(err).Msg("list categories")
+		return "", fmt.Errorf("list categories: %w", err)
+	}
+	if len(categories) < 2 {
+		logger.Info().Msg("not enough categories to merge")
+		return model.EndFlowStep, ErrNotEnoughCategories
+	}
+
+	err = h.showCancelButton(opts.message.GetChatID(), "")
+	if err != nil {
+		logger.Error().Err(err).Msg("show cancel button")
+		return "", fmt.Errorf("show cancel button: %w", err)
+	}
+
+	return model.ChooseCategoryFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:         opts.message.GetChatID(),
+		Message:        "Choose category which will be merged into another one:",
+		InlineKeyboard: getInlineKeyboardRows(categories, 3),
+	})
+}
+
+func (h handlerService) handleChooseCategoryFlowStepForMerge(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseCategoryFlowStepForMerge").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	category, err := h.stores.Category.Get(ctx, GetCategoryFilter{
+		UserID: opts.user.ID,
+		Title:  opts.message.GetText(),
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get category from store")
+		return "", fmt.Errorf("get category from store: %w", err)
+	}
+	if category == nil {
+		logger.Info().Msg("category not found")
+		return "", ErrCategoryNotFound
+	}
+
+	operationsCount, subscriptionsCount, err := h.countCategoryUsage(ctx, category.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("count category usage")
+		return "", fmt.Errorf("count category usage: %w", err)
+	}
+
+	targetCategories, err := h.listOtherCategories(ctx, opts.user.ID, category.ID)
+	if err != nil {
+		if errs.IsExpected(err) {
+			return model.EndFlowStep, err
+		}
+
+		logger.Error().Err(err).Msg("list other categories")
+		return "", fmt.Errorf("list other categories: %w", err)
+	}
+
+	opts.stateMetaData.Add(model.CategoryIDMetadataKey, category.ID)
+	return model.ChooseTargetCategoryFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                  opts.message.GetChatID(),
+		MessageID:               opts.message.GetMessageID(),
+		InlineMessageID:         opts.message.GetInlineMessageID(),
+		FormatMessageInMarkDown: true,
+		UpdatedMessage: fmt.Sprintf(
+			"Category `%s` is used by %d operation(s) and %d subscription(s).\nChoose category into which it will be merged:",
+			category.Title, operationsCount, subscriptionsCount,
+		),
+		UpdatedInlineKeyboard: getInlineKeyboardRows(targetCategories, 3),
+	})
+}
+
+func (h handlerService) handleChooseTargetCategoryFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseTargetCategoryFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	sourceCategory, targetCategory, err := h.mergeCategories(ctx, opts)
+	if err != nil {
+		if errs.IsExpected(err) {
+			return "", err
+		}
+
+		logger.Error().Err(err).Msg("merge categories")
+		return "", fmt.Errorf("merge categories: %w", err)
+	}
+
+	return model.EndFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                  opts.message.GetChatID(),
+		MessageID:               opts.message.GetMessageID(),
+		InlineMessageID:         opts.message.GetInlineMessageID(),
+		FormatMessageInMarkDown: true,
+		UpdatedKeyboard:         categoryKeyboardRows,
+		UpdatedMessage:          fmt.Sprintf("Category `%s` merged into `%s` successfully!", sourceCategory.Title, targetCategory.Title),
+	})
+}
+
+// mergeCategories moves everything from the category saved in metadata to the category chosen by the user.
+func (h handlerService) mergeCategories(ctx context.Context, opts flowProcessingOptions) (*model.Category, *model.Category, error) {
+	logger := h.logger.With().Str("name", "handlerService.mergeCategories").Logger()
+
+	sourceCategoryID, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.CategoryIDMetadataKey)
+	if !ok {
+		logger.Error().Msg("category id not found in metadata")
+		return nil, nil, fmt.Errorf("category id not found in metadata")
+	}
+
+	sourceCategory, err := h.stores.Category.Get(ctx, GetCategoryFilter{
+		ID:     sourceCategoryID,
+		UserID: opts.user.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get source category from store")
+		return nil, nil, fmt.Errorf("get source category from store: %w", err)
+	}
+	if sourceCategory == nil {
+		logger.Info().Msg("source category not found")
+		return nil, nil, ErrCategoryNotFound
+	}
+
+	targetCategory, err := h.stores.Category.Get(ctx, GetCategoryFilter{
+		UserID: opts.user.ID,
+		Title:  opts.message.GetText(),
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get target category from store")
+		return nil, nil, fmt.Errorf("get target category from store: %w", err)
+	}
+	if targetCategory == nil {
+		logger.Info().Msg("target category not found")
+		return nil, nil, ErrCategoryNotFound
+	}
+	if targetCategory.ID == sourceCategory.ID {
+		logger.Info().Msg("the same category chosen")
+		return nil, nil, ErrSameCategoryChosen
+	}
+
+	err = h.stores.Category.Merge(ctx, sourceCategory.ID, targetCategory.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("merge categories in store")
+		return nil, nil, fmt.Errorf("merge categories in store: %w", err)
+	}
+	logger.Info().Any("sourceCategory", sourceCategory).Any("targetCategory", targetCategory).Msg("merged categories")
+
+	return sourceCategory, targetCategory, nil
+}
+
+// countCategoryUsage returns the number of operations and balance subscriptions that use the category.
+func (h handlerService) countCategoryUsage(ctx context.Context, categoryID string) (int, int, error) {
+	logger := h.logger.With().Str("name", "handlerService.countCategoryUsage").Logger()
+	logger.Debug().Any("categoryID", categoryID).Msg("got args")
+
+	operationsCount, err := h.stores.Operation.Count(ctx, ListOperationsFilter{
+		CategoryID: categoryID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("count operations in store")
+		return 0, 0, fmt.Errorf("count operations in store: %w", err)
+	}
+
+	subscriptionsCount, err := h.stores.BalanceSubscription.Count(ctx, ListBalanceSubscriptionFilter{
+		CategoryID: categoryID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("count balance subscriptions in store")
+		return 0, 0, fmt.Errorf("count balance subscriptions in store: %w", err)
+	}
+
+	return operationsCount, subscriptionsCount, nil
+}
+
+// listOtherCategories returns user categories except the one with the given ID.
+func (h handlerService) listOtherCategories(ctx context.Context, userID, categoryID string) ([]model.Category, error) {
+	categories, err := h.listCategories(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	otherCategories := slices.DeleteFunc(categories, func(category model.Category) bool {
+		return category.ID == categoryID
+	})
+	if len(otherCategories) == 0 {
+		return nil, ErrNotEnoughCategories
+	}
+
+	return otherCategories, nil
+}
+
 func (h handlerService) listCategories(ctx context.Context, userID string) ([]model.Category, error) {
 	logger := h.logger.With().Str("name", "handlerService.listCategories").Logger()
 	logger.Debug().Any("userID", userID).Msg("got args")
@@ -256,3 +929,70 @@ func (h handlerService) listCategories(ctx context.Context, userID string) ([]mo
 	logger.Info().Any("categories", categories).Msg("got categories from store")
 	return categories, nil
 }
+
+// showSubcategoriesIfNeeded shows sub-categories of the chosen top-level category, so the user could pick the more specific one.
+// The chosen category itself is kept on the keyboard as well. Only sub-categories suitable for the operation type are shown.
+// Returns true when the category should be chosen once again.
+func (h handlerService) showSubcategoriesIfNeeded(ctx context.Context, opts flowProcessingOptions, category *model.Category, operationType model.OperationType) (bool, error) {
+	logger := h.logger.With().Str("name", "handlerService.showSubcategoriesIfNeeded").Logger()
+	logger.Debug().Any("category", category).Msg("got args")
+
+	shownParentCategoryID, _ := model.GetTypedFromMetadata[string](opts.stateMetaData, model.ParentCategoryIDMetadataKey)
+	if category.IsSubcategory() || shownParentCategoryID == category.ID {
+		opts.stateMetaData.Add(model.ParentCategoryIDMetadataKey, "")
+		return false, nil
+	}
+
+	subcategories, err := h.stores.Category.List(ctx, &ListCategoriesFilter{
+		UserID:   opts.user.ID,
+		ParentID: category.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list sub-categories from store")
+		return false, fmt.Errorf("list sub-categories from store: %w", err)
+	}
+
+	subcategories = model.GetActiveCategories(subcategories)
+	// Empty operation type means that sub-categories of any kind could be chosen.
+	if operationType != "" {
+		subcategories = model.FilterCategoriesByOperationType(subcategories, operationType)
+	}
+	if len(subcategories) == 0 {
+		return false, nil
+	}
+	logger.Debug().Any("subcategories", subcategories).Msg("got sub-categories from store")
+
+	opts.stateMetaData.Add(model.ParentCategoryIDMetadataKey, category.ID)
+	return true, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                  opts.message.GetChatID(),
+		MessageID:               opts.message.GetMessageID(),
+		InlineMessageID:         opts.message.GetInlineMessageID(),
+		FormatMessageInMarkDown: true,
+		UpdatedMessage:          fmt.Sprintf("Choose sub-category of `%s` or the category itself:", category.Title),
+		UpdatedInlineKeyboard:   getInlineKeyboardRows(append([]model.Category{*category}, subcategories...), 3),
+	})
+}
+
+// checkCategoryCanBeChosen checks that the category is active and suitable for the operation type,
+// since the user could type the title of the category which isn't shown on the keyboard.
+func (h handlerService) checkCategoryCanBeChosen(ctx context.Context, userID string, category *model.Category, operationType model.OperationType) error {
+	categories, err := h.getCategoriesForOperationType(ctx, userID, operationType)
+	if err != nil {
+		return err
+	}
+
+	if !slices.ContainsFunc(categories, func(c model.Category) bool { return c.ID == category.ID }) {
+		return ErrCategoryNotAvailable
+	}
+
+	return nil
+}
+
+// getCategoriesToChoose returns active top-level categories for the choosing keyboard without the currently used category.
+// Currently used category is kept when it has sub-categories, so the user could still navigate to them.
+func getCategoriesToChoose(categories []model.Category, currentCategoryID string) []model.Category {
+	categories = model.GetActiveCategories(categories)
+	return slices.DeleteFunc(model.GetTopLevelCategories(categories), func(category model.Category) bool {
+		return category.ID == currentCategoryID && len(model.GetSubcategories(category.ID, categories)) == 0
+	})
+}
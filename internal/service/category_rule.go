@@ -0,0 +1,431 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/VladPetriv/finance_bot/pkg/errs"
+	"github.com/google/uuid"
+)
+
+const (
+	skipCategoryRuleAmountRangeData = "skip_amount_range"
+	anyCategoryRuleBalanceData      = "any_balance"
+)
+
+func (h handlerService) handleCreateCategoryRuleFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleCreateCategoryRuleFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	_, err := h.listCategories(ctx, opts.user.ID)
+	if err != nil {
+		if errs.IsExpected(err) {
+			return model.EndFlowStep, err
+		}
+
+		logger.Error().Err(err).Msg("list categories")
+		return "", fmt.Errorf("list categories: %w", err)
+	}
+
+	return model.EnterCategoryRulePatternFlowStep, h.showCancelButton(
+		opts.message.GetChatID(),
+		"Enter text which operation description should contain, e.g. `coffee`.\nTo use regular expression wrap it into slashes, e.g. `/^(uber|bolt)/`:",
+	)
+}
+
+func (h handlerService) handleEnterCategoryRulePatternFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleEnterCategoryRulePatternFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	_, _, err := model.ParseCategoryRulePattern(opts.message.GetText())
+	if err != nil {
+		logger.Info().Err(err).Msg("invalid category rule pattern")
+		return "", ErrInvalidCategoryRulePattern
+	}
+	opts.stateMetaData.Add(model.CategoryRulePatternMetadataKey, opts.message.GetText())
+
+	categories, err := h.listCategories(ctx, opts.user.ID)
+	if err != nil {
+		if errs.IsExpected(err) {
+			return model.EndFlowStep, err
+		}
+
+		logger.Error().Err(err).Msg("list categories")
+		return "", fmt.Errorf("list categories: %w", err)
+	}
+
+	return model.ChooseCategoryFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:         opts.message.GetChatID(),
+		Message:        "Choose category for operations matched by the rule:",
+		InlineKeyboard: getInlineKeyboardRows(model.GetTopLevelCategories(model.GetActiveCategories(categories)), 3),
+	})
+}
+
+func (h handlerService) handleChooseCategoryFlowStepForCreateCategoryRule(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseCategoryFlowStepForCreateCategoryRule").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	category, err := h.stores.Category.Get(ctx, GetCategoryFilter{
+		UserID: opts.user.ID,
+		Title:  opts.message.GetText(),
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get category from store")
+		return "", fmt.Errorf("get category from store: %w", err)
+	}
+	if category == nil {
+		logger.Info().Msg("category not found")
+		return "", ErrCategoryNotFound
+	}
+
+	// Rule could be used for operations of any type, so sub-categories of all kinds are shown.
+	subcategoriesShown, err := h.showSubcategoriesIfNeeded(ctx, opts, category, "")
+	if err != nil {
+		logger.Error().Err(err).Msg("show sub-categories")
+		return "", fmt.Errorf("show sub-categories: %w", err)
+	}
+	if subcategoriesShown {
+		return model.ChooseCategoryFlowStep, nil
+	}
+
+	opts.stateMetaData.Add(model.CategoryIDMetadataKey, category.ID)
+
+	return model.EnterCategoryRuleAmountRangeFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                  opts.message.GetChatID(),
+		MessageID:               opts.message.GetMessageID(),
+		InlineMessageID:         opts.message.GetInlineMessageID(),
+		FormatMessageInMarkDown: true,
+		UpdatedMessage:          "Enter amount range of matched operations, e.g. `100-500`, `>100` or `<500`:",
+		UpdatedInlineKeyboard: []InlineKeyboardRow{
+			{
+				Buttons: []InlineKeyboardButton{
+					{
+						Text: "Any amount ⏭️",
+						Data: skipCategoryRuleAmountRangeData,
+					},
+				},
+			},
+		},
+	})
+}
+
+func (h handlerService) handleEnterCategoryRuleAmountRangeFlowStep(_ context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleEnterCategoryRuleAmountRangeFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	balancesKeyboard := append(getInlineKeyboardRows(opts.user.Balances, 2), InlineKeyboardRow{
+		Buttons: []InlineKeyboardButton{
+			{
+				Text: "Any balance 🌐",
+				Data: anyCategoryRuleBalanceData,
+			},
+		},
+	})
+
+	if opts.message.GetText() == skipCategoryRuleAmountRangeData {
+		return model.ChooseBalanceFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+			ChatID:                opts.message.GetChatID(),
+			MessageID:             opts.message.GetMessageID(),
+			InlineMessageID:       opts.message.GetInlineMessageID(),
+			UpdatedMessage:        "Choose balance of matched operations:",
+			UpdatedInlineKeyboard: balancesKeyboard,
+		})
+	}
+
+	minAmount, maxAmount, err := model.ParseCategoryRuleAmountRange(opts.message.GetText())
+	if err != nil {
+		logger.Info().Err(err).Msg("invalid category rule amount range")
+		return "", ErrInvalidCategoryRuleAmountRange
+	}
+	opts.stateMetaData.Add(model.CategoryRuleMinAmountMetadataKey, minAmount)
+	opts.stateMetaData.Add(model.CategoryRuleMaxAmountMetadataKey, maxAmount)
+
+	return model.ChooseBalanceFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:         opts.message.GetChatID(),
+		Message:        "Choose balance of matched operations:",
+		InlineKeyboard: balancesKeyboard,
+	})
+}
+
+func (h handlerService) handleChooseBalanceFlowStepForCreateCategoryRule(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseBalanceFlowStepForCreateCategoryRule").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	var balanceID string
+	if opts.message.GetText() != anyCategoryRuleBalanceData {
+		balance := opts.user.GetBalance(opts.message.GetText())
+		if balance == nil {
+			logger.Info().Msg("balance not found")
+			return "", ErrBalanceNotFound
+		}
+
+		balanceID = balance.ID
+	}
+
+	pattern, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.CategoryRulePatternMetadataKey)
+	if !ok {
+		logger.Error().Msg("category rule pattern not found in metadata")
+		return "", fmt.Errorf("category rule pattern not found in metadata")
+	}
+
+	categoryID, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.CategoryIDMetadataKey)
+	if !ok {
+		logger.Error().Msg("category id not found in metadata")
+		return "", fmt.Errorf("category id not found in metadata")
+	}
+
+	// Amount range is optional, so there is nothing in metadata when the user skipped it.
+	minAmount, _ := model.GetTypedFromMetadata[string](opts.stateMetaData, model.CategoryRuleMinAmountMetadataKey)
+	maxAmount, _ := model.GetTypedFromMetadata[string](opts.stateMetaData, model.CategoryRuleMaxAmountMetadataKey)
+
+	matchType, parsedPattern, err := model.ParseCategoryRulePattern(pattern)
+	if err != nil {
+		logger.Info().Err(err).Msg("invalid category rule pattern")
+		return "", ErrInvalidCategoryRulePattern
+	}
+
+	rule := &model.CategoryRule{
+		ID:         uuid.NewString(),
+		UserID:     opts.user.ID,
+		CategoryID: categoryID,
+		BalanceID:  balanceID,
+		MatchType:  matchType,
+		Pattern:    parsedPattern,
+		MinAmount:  minAmount,
+		MaxAmount:  maxAmount,
+		CreatedAt:  time.Now(),
+	}
+
+	err = h.stores.CategoryRule.Create(ctx, rule)
+	if err != nil {
+		logger.Error().Err(err).Msg("create category rule in store")
+		return "", fmt.Errorf("create category rule in store: %w", err)
+	}
+
+	return model.EndFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:          opts.message.GetChatID(),
+		MessageID:       opts.message.GetMessageID(),
+		InlineMessageID: opts.message.GetInlineMessageID(),
+		UpdatedMessage:  "Rule successfully created! New operations matched by it will be categorized automatically.",
+		UpdatedKeyboard: categoryKeyboardRows,
+	})
+}
+
+func (h handlerService) handleListCategoryRulesFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleListCategoryRulesFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	rules, err := h.stores.CategoryRule.List(ctx, ListCategoryRulesFilter{
+		UserID: opts.user.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list category rules from store")
+		return "", fmt.Errorf("list category rules from store: %w", err)
+	}
+	if len(rules) == 0 {
+		logger.Info().Msg("no category rules found")
+		return model.EndFlowStep, ErrCategoryRulesNotFound
+	}
+
+	categories, err := h.stores.Category.List(ctx, &ListCategoriesFilter{
+		UserID: opts.user.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list categories from store")
+		return "", fmt.Errorf("list categories from store: %w", err)
+	}
+
+	outputMessage := model.BuildCategoryRulesMessage(rules, categories, opts.user.Balances)
+	logger.Debug().Any("outputMessage", outputMessage).Msg("built output message")
+
+	return model.EndFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:   opts.message.GetChatID(),
+		Message:  outputMessage,
+		Keyboard: categoryKeyboardRows,
+	})
+}
+
+func (h handlerService) handleDeleteCategoryRuleFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleDeleteCategoryRuleFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	rules, err := h.stores.CategoryRule.List(ctx, ListCategoryRulesFilter{
+		UserID: opts.user.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list category rules from store")
+		return "", fmt.Errorf("list category rules from store: %w", err)
+	}
+	if len(rules) == 0 {
+		logger.Info().Msg("no category rules found")
+		return model.EndFlowStep, ErrCategoryRulesNotFound
+	}
+
+	categories, err := h.stores.Category.List(ctx, &ListCategoriesFilter{
+		UserID: opts.user.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list categories from store")
+		return "", fmt.Errorf("list categories from store: %w", err)
+	}
+
+	err = h.showCancelButton(opts.message.GetChatID(), "")
+	if err != nil {
+		logger.Error().Err(err).Msg("show cancel button")
+		return "", fmt.Errorf("show cancel button: %w", err)
+	}
+
+	rulesKeyboard := make([]InlineKeyboardRow, 0, len(rules))
+	for _, rule := range rules {
+		var categoryTitle string
+		for _, category := range categories {
+			if category.ID == rule.CategoryID {
+				categoryTitle = model.GetCategoryFullTitle(category, categories)
+				break
+			}
+		}
+
+		rulesKeyboard = append(rulesKeyboard, InlineKeyboardRow{
+			Buttons: []InlineKeyboardButton{
+				{
+					Text: fmt.Sprintf("%s → %s", rule.GetName(), categoryTitle),
+					Data: rule.ID,
+				},
+			},
+		})
+	}
+
+	return model.ChooseCategoryRuleFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:         opts.message.GetChatID(),
+		Message:        "Choose rule to delete:",
+		InlineKeyboard: rulesKeyboard,
+	})
+}
+
+func (h handlerService) handleChooseCategoryRuleFlowStepForDelete(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseCategoryRuleFlowStepForDelete").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	rule, err := h.stores.CategoryRule.Get(ctx, GetCategoryRuleFilter{
+		ID:     opts.message.GetText(),
+		UserID: opts.user.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get category rule from store")
+		return "", fmt.Errorf("get category rule from store: %w", err)
+	}
+	if rule == nil {
+		logger.Info().Msg("category rule not found")
+		return "", ErrCategoryRuleNotFound
+	}
+
+	err = h.stores.CategoryRule.Delete(ctx, rule.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("delete category rule from store")
+		return "", fmt.Errorf("delete category rule from store: %w", err)
+	}
+
+	return model.EndFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:          opts.message.GetChatID(),
+		MessageID:       opts.message.GetMessageID(),
+		InlineMessageID: opts.message.GetInlineMessageID(),
+		UpdatedMessage:  fmt.Sprintf("Rule %s successfully deleted!", rule.GetName()),
+		UpdatedKeyboard: categoryKeyboardRows,
+	})
+}
+
+// createCategoryRuleFromOperation creates a rule which matches descriptions containing the operation description
+// and assigns the operation category to them.
+func (h handlerService) createCategoryRuleFromOperation(ctx context.Context, userID string, operation *model.Operation) (*model.CategoryRule, error) {
+	logger := h.logger.With().Str("name", "handlerService.createCategoryRuleFromOperation").Logger()
+	logger.Debug().Any("userID", userID).Any("operation", operation).Msg("got args")
+
+	// Description is a plain text, so it's never treated as a regular expression.
+	pattern := strings.ToLower(strings.TrimSpace(operation.Description))
+	if pattern == "" || operation.CategoryID == "" {
+		logger.Info().Msg("operation can't be used for category rule")
+		return nil, ErrOperationCannotBeUsedForCategoryRule
+	}
+
+	rules, err := h.stores.CategoryRule.List(ctx, ListCategoryRulesFilter{
+		UserID: userID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list category rules from store")
+		return nil, fmt.Errorf("list category rules from store: %w", err)
+	}
+	for _, rule := range rules {
+		if rule.MatchType == model.CategoryRuleMatchTypeContains && strings.EqualFold(rule.Pattern, pattern) {
+			logger.Info().Msg("category rule already exists")
+			return nil, ErrCategoryRuleAlreadyExists
+		}
+	}
+
+	rule := &model.CategoryRule{
+		ID:         uuid.NewString(),
+		UserID:     userID,
+		CategoryID: operation.CategoryID,
+		MatchType:  model.CategoryRuleMatchTypeContains,
+		Pattern:    pattern,
+		CreatedAt:  time.Now(),
+	}
+
+	err = h.stores.CategoryRule.Create(ctx, rule)
+	if err != nil {
+		logger.Error().Err(err).Msg("create category rule in store")
+		return nil, fmt.Errorf("create category rule in store: %w", err)
+	}
+
+	return rule, nil
+}
+
+// getCategoryFromRules returns the category of the first user rule that matches the operation data.
+// Rules which categories are not suitable for the operation type are ignored, nil is returned when nothing matched.
+func (h handlerService) getCategoryFromRules(ctx context.Context, userID string, operationType model.OperationType, input model.CategoryRuleInput) (*model.Category, error) {
+	logger := h.logger.With().Str("name", "handlerService.getCategoryFromRules").Logger()
+	logger.Debug().Any("operationType", operationType).Any("input", input).Msg("got args")
+
+	rules, err := h.stores.CategoryRule.List(ctx, ListCategoryRulesFilter{
+		UserID: userID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list category rules from store")
+		return nil, fmt.Errorf("list category rules from store: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	categories, err := h.stores.Category.List(ctx, &ListCategoriesFilter{
+		UserID: userID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list categories from store")
+		return nil, fmt.Errorf("list categories from store: %w", err)
+	}
+
+	suitableCategories := make(map[string]model.Category, len(categories))
+	// Archived categories are not used for new operations, so their rules are ignored as well.
+	for _, category := range model.FilterCategoriesByOperationType(model.GetActiveCategories(categories), operationType) {
+		suitableCategories[category.ID] = category
+	}
+
+	suitableRules := make([]model.CategoryRule, 0, len(rules))
+	for _, rule := range rules {
+		if _, ok := suitableCategories[rule.CategoryID]; ok {
+			suitableRules = append(suitableRules, rule)
+		}
+	}
+
+	rule := model.FindCategoryRule(suitableRules, input)
+	if rule == nil {
+		return nil, nil
+	}
+	logger.Debug().Any("rule", rule).Msg("found matching category rule")
+
+	category := suitableCategories[rule.CategoryID]
+	return &category, nil
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/VladPetriv/finance_bot/pkg/errs"
+	"github.com/google/uuid"
+)
+
+const skipCategoryTemplateData = "skip_category_template"
+
+var categoryTemplatesKeyboardWithSkipButton = append(getInlineKeyboardRows(model.CategoryTemplates, 1), InlineKeyboardRow{
+	Buttons: []InlineKeyboardButton{
+		{
+			Text: "Skip ⏭️",
+			Data: skipCategoryTemplateData,
+		},
+	},
+})
+
+func (h handlerService) handleApplyCategoryTemplateFlowStep(_ context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleApplyCategoryTemplateFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	return model.ChooseCategoryTemplateFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:         opts.message.GetChatID(),
+		Message:        model.BuildCategoryTemplatesMessage() + "\nChoose the template, categories you already have will be skipped:",
+		InlineKeyboard: getInlineKeyboardRows(model.CategoryTemplates, 1),
+	})
+}
+
+func (h handlerService) handleChooseCategoryTemplateFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseCategoryTemplateFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	createdCategories, err := h.applyCategoryTemplate(ctx, opts.user.ID, opts.message.GetText())
+	if err != nil {
+		if errs.IsExpected(err) {
+			return "", err
+		}
+
+		logger.Error().Err(err).Msg("apply category template")
+		return "", fmt.Errorf("apply category template: %w", err)
+	}
+
+	return model.EndFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:          opts.message.GetChatID(),
+		MessageID:       opts.message.GetMessageID(),
+		InlineMessageID: opts.message.GetInlineMessageID(),
+		UpdatedMessage:  buildAppliedCategoryTemplateMessage(createdCategories),
+		UpdatedKeyboard: categoryKeyboardRows,
+	})
+}
+
+func (h handlerService) handleChooseCategoryTemplateFlowStepForStart(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseCategoryTemplateFlowStepForStart").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	message := "You can add categories from the templates later in the categories menu."
+	if opts.message.GetText() != skipCategoryTemplateData {
+		createdCategories, err := h.applyCategoryTemplate(ctx, opts.user.ID, opts.message.GetText())
+		if err != nil {
+			if errs.IsExpected(err) {
+				return "", err
+			}
+
+			logger.Error().Err(err).Msg("apply category template")
+			return "", fmt.Errorf("apply category template: %w", err)
+		}
+
+		message = buildAppliedCategoryTemplateMessage(createdCategories)
+	}
+
+	err := h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:          opts.message.GetChatID(),
+		MessageID:       opts.message.GetMessageID(),
+		InlineMessageID: opts.message.GetInlineMessageID(),
+		UpdatedMessage:  message,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("update message")
+		return "", fmt.Errorf("update message: %w", err)
+	}
+
+	return model.CreateInitialBalanceFlowStep, h.apis.Messenger.SendMessage(opts.message.GetChatID(), "Please enter the name of your initial balance!:")
+}
+
+// applyCategoryTemplate creates categories from the template which the user doesn't have yet and returns them.
+func (h handlerService) applyCategoryTemplate(ctx context.Context, userID, templateName string) ([]model.CategoryTemplateItem, error) {
+	logger := h.logger.With().Str("name", "handlerService.applyCategoryTemplate").Logger()
+	logger.Debug().Any("userID", userID).Any("templateName", templateName).Msg("got args")
+
+	template, ok := model.GetCategoryTemplate(templateName)
+	if !ok {
+		logger.Info().Msg("category template not found")
+		return nil, ErrCategoryTemplateNotFound
+	}
+
+	categories, err := h.stores.Category.List(ctx, &ListCategoriesFilter{
+		UserID: userID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list categories from store")
+		return nil, fmt.Errorf("list categories from store: %w", err)
+	}
+
+	missingCategories := template.GetMissingCategories(categories)
+	for _, item := range missingCategories {
+		category := model.Category{
+			ID:       uuid.NewString(),
+			UserID:   userID,
+			Title:    item.Title,
+			Kind:     item.Kind,
+			Position: model.GetNextCategoryPosition("", categories),
+		}
+
+		err := h.stores.Category.Create(ctx, &category)
+		if err != nil {
+			logger.Error().Err(err).Msg("create category in store")
+			return nil, fmt.Errorf("create category in store: %w", err)
+		}
+
+		categories = append(categories, category)
+	}
+
+	return missingCategories, nil
+}
+
+func buildAppliedCategoryTemplateMessage(createdCategories []model.CategoryTemplateItem) string {
+	if len(createdCategories) == 0 {
+		return "You already have all categories from this template!"
+	}
+
+	titles := make([]string, 0, len(createdCategories))
+	for _, item := range createdCategories {
+		titles = append(titles, item.Title)
+	}
+
+	return fmt.Sprintf("Created %d categories: %s", len(createdCategories), strings.Join(titles, ", "))
+}
@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/VladPetriv/finance_bot/config"
 	"github.com/VladPetriv/finance_bot/internal/model"
 	"github.com/VladPetriv/finance_bot/pkg/errs"
 	"github.com/VladPetriv/finance_bot/pkg/logger"
@@ -15,37 +17,40 @@ type currencyService struct {
 	logger   *logger.Logger
 	storages Stores
 	apis     APIs
+
+	exchangeRatesCache        *exchangeRatesCache
+	currenciesRefreshInterval time.Duration
 }
 
 // NewCurrency returns new instance of currency service.
-func NewCurrency(logger *logger.Logger, apis APIs, storages Stores) *currencyService {
+func NewCurrency(config *config.Config, logger *logger.Logger, apis APIs, storages Stores) *currencyService {
 	return &currencyService{
-		logger:   logger,
-		apis:     apis,
-		storages: storages,
+		logger:                    logger,
+		apis:                      apis,
+		storages:                  storages,
+		exchangeRatesCache:        newExchangeRatesCache(config.App.ExchangeRatesCacheTTL),
+		currenciesRefreshInterval: config.App.CurrenciesRefreshInterval,
 	}
 }
 
-// availableCurrenciesCount represents the number of available currencies which can be received from the CurrencyExchanger API.
-const availableCurrenciesCount = 161
-
 func (c *currencyService) InitCurrencies(ctx context.Context) error {
 	logger := c.logger.With().Str("name", "currencyService.InitCurrencies").Logger()
 
-	currenciesCount, err := c.storages.Currency.Count(ctx)
+	currenciesCount, err := c.storages.Currency.Count(ctx, ListCurrenciesFilter{})
 	if err != nil {
 		logger.Error().Err(err).Msg("count currencies in the database")
 		return fmt.Errorf("count currencies in the database: %w", err)
 	}
-	if currenciesCount == availableCurrenciesCount {
+	// Once the catalog is seeded, it's kept in sync by RefreshCurrencies.
+	if currenciesCount > 0 {
 		logger.Info().Any("currenciesCount", currenciesCount).Msg("currencies already initialized")
 		return nil
 	}
 
-	currencies, err := c.apis.CurrencyExchanger.FetchCurrencies()
+	currencies, err := c.apis.CurrencyCatalog.FetchCurrencies()
 	if err != nil {
-		logger.Error().Err(err).Msg("fetch currencies through currency exchanger")
-		return fmt.Errorf("fetch currencies through currency exchanger: %w", err)
+		logger.Error().Err(err).Msg("fetch currencies through currency catalog")
+		return fmt.Errorf("fetch currencies through currency catalog: %w", err)
 	}
 	if len(currencies) == 0 {
 		logger.Info().Msg("currencies not found")
@@ -54,10 +59,11 @@ func (c *currencyService) InitCurrencies(ctx context.Context) error {
 
 	for _, currency := range currencies {
 		err := c.storages.Currency.CreateIfNotExists(ctx, &model.Currency{
-			ID:     uuid.NewString(),
-			Name:   currency.Name,
-			Code:   currency.Code,
-			Symbol: currency.Symbol,
+			ID:            uuid.NewString(),
+			Name:          currency.Name,
+			Code:          currency.Code,
+			Symbol:        currency.Symbol,
+			DecimalPlaces: int(money.GetDecimalPlaces(currency.Code)),
 		})
 		if err != nil {
 			logger.Error().Err(err).Any("currency", currency).Msg("create currency in the database")
@@ -70,23 +76,379 @@ func (c *currencyService) InitCurrencies(ctx context.Context) error {
 	return nil
 }
 
+func (c *currencyService) RefreshCurrencies(ctx context.Context) {
+	logger := c.logger.With().Str("name", "currencyService.RefreshCurrencies").Logger()
+
+	// Currencies are refreshed right away, so changes made while the app was down are applied without waiting for the first tick.
+	err := c.refreshCurrencies(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("refresh currencies")
+	}
+
+	ticker := time.NewTicker(c.currenciesRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info().Msg("finished refreshing currencies")
+			return
+		case <-ticker.C:
+			err := c.refreshCurrencies(ctx)
+			if err != nil {
+				logger.Error().Err(err).Msg("refresh currencies")
+			}
+		}
+	}
+}
+
+func (c *currencyService) refreshCurrencies(ctx context.Context) error {
+	logger := c.logger.With().Str("name", "currencyService.refreshCurrencies").Logger()
+
+	// Currencies are fetched only from the primary provider, when it's unavailable the refresh is skipped
+	// until the next run instead of applying a partial list from the fallback ones.
+	fetchedCurrencies, err := c.apis.CurrencyCatalog.FetchCurrencies()
+	if err != nil {
+		logger.Error().Err(err).Msg("fetch currencies through currency catalog")
+		return fmt.Errorf("fetch currencies through currency catalog: %w", err)
+	}
+	// Empty response is treated as a provider failure, otherwise all currencies would be deprecated.
+	if len(fetchedCurrencies) == 0 {
+		logger.Warn().Msg("currency catalog returned no currencies, skipping refresh")
+		return nil
+	}
+
+	storedCurrencies, err := c.storages.Currency.List(ctx, ListCurrenciesFilter{})
+	if err != nil {
+		logger.Error().Err(err).Msg("list currencies from store")
+		return fmt.Errorf("list currencies from store: %w", err)
+	}
+
+	providedCurrencies := make([]model.Currency, 0, len(fetchedCurrencies))
+	for _, currency := range fetchedCurrencies {
+		providedCurrencies = append(providedCurrencies, model.Currency{
+			ID:            uuid.NewString(),
+			Name:          currency.Name,
+			Code:          currency.Code,
+			Symbol:        currency.Symbol,
+			DecimalPlaces: int(money.GetDecimalPlaces(currency.Code)),
+		})
+	}
+
+	changes := model.GetCurrencyCatalogChanges(storedCurrencies, providedCurrencies)
+	if changes.IsEmpty() {
+		logger.Info().Msg("currencies are up to date")
+		return nil
+	}
+
+	for _, currency := range changes.Created {
+		err := c.storages.Currency.CreateIfNotExists(ctx, &currency)
+		if err != nil {
+			logger.Error().Err(err).Any("currency", currency).Msg("create currency in store")
+			return fmt.Errorf("create currency in store: %w", err)
+		}
+	}
+
+	for _, currency := range append(changes.Updated, changes.Deprecated...) {
+		err := c.storages.Currency.Update(ctx, &currency)
+		if err != nil {
+			logger.Error().Err(err).Any("currency", currency).Msg("update currency in store")
+			return fmt.Errorf("update currency in store: %w", err)
+		}
+	}
+
+	logger.Info().
+		Strs("created", model.GetCurrencyCodes(changes.Created)).
+		Strs("updated", model.GetCurrencyCodes(changes.Updated)).
+		Strs("deprecated", model.GetCurrencyCodes(changes.Deprecated)).
+		Msg("refreshed currencies")
+	return nil
+}
+
 func (c *currencyService) Convert(ctx context.Context, opts ConvertCurrencyOptions) (*money.Money, error) {
 	logger := c.logger.With().Str("name", "currencyService.Convert").Logger()
 	logger.Debug().Any("opts", opts).Msg("got args")
 
-	exchangeRate, err := c.apis.CurrencyExchanger.GetExchangeRate(opts.BaseCurrency, opts.TargetCurrency)
+	exchangeRate, err := c.GetExchangeRate(ctx, GetExchangeRateOptions{
+		BaseCurrency:   opts.BaseCurrency,
+		TargetCurrency: opts.TargetCurrency,
+		Date:           opts.Date,
+		UserID:         opts.UserID,
+	})
 	if err != nil {
 		if errs.IsExpected(err) {
 			logger.Info().Msg(err.Error())
 			return nil, err
 		}
 
-		logger.Error().Err(err).Msg("get exchange rate through currency exchanger")
-		return nil, fmt.Errorf("get exchange rate through currency exchanger: %w", err)
+		logger.Error().Err(err).Msg("get exchange rate")
+		return nil, fmt.Errorf("get exchange rate: %w", err)
 	}
 	logger.Debug().Any("exchangeRate", exchangeRate).Msg("got exchange rate")
+	if exchangeRate.Outdated {
+		logger.Warn().Any("date", exchangeRate.Date).Msg("converted with outdated exchange rate")
+	}
 
-	opts.Amount.Mul(*exchangeRate)
+	opts.Amount.Mul(exchangeRate.Rate)
 
 	return &opts.Amount, nil
 }
+
+func (c *currencyService) GetExchangeRate(ctx context.Context, opts GetExchangeRateOptions) (*GetExchangeRateOutput, error) {
+	logger := c.logger.With().Str("name", "currencyService.GetExchangeRate").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	today := model.GetExchangeRateDate(time.Now())
+	date := today
+	if !opts.Date.IsZero() {
+		date = model.GetExchangeRateDate(opts.Date)
+	}
+
+	if opts.BaseCurrency == opts.TargetCurrency {
+		return &GetExchangeRateOutput{
+			Rate: money.NewFromInt(1),
+			Date: date,
+		}, nil
+	}
+
+	// Cache is checked first, since it contains only rates of public currencies, and private currencies
+	// can't reuse their codes.
+	if exchangeRate, ok := c.exchangeRatesCache.get(opts.BaseCurrency, opts.TargetCurrency, date); ok {
+		logger.Debug().Any("exchangeRate", exchangeRate).Msg("got exchange rate from cache")
+		return &GetExchangeRateOutput{
+			Rate: exchangeRate,
+			Date: date,
+		}, nil
+	}
+
+	if opts.UserID != "" {
+		exchangeRate, found, err := c.getPrivateCurrencyExchangeRate(ctx, opts)
+		if err != nil {
+			if errs.IsExpected(err) {
+				logger.Info().Msg(err.Error())
+				return nil, err
+			}
+
+			logger.Error().Err(err).Msg("get private currency exchange rate")
+			return nil, fmt.Errorf("get private currency exchange rate: %w", err)
+		}
+		if found {
+			return exchangeRate, nil
+		}
+	}
+
+	if date.Before(today) {
+		exchangeRate, err := c.getHistoricalExchangeRate(ctx, opts.BaseCurrency, opts.TargetCurrency, date)
+		if err != nil {
+			if errs.IsExpected(err) {
+				logger.Info().Any("date", date).Msg(err.Error())
+				return nil, err
+			}
+
+			logger.Error().Err(err).Msg("get historical exchange rate")
+			return nil, fmt.Errorf("get historical exchange rate: %w", err)
+		}
+
+		return exchangeRate, nil
+	}
+
+	exchangeRate, err := c.apis.CurrencyExchanger.GetExchangeRate(opts.BaseCurrency, opts.TargetCurrency)
+	if err != nil {
+		logger.Warn().Err(err).Msg("get exchange rate through currency exchanger, fall back to the latest stored rate")
+
+		// Conversions keep working with the latest known rate while the currency exchanger is unavailable,
+		// the rate is marked as outdated, so callers could warn about it.
+		storedExchangeRate, storedErr := c.getStoredExchangeRate(ctx, opts.BaseCurrency, opts.TargetCurrency, today)
+		if storedErr != nil {
+			logger.Error().Err(storedErr).Msg("get stored exchange rate")
+		}
+		if storedExchangeRate != nil {
+			storedExchangeRate.Outdated = storedExchangeRate.Date.Before(today)
+			return storedExchangeRate, nil
+		}
+
+		if errs.IsExpected(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get exchange rate through currency exchanger: %w", err)
+	}
+
+	err = c.storages.ExchangeRate.Upsert(ctx, model.ExchangeRate{
+		ID:             uuid.NewString(),
+		BaseCurrency:   opts.BaseCurrency,
+		TargetCurrency: opts.TargetCurrency,
+		Date:           today,
+		Rate:           exchangeRate.String(),
+	})
+	if err != nil {
+		// The rate is still valid, it just won't be available for historical lookups.
+		logger.Error().Err(err).Msg("upsert exchange rate in store")
+	}
+
+	c.exchangeRatesCache.set(opts.BaseCurrency, opts.TargetCurrency, today, *exchangeRate)
+
+	return &GetExchangeRateOutput{
+		Rate: *exchangeRate,
+		Date: today,
+	}, nil
+}
+
+// historicalExchangeRateMaxAge limits how old the stored rate could be to be used for the past date,
+// rates are not published on weekends and holidays, so the rate of the previous working day is used then.
+const historicalExchangeRateMaxAge = 7 * 24 * time.Hour
+
+// getHistoricalExchangeRate returns the exchange rate for the past date. The stored rate of that day is preferred,
+// otherwise the rate is requested from the currency exchanger and stored. When the currency exchanger doesn't have it,
+// the latest stored rate that is not older than historicalExchangeRateMaxAge is used and marked as outdated.
+// The current rate is never used instead, since it would be a wrong historical rate.
+func (c *currencyService) getHistoricalExchangeRate(ctx context.Context, baseCurrency, targetCurrency string, date time.Time) (*GetExchangeRateOutput, error) {
+	logger := c.logger.With().Str("name", "currencyService.getHistoricalExchangeRate").Logger()
+
+	storedExchangeRate, err := c.getStoredExchangeRate(ctx, baseCurrency, targetCurrency, date)
+	if err != nil {
+		return nil, fmt.Errorf("get stored exchange rate: %w", err)
+	}
+	if storedExchangeRate != nil && storedExchangeRate.Date.Equal(date) {
+		c.exchangeRatesCache.set(baseCurrency, targetCurrency, date, storedExchangeRate.Rate)
+		return storedExchangeRate, nil
+	}
+
+	exchangeRate, err := c.apis.CurrencyExchanger.GetHistoricalExchangeRate(baseCurrency, targetCurrency, date)
+	if err == nil {
+		err = c.storages.ExchangeRate.Upsert(ctx, model.ExchangeRate{
+			ID:             uuid.NewString(),
+			BaseCurrency:   baseCurrency,
+			TargetCurrency: targetCurrency,
+			Date:           date,
+			Rate:           exchangeRate.String(),
+		})
+		if err != nil {
+			// The rate is still valid, it just will be requested again next time.
+			logger.Error().Err(err).Msg("upsert exchange rate in store")
+		}
+
+		c.exchangeRatesCache.set(baseCurrency, targetCurrency, date, *exchangeRate)
+		return &GetExchangeRateOutput{
+			Rate: *exchangeRate,
+			Date: date,
+		}, nil
+	}
+	logger.Warn().Err(err).Any("date", date).Msg("get historical exchange rate through currency exchanger")
+
+	if storedExchangeRate == nil || date.Sub(storedExchangeRate.Date) > historicalExchangeRateMaxAge {
+		return nil, ErrHistoricalExchangeRateNotFound
+	}
+
+	// Rate is cached under its own date, so it's not returned for other dates without the age check.
+	c.exchangeRatesCache.set(baseCurrency, targetCurrency, storedExchangeRate.Date, storedExchangeRate.Rate)
+	storedExchangeRate.Outdated = true
+
+	return storedExchangeRate, nil
+}
+
+// getStoredExchangeRate returns the latest stored exchange rate that was actual on the provided date.
+func (c *currencyService) getStoredExchangeRate(ctx context.Context, baseCurrency, targetCurrency string, date time.Time) (*GetExchangeRateOutput, error) {
+	storedExchangeRate, err := c.storages.ExchangeRate.Get(ctx, GetExchangeRateFilter{
+		BaseCurrency:   baseCurrency,
+		TargetCurrency: targetCurrency,
+		DateTo:         date,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get exchange rate from store: %w", err)
+	}
+	if storedExchangeRate == nil {
+		return nil, nil
+	}
+
+	exchangeRate, err := money.NewFromString(storedExchangeRate.Rate)
+	if err != nil {
+		return nil, fmt.Errorf("parse stored exchange rate: %w", err)
+	}
+
+	return &GetExchangeRateOutput{
+		Rate: exchangeRate,
+		Date: storedExchangeRate.Date,
+	}, nil
+}
+
+// getPrivateCurrencyExchangeRate returns the exchange rate when one of the currencies is a private currency of the user.
+// Private currencies are converted only with their manual rates to the requested currency.
+// Returns false when both currencies are public ones.
+func (c *currencyService) getPrivateCurrencyExchangeRate(ctx context.Context, opts GetExchangeRateOptions) (*GetExchangeRateOutput, bool, error) {
+	baseCurrency, err := c.storages.Currency.Get(ctx, GetCurrencyFilter{
+		Code:   opts.BaseCurrency,
+		UserID: opts.UserID,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("get base currency from store: %w", err)
+	}
+	if baseCurrency != nil && baseCurrency.IsPrivate() {
+		exchangeRate, err := c.getExchangeRateFromPrivateCurrency(ctx, *baseCurrency, opts)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return exchangeRate, true, nil
+	}
+
+	targetCurrency, err := c.storages.Currency.Get(ctx, GetCurrencyFilter{
+		Code:   opts.TargetCurrency,
+		UserID: opts.UserID,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("get target currency from store: %w", err)
+	}
+	if targetCurrency == nil || !targetCurrency.IsPrivate() {
+		return nil, false, nil
+	}
+
+	// Rate to the private currency is the inverse of the rate from it.
+	reverseExchangeRate, err := c.getExchangeRateFromPrivateCurrency(ctx, *targetCurrency, GetExchangeRateOptions{
+		BaseCurrency:   opts.TargetCurrency,
+		TargetCurrency: opts.BaseCurrency,
+		Date:           opts.Date,
+		UserID:         opts.UserID,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if reverseExchangeRate.Rate.Equal(money.Zero) {
+		return nil, false, ErrCurrencyExchangeRateNotFound
+	}
+
+	exchangeRate := money.NewFromInt(1)
+	exchangeRate.Div(reverseExchangeRate.Rate)
+	reverseExchangeRate.Rate = exchangeRate
+
+	return reverseExchangeRate, true, nil
+}
+
+// getExchangeRateFromPrivateCurrency returns the exchange rate from the private currency to the target one.
+func (c *currencyService) getExchangeRateFromPrivateCurrency(ctx context.Context, currency model.Currency, opts GetExchangeRateOptions) (*GetExchangeRateOutput, error) {
+	rates, err := c.storages.Currency.ListRates(ctx, ListCurrencyRatesFilter{
+		CurrencyIDs: []string{currency.ID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list currency rates from store: %w", err)
+	}
+
+	date := model.GetExchangeRateDate(time.Now())
+	if !opts.Date.IsZero() {
+		date = model.GetExchangeRateDate(opts.Date)
+	}
+
+	rate, found := model.GetCurrencyRateForDate(rates, opts.TargetCurrency, date)
+	if !found {
+		return nil, ErrCurrencyExchangeRateNotFound
+	}
+
+	exchangeRate, err := money.NewFromString(rate.Rate)
+	if err != nil {
+		return nil, fmt.Errorf("parse currency rate: %w", err)
+	}
+
+	return &GetExchangeRateOutput{
+		Rate: exchangeRate,
+		Date: rate.EffectiveDate,
+	}, nil
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/config"
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/VladPetriv/finance_bot/pkg/logger"
+	"github.com/VladPetriv/finance_bot/pkg/money"
+	"github.com/VladPetriv/finance_bot/pkg/worker"
+)
+
+type digestService struct {
+	logger *logger.Logger
+	stores Stores
+	apis   APIs
+
+	sendDigestsInterval time.Duration
+}
+
+// NewDigest returns new instance of digest service.
+func NewDigest(config *config.Config, logger *logger.Logger, stores Stores, apis APIs) *digestService {
+	return &digestService{
+		logger:              logger,
+		stores:              stores,
+		apis:                apis,
+		sendDigestsInterval: config.App.SendDigestsInterval,
+	}
+}
+
+func (d *digestService) SendDigests(ctx context.Context) {
+	logger := d.logger.With().Str("name", "digestService.SendDigests").Logger()
+
+	ticker := time.NewTicker(d.sendDigestsInterval)
+	defer ticker.Stop()
+
+	pool := worker.NewPool(5, d.sendDigest)
+	pool.Start(ctx)
+	defer pool.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info().Msg("finished sending digests")
+			return
+		case <-ticker.C:
+			users, err := d.stores.User.List(ctx, ListUsersFilter{
+				WithEnabledDigest: true,
+			})
+			if err != nil {
+				logger.Error().Err(err).Msg("list users with enabled digest")
+				continue
+			}
+			logger.Debug().Any("users", users).Msg("got users with enabled digest")
+
+			for _, user := range users {
+				pool.AddJob(user.ID, user)
+			}
+		}
+	}
+}
+
+func (d *digestService) sendDigest(ctx context.Context, id string, user model.User) error {
+	logger := d.logger.With().Str("name", "digestService.sendDigest").Logger()
+	logger.Debug().Any("user", user).Msg("got args")
+
+	userWithDetails, err := d.stores.User.Get(ctx, GetUserFilter{
+		Username:        user.Username,
+		PreloadBalances: true,
+		PreloadSettings: true,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get user from store")
+		return fmt.Errorf("get user from store: %w", err)
+	}
+	if userWithDetails == nil || userWithDetails.Settings == nil {
+		logger.Warn().Msg("user during sending digest not found")
+		return ErrUserNotFound
+	}
+
+	now := time.Now()
+	period, ok := userWithDetails.Settings.GetDueDigestPeriod(now)
+	if !ok {
+		logger.Debug().Msg("digest is not due yet")
+		return nil
+	}
+
+	digest, err := d.buildDigest(ctx, userWithDetails, period, now)
+	if err != nil {
+		logger.Error().Err(err).Msg("build digest")
+		return fmt.Errorf("build digest: %w", err)
+	}
+
+	message, err := digest.BuildMessage()
+	if err != nil {
+		logger.Error().Err(err).Msg("build digest message")
+		return fmt.Errorf("build digest message: %w", err)
+	}
+
+	err = d.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:                  userWithDetails.ChatID,
+		Message:                 message,
+		FormatMessageInMarkDown: true,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("send digest to user")
+		return fmt.Errorf("send digest to user: %w", err)
+	}
+
+	settings := userWithDetails.Settings
+	settings.LastDigestSentAt = &now
+
+	err = d.stores.User.UpdateSettings(ctx, settings)
+	if err != nil {
+		logger.Error().Err(err).Msg("update user settings in store")
+		return fmt.Errorf("update user settings in store: %w", err)
+	}
+
+	return nil
+}
+
+// buildDigest collects operations of all user balances for the period and subscription charges expected until the next digest.
+func (d *digestService) buildDigest(ctx context.Context, user *model.User, period model.Period, now time.Time) (*model.Digest, error) {
+	categories, err := d.stores.Category.List(ctx, &ListCategoriesFilter{
+		UserID: user.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list categories from store: %w", err)
+	}
+
+	digest := model.Digest{
+		Period:                      period,
+		Frequency:                   user.Settings.DigestFrequency,
+		Categories:                  categories,
+		UpcomingChargesPeriodInDays: user.Settings.DigestFrequency.GetUpcomingChargesPeriodInDays(),
+	}
+
+	for _, userBalance := range user.Balances {
+		balance, err := d.stores.Balance.Get(ctx, GetBalanceFilter{
+			BalanceID:       userBalance.ID,
+			PreloadCurrency: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("get balance from store: %w", err)
+		}
+		if balance == nil {
+			continue
+		}
+
+		operations, err := d.stores.Operation.List(ctx, ListOperationsFilter{
+			BalanceID:     balance.ID,
+			CreatedAtFrom: period.From,
+			CreatedAtTo:   period.To,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list operations from store: %w", err)
+		}
+
+		digest.Balances = append(digest.Balances, model.DigestBalance{
+			Balance:    *balance,
+			Operations: operations,
+		})
+
+		upcomingCharges, err := d.getUpcomingCharges(ctx, *balance, now, digest.UpcomingChargesPeriodInDays)
+		if err != nil {
+			return nil, fmt.Errorf("get upcoming charges: %w", err)
+		}
+
+		digest.UpcomingCharges = append(digest.UpcomingCharges, upcomingCharges...)
+	}
+
+	slices.SortFunc(digest.UpcomingCharges, func(a, b model.DigestUpcomingCharge) int {
+		return a.Date.Compare(b.Date)
+	})
+
+	return &digest, nil
+}
+
+// getUpcomingCharges returns charges of the balance subscriptions which are scheduled for the provided number of days ahead.
+// Balance is expected to have preloaded currency.
+func (d *digestService) getUpcomingCharges(ctx context.Context, balance model.Balance, now time.Time, periodInDays int) ([]model.DigestUpcomingCharge, error) {
+	balanceSubscriptions, err := d.stores.BalanceSubscription.List(ctx, ListBalanceSubscriptionFilter{
+		BalanceID: balance.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list balance subscriptions from store: %w", err)
+	}
+	if len(balanceSubscriptions) == 0 {
+		return nil, nil
+	}
+
+	scheduledOperations, err := d.stores.BalanceSubscription.ListScheduledOperation(ctx, ListScheduledOperation{
+		BetweenFilter: &BetweenFilter{
+			From: now,
+			To:   now.AddDate(0, 0, periodInDays),
+		},
+		BalanceSubscriptionIDs: extractIDs(balanceSubscriptions, func(bs model.BalanceSubscription) string {
+			return bs.ID
+		}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list scheduled operations from store: %w", err)
+	}
+
+	upcomingCharges := make([]model.DigestUpcomingCharge, 0, len(scheduledOperations))
+	for _, scheduledOperation := range scheduledOperations {
+		index := slices.IndexFunc(balanceSubscriptions, func(bs model.BalanceSubscription) bool {
+			return bs.ID == scheduledOperation.SubscriptionID
+		})
+		if index == -1 {
+			continue
+		}
+
+		balanceSubscription := balanceSubscriptions[index]
+		if balanceSubscription.IsInTrial(scheduledOperation.CreationDate) {
+			continue
+		}
+
+		amount, err := money.NewFromString(balanceSubscription.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("parse balance subscription amount: %w", err)
+		}
+
+		currency, err := d.getBalanceSubscriptionCurrency(ctx, balanceSubscription, balance)
+		if err != nil {
+			return nil, fmt.Errorf("get balance subscription currency: %w", err)
+		}
+
+		upcomingCharges = append(upcomingCharges, model.DigestUpcomingCharge{
+			UpcomingSubscriptionCharge: model.UpcomingSubscriptionCharge{
+				Name:   balanceSubscription.Name,
+				Amount: amount,
+				Date:   scheduledOperation.CreationDate,
+			},
+			Currency: currency,
+		})
+	}
+
+	return upcomingCharges, nil
+}
+
+// getBalanceSubscriptionCurrency returns the currency in which subscription is charged, it's the balance currency unless subscription overrides it.
+// Balance is expected to have preloaded currency.
+func (d *digestService) getBalanceSubscriptionCurrency(ctx context.Context, subscription model.BalanceSubscription, balance model.Balance) (model.Currency, error) {
+	if subscription.CurrencyID == "" || subscription.CurrencyID == balance.CurrencyID {
+		return balance.GetCurrency(), nil
+	}
+
+	currency, err := d.stores.Currency.Get(ctx, GetCurrencyFilter{
+		ID: subscription.CurrencyID,
+	})
+	if err != nil {
+		return model.Currency{}, fmt.Errorf("get currency from store: %w", err)
+	}
+	if currency == nil {
+		return model.Currency{}, ErrCurrencyNotFound
+	}
+
+	return *currency, nil
+}
@@ -118,6 +118,17 @@ func (e eventService) handlePanic(ctx context.Context, msg Message, r any) {
 }
 
 func getEventFromMsg(user *model.User, msg Message) model.Event {
+	switch {
+	case strings.HasPrefix(msg.GetText(), model.SkipScheduledOperationCallbackPrefix):
+		return model.SkipScheduledOperationEvent
+	case strings.HasPrefix(msg.GetText(), model.SnoozeScheduledOperationCallbackPrefix):
+		return model.SnoozeScheduledOperationEvent
+	case strings.HasPrefix(msg.GetText(), model.CancelBalanceSubscriptionCallbackPrefix):
+		return model.CancelBalanceSubscriptionEvent
+	case strings.HasPrefix(msg.GetText(), model.BalanceStatisticsChartCallbackPrefix):
+		return model.BalanceStatisticsChartEvent
+	}
+
 	aiParserEnabled := user != nil && user.Settings != nil && user.Settings.AIParserEnabled
 	inputIsNotACommand := !strings.Contains(strings.Join(model.AvailableCommands, " "), msg.GetText())
 
@@ -190,7 +201,12 @@ func (e eventService) ReactOnEvent(ctx context.Context, event model.Event, msg M
 		model.UpdateBalanceEvent, model.DeleteBalanceEvent, model.CreateCategoryEvent, model.ListCategoriesEvent,
 		model.UpdateCategoryEvent, model.DeleteCategoryEvent, model.CreateOperationEvent, model.GetOperationsHistoryEvent,
 		model.DeleteOperationEvent, model.UpdateOperationEvent, model.CreateBalanceSubscriptionEvent, model.ListBalanceSubscriptionEvent,
-		model.UpdateBalanceSubscriptionEvent, model.DeleteBalanceSubscriptionEvent, model.CreateOperationsThroughOneTimeInputEvent:
+		model.UpdateBalanceSubscriptionEvent, model.DeleteBalanceSubscriptionEvent, model.CreateOperationsThroughOneTimeInputEvent,
+		model.DetectRecurringPaymentsEvent, model.GetBalanceSubscriptionsSummaryEvent, model.ExportBalanceSubscriptionsCalendarEvent,
+		model.CreateCurrencyEvent, model.ListCurrenciesEvent, model.SetCurrencyRateEvent, model.GetNetWorthEvent,
+		model.CompareBalancePeriodsEvent,
+		model.MergeCategoriesEvent, model.CreateCategoryRuleEvent, model.ListCategoryRulesEvent, model.DeleteCategoryRuleEvent,
+		model.ApplyCategoryTemplateEvent, model.OnboardingEvent:
 		err := e.services.Handler.HandleAction(ctx, msg)
 		if err != nil {
 			if errs.IsExpected(err) {
@@ -201,6 +217,39 @@ func (e eventService) ReactOnEvent(ctx context.Context, event model.Event, msg M
 			return fmt.Errorf("handle action: %w", err)
 		}
 
+	case model.SkipScheduledOperationEvent, model.SnoozeScheduledOperationEvent:
+		err := e.services.Handler.HandleScheduledOperationAction(ctx, event, msg)
+		if err != nil {
+			if errs.IsExpected(err) {
+				logger.Info().Err(err).Msg(err.Error())
+				return err
+			}
+			logger.Error().Err(err).Msg("handle scheduled operation action")
+			return fmt.Errorf("handle scheduled operation action: %w", err)
+		}
+
+	case model.CancelBalanceSubscriptionEvent:
+		err := e.services.Handler.HandleBalanceSubscriptionCancelAction(ctx, msg)
+		if err != nil {
+			if errs.IsExpected(err) {
+				logger.Info().Err(err).Msg(err.Error())
+				return err
+			}
+			logger.Error().Err(err).Msg("handle balance subscription cancel action")
+			return fmt.Errorf("handle balance subscription cancel action: %w", err)
+		}
+
+	case model.BalanceStatisticsChartEvent:
+		err := e.services.Handler.HandleBalanceStatisticsChartAction(ctx, msg)
+		if err != nil {
+			if errs.IsExpected(err) {
+				logger.Info().Err(err).Msg(err.Error())
+				return err
+			}
+			logger.Error().Err(err).Msg("handle balance statistics chart action")
+			return fmt.Errorf("handle balance statistics chart action: %w", err)
+		}
+
 	default:
 		logger.Error().Any("event", event).Msg("receive unexpected event")
 		return fmt.Errorf("receive unexpected event: %v", event)
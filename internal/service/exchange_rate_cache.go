@@ -0,0 +1,67 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/pkg/money"
+)
+
+// exchangeRatesCache keeps recently received exchange rates in memory,
+// so conversions don't request the currency exchanger every time.
+type exchangeRatesCache struct {
+	mu    sync.RWMutex
+	ttl   time.Duration
+	now   func() time.Time
+	rates map[exchangeRatesCacheKey]cachedExchangeRate
+}
+
+type exchangeRatesCacheKey struct {
+	baseCurrency   string
+	targetCurrency string
+	date           time.Time
+}
+
+type cachedExchangeRate struct {
+	rate      money.Money
+	expiresAt time.Time
+}
+
+func newExchangeRatesCache(ttl time.Duration) *exchangeRatesCache {
+	return &exchangeRatesCache{
+		ttl:   ttl,
+		now:   time.Now,
+		rates: make(map[exchangeRatesCacheKey]cachedExchangeRate),
+	}
+}
+
+// get returns the cached exchange rate, expired rates are treated as missing.
+func (e *exchangeRatesCache) get(baseCurrency, targetCurrency string, date time.Time) (money.Money, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	cachedRate, ok := e.rates[exchangeRatesCacheKey{baseCurrency: baseCurrency, targetCurrency: targetCurrency, date: date}]
+	if !ok || !e.now().Before(cachedRate.expiresAt) {
+		return money.Zero, false
+	}
+
+	return cachedRate.rate, true
+}
+
+// set caches the exchange rate and removes already expired ones, so the cache doesn't grow infinitely.
+func (e *exchangeRatesCache) set(baseCurrency, targetCurrency string, date time.Time, rate money.Money) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := e.now()
+	for key, cachedRate := range e.rates {
+		if !now.Before(cachedRate.expiresAt) {
+			delete(e.rates, key)
+		}
+	}
+
+	e.rates[exchangeRatesCacheKey{baseCurrency: baseCurrency, targetCurrency: targetCurrency, date: date}] = cachedExchangeRate{
+		rate:      rate,
+		expiresAt: now.Add(e.ttl),
+	}
+}
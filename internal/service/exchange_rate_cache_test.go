@@ -0,0 +1,38 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/pkg/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_exchangeRatesCache(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	date := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	cache := newExchangeRatesCache(time.Hour)
+	cache.now = func() time.Time { return now }
+
+	_, ok := cache.get("USD", "EUR", date)
+	assert.False(t, ok)
+
+	cache.set("USD", "EUR", date, money.NewFromFloat(0.92))
+
+	rate, ok := cache.get("USD", "EUR", date)
+	assert.True(t, ok)
+	assert.Equal(t, "0.92", rate.StringFixed())
+
+	_, ok = cache.get("EUR", "USD", date)
+	assert.False(t, ok, "rate is cached only for the requested direction")
+
+	_, ok = cache.get("USD", "EUR", date.AddDate(0, 0, -1))
+	assert.False(t, ok, "rate is cached only for the requested date")
+
+	now = now.Add(time.Hour)
+	_, ok = cache.get("USD", "EUR", date)
+	assert.False(t, ok, "rate is expired after ttl")
+}
@@ -48,15 +48,20 @@ func (h *handlerService) RegisterHandlers() {
 			model.GetUserSettingsFlowStep: h.handleGetUserSettingsFlowStep,
 		},
 		model.UpdateUserSettingsFlow: {
-			model.UpdateUserSettingsFlowStep:                        h.handleUpdateUserSettingsFlowStep,
-			model.ChooseUpdateUserSettingsOptionFlowStep:            h.handleChooseUpdateUserSettingsOptionFlowStep,
-			model.UpdateAIParserEnabledUserSettingFlowStep:          h.handleUpdateAIParserEnabledUserSettingFlowStep,
-			model.UpdateSubscriptionNotificationUserSettingFlowStep: h.handleUpdateSubscriptionNotificationUserSettingFlowStep,
+			model.UpdateUserSettingsFlowStep:                                h.handleUpdateUserSettingsFlowStep,
+			model.ChooseUpdateUserSettingsOptionFlowStep:                    h.handleChooseUpdateUserSettingsOptionFlowStep,
+			model.UpdateAIParserEnabledUserSettingFlowStep:                  h.handleUpdateAIParserEnabledUserSettingFlowStep,
+			model.UpdateSubscriptionNotificationUserSettingFlowStep:         h.handleUpdateSubscriptionNotificationUserSettingFlowStep,
+			model.UpdateSubscriptionNotificationLeadTimeUserSettingFlowStep: h.handleUpdateSubscriptionNotificationLeadTimeUserSettingFlowStep,
+			model.UpdateBaseCurrencyUserSettingFlowStep:                     h.handleUpdateBaseCurrencyUserSettingFlowStep,
+			model.UpdateDigestFrequencyUserSettingFlowStep:                  h.handleUpdateDigestFrequencyUserSettingFlowStep,
+			model.UpdateDigestSendTimeUserSettingFlowStep:                   h.handleUpdateDigestSendTimeUserSettingFlowStep,
 		},
 
 		// Flows with balances
 		model.StartFlow: {
-			model.CreateInitialBalanceFlowStep: h.handleCreateInitialBalanceFlowStep,
+			model.ChooseCategoryTemplateFlowStep: h.handleChooseCategoryTemplateFlowStepForStart,
+			model.CreateInitialBalanceFlowStep:   h.handleCreateInitialBalanceFlowStep,
 			// NOTE: We're using -ForUpdate methods, since the balance after first step is already created in the store.
 			model.EnterBalanceAmountFlowStep:   h.handleEnterBalanceAmountFlowStepForUpdate,
 			model.EnterBalanceCurrencyFlowStep: h.handleEnterBalanceCurrencyFlowStepForUpdate,
@@ -68,9 +73,11 @@ func (h *handlerService) RegisterHandlers() {
 			model.EnterBalanceCurrencyFlowStep: h.handleEnterBalanceCurrencyFlowStepForCreate,
 		},
 		model.GetBalanceFlow: {
-			model.GetBalanceFlowStep:                   h.handleGetBalanceFlowStep,
-			model.ChooseMonthBalanceStatisticsFlowStep: h.handleChooseMonthBalanceStatisticsFlowStep,
-			model.ChooseBalanceFlowStep:                h.handleChooseBalanceFlowStepForGetBalance,
+			model.GetBalanceFlowStep:       h.handleGetBalanceFlowStep,
+			model.ChoosePeriodTypeFlowStep: h.handleChoosePeriodTypeFlowStep,
+			model.ChoosePeriodYearFlowStep: h.handleChoosePeriodYearFlowStep,
+			model.ChoosePeriodFlowStep:     h.handleChoosePeriodFlowStepForGetBalance,
+			model.ChooseBalanceFlowStep:    h.handleChooseBalanceFlowStepForGetBalance,
 		},
 		model.UpdateBalanceFlow: {
 			model.UpdateBalanceFlowStep:             h.handleUpdateBalanceFlowStep,
@@ -85,23 +92,65 @@ func (h *handlerService) RegisterHandlers() {
 			model.ConfirmBalanceDeletionFlowStep: h.handleConfirmBalanceDeletionFlowStep,
 			model.ChooseBalanceFlowStep:          h.handleChooseBalanceFlowStepForDelete,
 		},
+		model.GetNetWorthFlow: {
+			model.GetNetWorthFlowStep: h.handleGetNetWorthFlowStep,
+		},
+		model.CompareBalancePeriodsFlow: {
+			model.CompareBalancePeriodsFlowStep: h.handleCompareBalancePeriodsFlowStep,
+			model.ChooseBalanceFlowStep:         h.handleChooseBalanceFlowStepForCompareBalancePeriods,
+			model.ChoosePeriodTypeFlowStep:      h.handleChoosePeriodTypeFlowStepForCompareBalancePeriods,
+			model.ChoosePeriodYearFlowStep:      h.handleChoosePeriodYearFlowStep,
+			model.ChoosePeriodFlowStep:          h.handleChoosePeriodFlowStepForCompareBalancePeriods,
+		},
 
 		// Flows with categories
 		model.CreateCategoryFlow: {
-			model.CreateCategoryFlowStep:    h.handleCreateCategoryFlowStep,
-			model.EnterCategoryNameFlowStep: h.handleEnterCategoryNameFlowStep,
+			model.CreateCategoryFlowStep:       h.handleCreateCategoryFlowStep,
+			model.EnterCategoryNameFlowStep:    h.handleEnterCategoryNameFlowStep,
+			model.ChooseCategoryKindFlowStep:   h.handleChooseCategoryKindFlowStep,
+			model.ChooseParentCategoryFlowStep: h.handleChooseParentCategoryFlowStep,
 		},
 		model.ListCategoriesFlow: {
-			model.ListCategoriesFlowStep: h.handleListCategoriesFlowStep,
+			model.ListCategoriesFlowStep:             h.handleListCategoriesFlowStep,
+			model.ChooseCategoriesListOptionFlowStep: h.handleChooseCategoriesListOptionFlowStep,
+			model.ChooseCategoryFlowStep:             h.handleChooseCategoryFlowStepForReorder,
+			model.EnterCategoryPositionFlowStep:      h.handleEnterCategoryPositionFlowStep,
+			model.ChooseArchivedCategoryFlowStep:     h.handleChooseArchivedCategoryFlowStep,
 		},
 		model.UpdateCategoryFlow: {
-			model.UpdateCategoryFlowStep:           h.handleUpdateCategoryFlowStep,
-			model.ChooseCategoryFlowStep:           h.handleChooseCategoryFlowStepForUpdate,
-			model.EnterUpdatedCategoryNameFlowStep: h.handleEnterUpdatedCategoryNameFlowStep,
+			model.UpdateCategoryFlowStep:             h.handleUpdateCategoryFlowStep,
+			model.ChooseCategoryFlowStep:             h.handleChooseCategoryFlowStepForUpdate,
+			model.ChooseUpdateCategoryOptionFlowStep: h.handleChooseUpdateCategoryOptionFlowStep,
+			model.ChooseCategoryKindFlowStep:         h.handleChooseCategoryKindFlowStepForUpdate,
+			model.EnterUpdatedCategoryNameFlowStep:   h.handleEnterUpdatedCategoryNameFlowStep,
 		},
 		model.DeleteCategoryFlow: {
-			model.DeleteCategoryFlowStep: h.handleDeleteCategoryFlowStep,
-			model.ChooseCategoryFlowStep: h.handleChooseCategoryFlowStepForDelete,
+			model.DeleteCategoryFlowStep:            h.handleDeleteCategoryFlowStep,
+			model.ChooseCategoryFlowStep:            h.handleChooseCategoryFlowStepForDelete,
+			model.ChooseReplacementCategoryFlowStep: h.handleChooseReplacementCategoryFlowStep,
+		},
+		model.MergeCategoriesFlow: {
+			model.MergeCategoriesFlowStep:      h.handleMergeCategoriesFlowStep,
+			model.ChooseCategoryFlowStep:       h.handleChooseCategoryFlowStepForMerge,
+			model.ChooseTargetCategoryFlowStep: h.handleChooseTargetCategoryFlowStep,
+		},
+		model.CreateCategoryRuleFlow: {
+			model.CreateCategoryRuleFlowStep:           h.handleCreateCategoryRuleFlowStep,
+			model.EnterCategoryRulePatternFlowStep:     h.handleEnterCategoryRulePatternFlowStep,
+			model.ChooseCategoryFlowStep:               h.handleChooseCategoryFlowStepForCreateCategoryRule,
+			model.EnterCategoryRuleAmountRangeFlowStep: h.handleEnterCategoryRuleAmountRangeFlowStep,
+			model.ChooseBalanceFlowStep:                h.handleChooseBalanceFlowStepForCreateCategoryRule,
+		},
+		model.ListCategoryRulesFlow: {
+			model.ListCategoryRulesFlowStep: h.handleListCategoryRulesFlowStep,
+		},
+		model.DeleteCategoryRuleFlow: {
+			model.DeleteCategoryRuleFlowStep: h.handleDeleteCategoryRuleFlowStep,
+			model.ChooseCategoryRuleFlowStep: h.handleChooseCategoryRuleFlowStepForDelete,
+		},
+		model.ApplyCategoryTemplateFlow: {
+			model.ApplyCategoryTemplateFlowStep:  h.handleApplyCategoryTemplateFlowStep,
+			model.ChooseCategoryTemplateFlowStep: h.handleChooseCategoryTemplateFlowStep,
 		},
 
 		// Flows with operations
@@ -119,6 +168,9 @@ func (h *handlerService) RegisterHandlers() {
 		model.GetOperationsHistoryFlow: {
 			model.GetOperationsHistoryFlowStep:                 h.handleGetOperationsHistoryFlowStep,
 			model.ChooseBalanceFlowStep:                        h.handleChooseBalanceFlowStepForGetOperationsHistory,
+			model.ChoosePeriodTypeFlowStep:                     h.handleChoosePeriodTypeFlowStep,
+			model.ChoosePeriodYearFlowStep:                     h.handleChoosePeriodYearFlowStep,
+			model.ChoosePeriodFlowStep:                         h.handleChoosePeriodFlowStepForGetOperationsHistory,
 			model.ChooseTimePeriodForOperationsHistoryFlowStep: h.handleChooseTimePeriodForOperationsHistoryFlowStep,
 		},
 		model.UpdateOperationFlow: {
@@ -145,27 +197,33 @@ func (h *handlerService) RegisterHandlers() {
 
 		// Flows with balance subscriptions
 		model.CreateBalanceSubscriptionFlow: {
-			model.CreateBalanceSubscriptionFlowStep:              h.handleCreateBalanceSubscriptionFlowStep,
-			model.ChooseBalanceFlowStep:                          h.handleChooseBalanceFlowStepForCreateBalanceSubscription,
-			model.ChooseCategoryFlowStep:                         h.handleChooseCategoryFlowStepForCreateBalanceSubscription,
-			model.EnterBalanceSubscriptionNameFlowStep:           h.handleEnterBalanceSubscriptionNameFlowStep,
-			model.EnterBalanceSubscriptionAmountFlowStep:         h.handleEnterBalanceSubscriptionAmountFlowStep,
-			model.ChooseBalanceSubscriptionFrequencyFlowStep:     h.handleChooseBalanceSubscriptionFrequencyFlowStep,
-			model.EnterStartAtDateForBalanceSubscriptionFlowStep: h.handleEnterStartAtDateForBalanceSubscriptionFlowStep,
+			model.CreateBalanceSubscriptionFlowStep:                h.handleCreateBalanceSubscriptionFlowStep,
+			model.ChooseBalanceFlowStep:                            h.handleChooseBalanceFlowStepForCreateBalanceSubscription,
+			model.ChooseCategoryFlowStep:                           h.handleChooseCategoryFlowStepForCreateBalanceSubscription,
+			model.EnterBalanceSubscriptionNameFlowStep:             h.handleEnterBalanceSubscriptionNameFlowStep,
+			model.EnterBalanceSubscriptionAmountFlowStep:           h.handleEnterBalanceSubscriptionAmountFlowStep,
+			model.ChooseBalanceSubscriptionCurrencyFlowStep:        h.handleChooseBalanceSubscriptionCurrencyFlowStepForCreate,
+			model.ChooseBalanceSubscriptionFrequencyFlowStep:       h.handleChooseBalanceSubscriptionFrequencyFlowStep,
+			model.EnterStartAtDateForBalanceSubscriptionFlowStep:   h.handleEnterStartAtDateForBalanceSubscriptionFlowStep,
+			model.ConfirmBalanceSubscriptionTrialFlowStep:          h.handleConfirmBalanceSubscriptionTrialFlowStep,
+			model.EnterBalanceSubscriptionTrialEndDateFlowStep:     h.handleEnterBalanceSubscriptionTrialEndDateFlowStep,
+			model.EnterBalanceSubscriptionAmountAfterTrialFlowStep: h.handleEnterBalanceSubscriptionAmountAfterTrialFlowStep,
 		},
 		model.ListBalanceSubscriptionFlow: {
 			model.ListBalanceSubscriptionFlowStep: h.handleListBalanceSubscriptionFlowStep,
 			model.ChooseBalanceFlowStep:           h.handleChooseBalanceFlowStepForListBalanceSubscriptions,
 		},
 		model.UpdateBalanceSubscriptionFlow: {
-			model.UpdateBalanceSubscriptionFlowStep:             h.handleUpdateBalanceSubscriptionFlowStep,
-			model.ChooseBalanceFlowStep:                         h.handleChooseBalanceFlowStepForUpdateBalanceSubscription,
-			model.ChooseBalanceSubscriptionToUpdateFlowStep:     h.handleChooseBalanceSubscriptionToUpdateFlowStep,
-			model.ChooseUpdateBalanceSubscriptionOptionFlowStep: h.handleChooseUpdateBalanceSubscriptionOptionFlowStep,
-			model.EnterBalanceSubscriptionNameFlowStep:          h.handleEnterBalanceSubscriptionNameFlowStepForUpdate,
-			model.EnterBalanceSubscriptionAmountFlowStep:        h.handleEnterBalanceSubscriptionAmountFlowStepForUpdate,
-			model.ChooseCategoryFlowStep:                        h.handleChooseCategoryFlowStepForBalanceSubscriptionUpdate,
-			model.ChooseBalanceSubscriptionFrequencyFlowStep:    h.handleChooseBalanceSubscriptionFrequencyFlowStepForUpdate,
+			model.UpdateBalanceSubscriptionFlowStep:                     h.handleUpdateBalanceSubscriptionFlowStep,
+			model.ChooseBalanceFlowStep:                                 h.handleChooseBalanceFlowStepForUpdateBalanceSubscription,
+			model.ChooseBalanceSubscriptionToUpdateFlowStep:             h.handleChooseBalanceSubscriptionToUpdateFlowStep,
+			model.ChooseUpdateBalanceSubscriptionOptionFlowStep:         h.handleChooseUpdateBalanceSubscriptionOptionFlowStep,
+			model.EnterBalanceSubscriptionNameFlowStep:                  h.handleEnterBalanceSubscriptionNameFlowStepForUpdate,
+			model.EnterBalanceSubscriptionAmountFlowStep:                h.handleEnterBalanceSubscriptionAmountFlowStepForUpdate,
+			model.ChooseCategoryFlowStep:                                h.handleChooseCategoryFlowStepForBalanceSubscriptionUpdate,
+			model.ChooseBalanceSubscriptionFrequencyFlowStep:            h.handleChooseBalanceSubscriptionFrequencyFlowStepForUpdate,
+			model.ChooseBalanceSubscriptionNotificationLeadTimeFlowStep: h.handleChooseBalanceSubscriptionNotificationLeadTimeFlowStep,
+			model.ChooseBalanceSubscriptionCurrencyFlowStep:             h.handleChooseBalanceSubscriptionCurrencyFlowStep,
 		},
 		model.DeleteBalanceSubscriptionFlow: {
 			model.DeleteBalanceSubscriptionFlowStep:         h.handleDeleteBalanceSubscriptionFlowStep,
@@ -173,6 +231,35 @@ func (h *handlerService) RegisterHandlers() {
 			model.ChooseBalanceSubscriptionToDeleteFlowStep: h.handleChooseBalanceSubscriptionToDeleteFlowStep,
 			model.ConfirmDeleteBalanceSubscriptionFlowStep:  h.handleConfirmDeleteBalanceSubscriptionFlowStep,
 		},
+		model.DetectRecurringPaymentsFlow: {
+			model.DetectRecurringPaymentsFlowStep:                 h.handleDetectRecurringPaymentsFlowStep,
+			model.ChooseBalanceFlowStep:                           h.handleChooseBalanceFlowStepForDetectRecurringPayments,
+			model.ConfirmSubscriptionFromRecurringPaymentFlowStep: h.handleConfirmSubscriptionFromRecurringPaymentFlowStep,
+		},
+		model.GetBalanceSubscriptionsSummaryFlow: {
+			model.GetBalanceSubscriptionsSummaryFlowStep:            h.handleGetBalanceSubscriptionsSummaryFlowStep,
+			model.ChooseBalanceFlowStep:                             h.handleChooseBalanceFlowStepForBalanceSubscriptionsSummary,
+			model.ChooseBalanceSubscriptionsSummaryCurrencyFlowStep: h.handleChooseBalanceSubscriptionsSummaryCurrencyFlowStep,
+		},
+		model.ExportBalanceSubscriptionsCalendarFlow: {
+			model.ExportBalanceSubscriptionsCalendarFlowStep: h.handleExportBalanceSubscriptionsCalendarFlowStep,
+		},
+		model.CreateCurrencyFlow: {
+			model.CreateCurrencyFlowStep:             h.handleCreateCurrencyFlowStep,
+			model.EnterCurrencyCodeFlowStep:          h.handleEnterCurrencyCodeFlowStep,
+			model.EnterCurrencyNameFlowStep:          h.handleEnterCurrencyNameFlowStep,
+			model.EnterCurrencySymbolFlowStep:        h.handleEnterCurrencySymbolFlowStep,
+			model.EnterCurrencyDecimalPlacesFlowStep: h.handleEnterCurrencyDecimalPlacesFlowStep,
+		},
+		model.ListCurrenciesFlow: {
+			model.ListCurrenciesFlowStep: h.handleListCurrenciesFlowStep,
+		},
+		model.SetCurrencyRateFlow: {
+			model.SetCurrencyRateFlowStep:         h.handleSetCurrencyRateFlowStep,
+			model.ChooseCurrencyFlowStep:          h.handleChooseCurrencyFlowStepForSetRate,
+			model.EnterCurrencyRateTargetFlowStep: h.handleEnterCurrencyRateTargetFlowStep,
+			model.EnterCurrencyRateFlowStep:       h.handleEnterCurrencyRateFlowStep,
+		},
 	}
 }
 
@@ -242,29 +329,36 @@ func (h handlerService) HandleStart(ctx context.Context, msg Message) error {
 	}
 
 	err = h.stores.User.CreateSettings(ctx, &model.UserSettings{
-		ID:                              uuid.NewString(),
-		UserID:                          userID,
-		AIParserEnabled:                 false,
-		NotifyAboutSubscriptionPayments: true,
+		ID:                               uuid.NewString(),
+		UserID:                           userID,
+		AIParserEnabled:                  false,
+		NotifyAboutSubscriptionPayments:  true,
+		SubscriptionNotificationLeadTime: model.DefaultNotificationLeadTime,
+		DigestFrequency:                  model.DigestFrequencyOff,
+		DigestSendTime:                   model.DefaultDigestSendTime,
 	})
 	if err != nil {
 		logger.Error().Err(err).Msg("create user settings in store")
 		return fmt.Errorf("create user settings in store: %w", err)
 	}
 
-	welcomeMessage := fmt.Sprintf("Hello, @%s!\nWelcome to @FinanceTracking_bot!", username)
-	enterBalanceNameMessage := "Please enter the name of your initial balance!:"
+	err = h.apis.Messenger.SendMessage(chatID, fmt.Sprintf("Hello, @%s!\nWelcome to @FinanceTracking_bot!", username))
+	if err != nil {
+		logger.Error().Err(err).Msg("send message")
+		return fmt.Errorf("send message: %w", err)
+	}
 
-	messagesToSend := []string{welcomeMessage, enterBalanceNameMessage}
-	for _, message := range messagesToSend {
-		err := h.apis.Messenger.SendMessage(chatID, message)
-		if err != nil {
-			logger.Error().Err(err).Msg("send message")
-			return fmt.Errorf("send message: %w", err)
-		}
+	err = h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:         chatID,
+		Message:        model.BuildCategoryTemplatesMessage() + "\nChoose the template to create your first categories:",
+		InlineKeyboard: categoryTemplatesKeyboardWithSkipButton,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("send message with keyboard")
+		return fmt.Errorf("send message with keyboard: %w", err)
 	}
 
-	nextStep = model.CreateInitialBalanceFlowStep
+	nextStep = model.ChooseCategoryTemplateFlowStep
 	return nil
 }
 
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/VladPetriv/finance_bot/pkg/money"
 )
 
 type identifiable interface {
@@ -95,9 +96,9 @@ func (h handlerService) getOperationsKeyboard(ctx context.Context, opts getOpera
 }
 
 type getOperationsHistoryKeyboardOptions struct {
-	balance        *model.Balance
-	creationPeriod model.CreationPeriod
-	page           int
+	balance *model.Balance
+	period  model.Period
+	page    int
 }
 
 func (h handlerService) getOperationsHistoryKeyboard(ctx context.Context, opts getOperationsHistoryKeyboardOptions) (string, []InlineKeyboardRow, error) {
@@ -105,8 +106,9 @@ func (h handlerService) getOperationsHistoryKeyboard(ctx context.Context, opts g
 	logger.Debug().Any("opts", opts).Msg("got args")
 
 	operationsCount, err := h.stores.Operation.Count(ctx, ListOperationsFilter{
-		BalanceID:      opts.balance.ID,
-		CreationPeriod: opts.creationPeriod,
+		BalanceID:     opts.balance.ID,
+		CreatedAtFrom: opts.period.From,
+		CreatedAtTo:   opts.period.To,
 	})
 	if err != nil {
 		logger.Error().Err(err).Msg("count operations")
@@ -127,7 +129,8 @@ func (h handlerService) getOperationsHistoryKeyboard(ctx context.Context, opts g
 		func() (string, error) {
 			operations, err := h.stores.Operation.List(ctx, ListOperationsFilter{
 				BalanceID:            opts.balance.ID,
-				CreationPeriod:       opts.creationPeriod,
+				CreatedAtFrom:        opts.period.From,
+				CreatedAtTo:          opts.period.To,
 				OrderByCreatedAtDesc: true,
 				Pagination: &Pagination{
 					Limit: operationsPerKeyboard,
@@ -143,9 +146,11 @@ func (h handlerService) getOperationsHistoryKeyboard(ctx context.Context, opts g
 				return "", ErrOperationsNotFound
 			}
 
+			currencyFormat := opts.balance.GetCurrency().GetMoneyFormat()
+			balanceAmount, _ := money.NewFromString(opts.balance.Amount)
 			outputMessage := fmt.Sprintf(
-				"💰 *Balance:* %v%s\n📅 *Period:* %v\n\n",
-				opts.balance.Amount, opts.balance.GetCurrency().Symbol, opts.creationPeriod,
+				"💰 *Balance:* %s\n📅 *Period:* %v\n\n",
+				balanceAmount.Format(currencyFormat), opts.period.GetName(),
 			)
 
 			separator := "━━━━━━━━━━━━━━━"
@@ -163,24 +168,27 @@ func (h handlerService) getOperationsHistoryKeyboard(ctx context.Context, opts g
 				}
 
 				emoji, typeLabel := model.GetOperationTypeLabel(o.Type)
+				operationAmount, _ := money.NewFromString(o.Amount)
 				outputMessage += fmt.Sprintf(
 					"%s\n"+
 						"📌 *Operation:* %s %s\n"+
 						"📝 *Description:* %s\n"+
 						"📂 *Category:* %s\n"+
-						"💰 *Amount:* %s%s\n",
+						"💰 *Amount:* %s\n",
 					separator,
 					emoji,
 					typeLabel,
 					o.Description,
 					category.Title,
-					o.Amount,
-					opts.balance.GetCurrency().Symbol,
+					operationAmount.Format(currencyFormat),
 				)
 
 				if o.ExchangeRate != "" {
 					outputMessage += fmt.Sprintf("💱 *Exchange Rate:* %s\n", o.ExchangeRate)
 				}
+				if spread := o.GetExchangeRateSpread(); spread != "" {
+					outputMessage += fmt.Sprintf("📉 *Spread:* %s\n", spread)
+				}
 
 				outputMessage += fmt.Sprintf("🕐 *Date:* %s\n", o.CreatedAt.Format(time.ANSIC))
 
@@ -358,18 +366,30 @@ const (
 	currenciesPerKeyboardRow = 3
 )
 
-func (h handlerService) getCurrenciesKeyboard(ctx context.Context, page int) ([]InlineKeyboardRow, error) {
+// getCurrenciesKeyboard returns paginated keyboard with public currencies and private currencies of the user.
+func (h handlerService) getCurrenciesKeyboard(ctx context.Context, userID string, page int) ([]InlineKeyboardRow, error) {
 	logger := h.logger.With().Str("name", "handlerService.getCurrenciesKeyboardForBalance").Logger()
 
+	currenciesCount, err := h.stores.Currency.Count(ctx, ListCurrenciesFilter{
+		UserID:            userID,
+		ExcludeDeprecated: true,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("count currencies in store")
+		return nil, fmt.Errorf("count currencies in store: %w", err)
+	}
+
 	keyboard, err := paginateInlineKeyboard(
 		inlineKeyboardPaginatorOptions{
-			totalCount:     availableCurrenciesCount,
+			totalCount:     currenciesCount,
 			maxPerKeyboard: currenciesPerKeyboard,
 			maxPerRow:      currenciesPerKeyboardRow,
 			currentPage:    page,
 		},
 		func() ([]model.Currency, error) {
 			currencies, err := h.stores.Currency.List(ctx, ListCurrenciesFilter{
+				UserID:            userID,
+				ExcludeDeprecated: true,
 				Pagination: &Pagination{
 					Limit: currenciesPerKeyboard,
 					Page:  page,
@@ -393,3 +413,85 @@ func (h handlerService) getCurrenciesKeyboard(ctx context.Context, page int) ([]
 
 	return keyboard, nil
 }
+
+// getBalanceSubscriptionCurrenciesKeyboard returns paginated currencies keyboard with the option to charge subscription in the balance currency.
+func (h handlerService) getBalanceSubscriptionCurrenciesKeyboard(ctx context.Context, userID string, page int) ([]InlineKeyboardRow, error) {
+	currenciesKeyboard, err := h.getCurrenciesKeyboard(ctx, userID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(currenciesKeyboard, InlineKeyboardRow{
+		Buttons: []InlineKeyboardButton{
+			{
+				Text: model.BalanceSubscriptionCurrencyDefaultLabel,
+			},
+		},
+	}), nil
+}
+
+// getDigestFrequencyKeyboard returns keyboard with all available digest frequencies.
+func getDigestFrequencyKeyboard() []InlineKeyboardRow {
+	rows := make([]InlineKeyboardRow, 0, len(model.AvailableDigestFrequencies))
+	for _, frequency := range model.AvailableDigestFrequencies {
+		rows = append(rows, InlineKeyboardRow{
+			Buttons: []InlineKeyboardButton{
+				{
+					Text: frequency.String(),
+				},
+			},
+		})
+	}
+
+	return rows
+}
+
+// getDigestSendTimeKeyboard returns keyboard with all available digest send times, three per row.
+func getDigestSendTimeKeyboard() []InlineKeyboardRow {
+	buttons := make([]InlineKeyboardButton, 0, len(model.AvailableDigestSendTimes))
+	for _, sendTime := range model.AvailableDigestSendTimes {
+		buttons = append(buttons, InlineKeyboardButton{
+			Text: sendTime.String(),
+		})
+	}
+
+	rows := make([]InlineKeyboardRow, 0, len(buttons)/3+1)
+	for i := 0; i < len(buttons); i += 3 {
+		rows = append(rows, InlineKeyboardRow{
+			Buttons: buttons[i:min(i+3, len(buttons))],
+		})
+	}
+
+	return rows
+}
+
+// getNotificationLeadTimeKeyboard returns keyboard with all available notification lead times.
+// When withDefaultOption is true, the option for using the lead time from user settings is added.
+func getNotificationLeadTimeKeyboard(withDefaultOption bool) []InlineKeyboardRow {
+	buttons := make([]InlineKeyboardButton, 0, len(model.AvailableNotificationLeadTimes))
+	for _, leadTime := range model.AvailableNotificationLeadTimes {
+		buttons = append(buttons, InlineKeyboardButton{
+			Text: leadTime.String(),
+		})
+	}
+
+	rows := []InlineKeyboardRow{
+		{
+			Buttons: buttons[:2],
+		},
+		{
+			Buttons: buttons[2:],
+		},
+	}
+	if withDefaultOption {
+		rows = append(rows, InlineKeyboardRow{
+			Buttons: []InlineKeyboardButton{
+				{
+					Text: model.NotificationLeadTimeDefaultLabel,
+				},
+			},
+		})
+	}
+
+	return rows
+}
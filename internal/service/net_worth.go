@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/VladPetriv/finance_bot/pkg/money"
+)
+
+func (h *handlerService) handleGetNetWorthFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleGetNetWorthFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	baseCurrency, err := h.getUserBaseCurrency(ctx, opts.user)
+	if err != nil {
+		logger.Error().Err(err).Msg("get user base currency")
+		return "", fmt.Errorf("get user base currency: %w", err)
+	}
+	if baseCurrency == nil {
+		logger.Info().Msg("base currency not set")
+		return model.EndFlowStep, ErrBaseCurrencyNotSet
+	}
+
+	balances, err := h.listUserBalancesWithCurrency(ctx, opts.user)
+	if err != nil {
+		logger.Error().Err(err).Msg("list user balances with currency")
+		return "", fmt.Errorf("list user balances with currency: %w", err)
+	}
+	if len(balances) == 0 {
+		return model.EndFlowStep, ErrBalanceNotFound
+	}
+
+	items := make([]model.NetWorthItem, 0, len(balances))
+	for _, balance := range balances {
+		items = append(items, model.NetWorthItem{
+			Balance:         balance,
+			ConvertedAmount: h.convertBalanceAmount(ctx, balance, baseCurrency.Code, opts.user.ID),
+		})
+	}
+
+	netWorth := model.NetWorth{
+		Currency: *baseCurrency,
+		Items:    items,
+	}
+
+	return model.EndFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:                  opts.message.GetChatID(),
+		Message:                 netWorth.BuildMessage(),
+		FormatMessageInMarkDown: true,
+		Keyboard:                balanceKeyboardRows,
+	})
+}
+
+// convertBalanceAmount converts balance amount into the target currency.
+// Returns nil when the amount couldn't be converted, so the balance is marked instead of failing the whole view.
+func (h *handlerService) convertBalanceAmount(ctx context.Context, balance model.Balance, targetCurrency, userID string) *money.Money {
+	logger := h.logger.With().Str("name", "handlerService.convertBalanceAmount").Logger()
+
+	amount, err := money.NewFromString(balance.Amount)
+	if err != nil {
+		logger.Warn().Err(err).Str("balance_id", balance.ID).Msg("parse balance amount")
+		return nil
+	}
+
+	currency := balance.GetCurrency()
+	if currency.Code == targetCurrency {
+		return &amount
+	}
+
+	convertedAmount, err := h.services.Currency.Convert(ctx, ConvertCurrencyOptions{
+		BaseCurrency:   currency.Code,
+		TargetCurrency: targetCurrency,
+		Amount:         amount,
+		UserID:         userID,
+	})
+	if err != nil {
+		logger.Warn().Err(err).Str("balance_id", balance.ID).Msg("convert balance amount")
+		return nil
+	}
+
+	return convertedAmount
+}
+
+// getUserBaseCurrency returns the base currency chosen by the user or nil when it's not set.
+func (h *handlerService) getUserBaseCurrency(ctx context.Context, user *model.User) (*model.Currency, error) {
+	if user.Settings == nil || user.Settings.BaseCurrencyCode == "" {
+		return nil, nil
+	}
+
+	baseCurrency, err := h.stores.Currency.Get(ctx, GetCurrencyFilter{
+		Code:   user.Settings.BaseCurrencyCode,
+		UserID: user.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get base currency from store: %w", err)
+	}
+
+	return baseCurrency, nil
+}
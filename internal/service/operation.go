@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/VladPetriv/finance_bot/pkg/errs"
 	"github.com/VladPetriv/finance_bot/pkg/money"
 	"github.com/google/uuid"
 )
@@ -23,11 +24,24 @@ func (h *handlerService) handleCreateOperationsThroughOneTimeInputFlowStep(ctx c
 		logger.Error().Err(err).Msg("list categories from store")
 		return "", fmt.Errorf("list categories from store: %w", err)
 	}
+
+	// Archived categories are not sent to the prompter, so they are never chosen for new operations.
+	categories = model.GetActiveCategories(categories)
 	if len(categories) == 0 {
 		logger.Info().Msg("no categories found")
 		return model.EndFlowStep, ErrCategoriesNotFound
 	}
 
+	// When the user explicitly set the sign, there is no need to send categories of the other kind.
+	operationType, ok := model.GetOperationTypeFromInputSign(opts.message.GetText())
+	if ok {
+		categories = model.FilterCategoriesByOperationType(categories, operationType)
+		if len(categories) == 0 {
+			logger.Info().Msg("no categories found for operation type")
+			return model.EndFlowStep, ErrCategoriesForOperationTypeNotFound
+		}
+	}
+
 	prompt, err := model.BuildCreateOperationFromTextPrompt(opts.message.GetText(), categories)
 	if err != nil {
 		logger.Error().Err(err).Msg("build create operation from text prompt")
@@ -52,11 +66,26 @@ func (h *handlerService) handleCreateOperationsThroughOneTimeInputFlowStep(ctx c
 		return "", ErrInvalidAmountFormat
 	}
 
+	// User's rules are more precise than the prompter, so the category from the matched rule is preferred.
+	// Balance is chosen only after confirmation, so rules limited to a balance are skipped here.
+	categoryFromRule, err := h.getCategoryFromRules(ctx, opts.user.ID, operationData.Type, model.CategoryRuleInput{
+		Description: opts.message.GetText(),
+		Amount:      parsedAmount,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get category from rules")
+		return "", fmt.Errorf("get category from rules: %w", err)
+	}
+
 	var categoryTitle string
-	for _, category := range categories {
-		if category.ID == operationData.CategoryID {
-			categoryTitle = category.Title
-			break
+	if categoryFromRule != nil {
+		categoryTitle = categoryFromRule.Title
+	} else {
+		for _, category := range categories {
+			if category.ID == operationData.CategoryID && category.IsSuitableForOperationType(operationData.Type) {
+				categoryTitle = category.Title
+				break
+			}
 		}
 	}
 	if categoryTitle == "" {
@@ -65,7 +94,7 @@ func (h *handlerService) handleCreateOperationsThroughOneTimeInputFlowStep(ctx c
 
 	opts.stateMetaData.Add(model.CategoryTitleMetadataKey, categoryTitle)
 	opts.stateMetaData.Add(model.OperationTypeMetadataKey, operationData.Type)
-	opts.stateMetaData.Add(model.OperationAmountMetadataKey, parsedAmount.StringFixed())
+	opts.stateMetaData.Add(model.OperationAmountMetadataKey, parsedAmount.String())
 	opts.stateMetaData.Add(model.OperationDescriptionMetadataKey, operationData.Description)
 
 	operationDetailsMessage := fmt.Sprintf(`Please confirm the following operation details:
@@ -225,26 +254,49 @@ func (h handlerService) handleChooseBalanceFlowStepForCreatingOperation(ctx cont
 
 	opts.stateMetaData.Add(model.BalanceNameMetadataKey, opts.message.GetText())
 
+	operationType, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.OperationTypeMetadataKey)
+	if !ok {
+		logger.Error().Msg("operation type not found in metadata")
+		return "", fmt.Errorf("operation type not found in metadata")
+	}
+
+	// Categories are checked before other details are entered, so the user doesn't fill them in vain.
+	_, err := h.getCategoriesForOperationType(ctx, opts.user.ID, model.OperationType(operationType))
+	if err != nil {
+		if errs.IsExpected(err) {
+			logger.Info().Err(err).Msg(err.Error())
+			return "", err
+		}
+		logger.Error().Err(err).Msg("get categories for operation type")
+		return "", fmt.Errorf("get categories for operation type: %w", err)
+	}
+
+	return model.EnterOperationDescriptionFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:          opts.message.GetChatID(),
+		MessageID:       opts.message.GetMessageID(),
+		InlineMessageID: opts.message.GetInlineMessageID(),
+		UpdatedMessage:  "Enter operation description:",
+	})
+}
+
+// getCategoriesForOperationType returns active categories of the user which could be used for the operation type.
+func (h handlerService) getCategoriesForOperationType(ctx context.Context, userID string, operationType model.OperationType) ([]model.Category, error) {
 	categories, err := h.stores.Category.List(ctx, &ListCategoriesFilter{
-		UserID: opts.user.ID,
+		UserID: userID,
 	})
 	if err != nil {
-		logger.Error().Err(err).Msg("list categories from store")
-		return "", fmt.Errorf("list categories from store: %w", err)
+		return nil, fmt.Errorf("list categories from store: %w", err)
 	}
 	if len(categories) == 0 {
-		logger.Info().Msg("no categories found")
-		return "", ErrCategoriesNotFound
+		return nil, ErrCategoriesNotFound
 	}
-	logger.Debug().Any("categories", categories).Msg("got categories from store")
 
-	return model.ChooseCategoryFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
-		ChatID:                opts.message.GetChatID(),
-		MessageID:             opts.message.GetMessageID(),
-		InlineMessageID:       opts.message.GetInlineMessageID(),
-		UpdatedMessage:        "Choose operation category:",
-		UpdatedInlineKeyboard: getInlineKeyboardRows(categories, 3),
-	})
+	categories = model.FilterCategoriesByOperationType(model.GetActiveCategories(categories), operationType)
+	if len(categories) == 0 {
+		return nil, ErrCategoriesForOperationTypeNotFound
+	}
+
+	return categories, nil
 }
 
 func (h handlerService) handleChooseBalanceFromFlowStep(_ context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
@@ -306,12 +358,47 @@ func (h handlerService) handleChooseBalanceToFlowStep(ctx context.Context, opts
 	}
 
 	if balanceFrom.GetCurrency().Code != balanceTo.GetCurrency().Code {
-		outputMessage := model.BuildCurrencyConversionMessage(balanceFrom, balanceTo)
+		// Market rate is only a suggestion, so the user still could enter the rate manually when it's unavailable.
+		var marketExchangeRate *money.Money
+		marketExchangeRateOutput, err := h.services.Currency.GetExchangeRate(ctx, GetExchangeRateOptions{
+			BaseCurrency:   balanceFrom.GetCurrency().Code,
+			TargetCurrency: balanceTo.GetCurrency().Code,
+			Date:           time.Now(),
+			UserID:         opts.user.ID,
+		})
+		if err != nil {
+			logger.Warn().Err(err).Msg("get market exchange rate")
+		} else {
+			marketExchangeRate = &marketExchangeRateOutput.Rate
+		}
+
+		message := model.BuildCurrencyConversionMessage(balanceFrom, balanceTo, marketExchangeRate)
+		if marketExchangeRate != nil && marketExchangeRateOutput.Outdated {
+			message += fmt.Sprintf("\n\n⚠️ Current market rate is unavailable, the suggested one is from %s.", marketExchangeRateOutput.Date.Format("02/01/2006"))
+		}
+
+		var keyboard []InlineKeyboardRow
+		if marketExchangeRate != nil {
+			roundedMarketExchangeRate := marketExchangeRate.StringRounded(model.ExchangeRatePlaces)
+			opts.stateMetaData.Add(model.MarketExchangeRateMetadataKey, roundedMarketExchangeRate)
+			keyboard = []InlineKeyboardRow{
+				{
+					Buttons: []InlineKeyboardButton{
+						{
+							Text: fmt.Sprintf("Use %s", roundedMarketExchangeRate),
+							Data: roundedMarketExchangeRate,
+						},
+					},
+				},
+			}
+		}
+
 		return model.EnterCurrencyExchangeRateFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
-			ChatID:          opts.message.GetChatID(),
-			MessageID:       opts.message.GetMessageID(),
-			InlineMessageID: opts.message.GetInlineMessageID(),
-			UpdatedMessage:  outputMessage,
+			ChatID:                opts.message.GetChatID(),
+			MessageID:             opts.message.GetMessageID(),
+			InlineMessageID:       opts.message.GetInlineMessageID(),
+			UpdatedMessage:        message,
+			UpdatedInlineKeyboard: keyboard,
 		})
 	}
 
@@ -327,7 +414,7 @@ func (h handlerService) handleEnterCurrencyExchangeRateFlowStep(ctx context.Cont
 	logger := h.logger.With().Str("name", "handlerService.handleEnterCurrencyExchangeRateFlowStep").Logger()
 	logger.Debug().Any("opts", opts).Msg("got args")
 
-	exchangeRate, err := money.NewFromString(opts.message.GetText())
+	exchangeRate, err := money.NewFromExpression(opts.message.GetText())
 	if err != nil {
 		logger.Error().Err(err).Msg("parse exchange rate")
 		return "", ErrInvalidExchangeRateFormat
@@ -364,16 +451,79 @@ func (h handlerService) handleEnterCurrencyExchangeRateFlowStep(ctx context.Cont
 	)
 }
 
-func (h handlerService) handleChooseCategoryFlowStep(_ context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+func (h handlerService) handleChooseCategoryFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
 	logger := h.logger.With().Str("name", "handlerService.handleChooseCategoryFlowStep").Logger()
 	logger.Debug().Any("opts", opts).Msg("got args")
 
-	opts.stateMetaData.Add(model.CategoryTitleMetadataKey, opts.message.GetText())
-	return model.EnterOperationDescriptionFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+	category, err := h.stores.Category.Get(ctx, GetCategoryFilter{
+		UserID: opts.user.ID,
+		Title:  opts.message.GetText(),
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get category from store")
+		return "", fmt.Errorf("get category from store: %w", err)
+	}
+	if category == nil {
+		logger.Info().Msg("category not found")
+		return "", ErrCategoryNotFound
+	}
+
+	operationType, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.OperationTypeMetadataKey)
+	if !ok {
+		logger.Error().Msg("operation type not found in metadata")
+		return "", fmt.Errorf("operation type not found in metadata")
+	}
+
+	err = h.checkCategoryCanBeChosen(ctx, opts.user.ID, category, model.OperationType(operationType))
+	if err != nil {
+		if errs.IsExpected(err) {
+			logger.Info().Err(err).Msg(err.Error())
+			return "", err
+		}
+		logger.Error().Err(err).Msg("check category can be chosen")
+		return "", fmt.Errorf("check category can be chosen: %w", err)
+	}
+
+	subcategoriesShown, err := h.showSubcategoriesIfNeeded(ctx, opts, category, model.OperationType(operationType))
+	if err != nil {
+		logger.Error().Err(err).Msg("show sub-categories")
+		return "", fmt.Errorf("show sub-categories: %w", err)
+	}
+	if subcategoriesShown {
+		return model.ChooseCategoryFlowStep, nil
+	}
+
+	opts.stateMetaData.Add(model.CategoryTitleMetadataKey, category.Title)
+
+	operationAmount, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.OperationAmountMetadataKey)
+	if !ok {
+		logger.Error().Msg("operation amount not found in metadata")
+		return "", fmt.Errorf("operation amount not found in metadata")
+	}
+
+	parsedAmount, err := money.NewFromString(operationAmount)
+	if err != nil {
+		logger.Error().Err(err).Msg("parse operation amount")
+		return "", fmt.Errorf("parse operation amount: %w", err)
+	}
+
+	operation, err := h.createSpendingOrIncomingOperation(ctx, createSpendingOrIncomingOperationOptions{
+		metaData:        opts.stateMetaData,
+		user:            opts.user,
+		operationAmount: parsedAmount,
+		operationType:   model.OperationType(operationType),
+	})
+	if err != nil {
+		logger.Error().Err(err).Msgf("create %s operation", operationType)
+		return "", fmt.Errorf("create %s operation: %w", operationType, err)
+	}
+
+	return model.EndFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
 		ChatID:          opts.message.GetChatID(),
 		MessageID:       opts.message.GetMessageID(),
 		InlineMessageID: opts.message.GetInlineMessageID(),
-		UpdatedMessage:  "Enter operation description:",
+		UpdatedKeyboard: operationKeyboardRows,
+		UpdatedMessage:  fmt.Sprintf("Operation successfully created!\n\n%s", operation.GetDetails()),
 	})
 }
 
@@ -389,7 +539,7 @@ func (h handlerService) handleEnterOperationAmountFlowStep(ctx context.Context,
 	logger := h.logger.With().Str("name", "handlerService.handleEnterOperationAmountFlowStep").Logger()
 	logger.Debug().Any("opts", opts).Msg("got args")
 
-	operationAmount, err := money.NewFromString(opts.message.GetText())
+	operationAmount, err := money.NewFromExpression(opts.message.GetText())
 	if err != nil {
 		logger.Error().Err(err).Msg("parse operation amount")
 		return "", ErrInvalidAmountFormat
@@ -408,6 +558,51 @@ func (h handlerService) handleEnterOperationAmountFlowStep(ctx context.Context,
 	var outputMessage string
 	switch parsedOperationType {
 	case model.OperationTypeIncoming, model.OperationTypeSpending:
+		balanceName, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.BalanceNameMetadataKey)
+		if !ok {
+			logger.Error().Msg("balance name not found in metadata")
+			return "", fmt.Errorf("balance name not found in metadata")
+		}
+
+		balance := opts.user.GetBalance(balanceName)
+		if balance == nil {
+			logger.Info().Msg("balance not found")
+			return "", ErrBalanceNotFound
+		}
+
+		operationDescription, _ := model.GetTypedFromMetadata[string](opts.stateMetaData, model.OperationDescriptionMetadataKey)
+
+		// Category is asked only when none of the user's rules matches the operation,
+		// otherwise the category from the matched rule is used without choosing it again.
+		categoryFromRule, err := h.getCategoryFromRules(ctx, opts.user.ID, parsedOperationType, model.CategoryRuleInput{
+			Description: operationDescription,
+			Amount:      operationAmount,
+			BalanceID:   balance.ID,
+		})
+		if err != nil {
+			logger.Error().Err(err).Msg("get category from rules")
+			return "", fmt.Errorf("get category from rules: %w", err)
+		}
+		if categoryFromRule == nil {
+			categories, err := h.getCategoriesForOperationType(ctx, opts.user.ID, parsedOperationType)
+			if err != nil {
+				if errs.IsExpected(err) {
+					logger.Info().Err(err).Msg(err.Error())
+					return "", err
+				}
+				logger.Error().Err(err).Msg("get categories for operation type")
+				return "", fmt.Errorf("get categories for operation type: %w", err)
+			}
+
+			opts.stateMetaData.Add(model.OperationAmountMetadataKey, operationAmount.String())
+			return model.ChooseCategoryFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+				ChatID:         opts.message.GetChatID(),
+				Message:        "Choose operation category:",
+				InlineKeyboard: getInlineKeyboardRows(model.GetTopLevelCategories(categories), 3),
+			})
+		}
+		opts.stateMetaData.Add(model.CategoryTitleMetadataKey, categoryFromRule.Title)
+
 		operation, err := h.createSpendingOrIncomingOperation(ctx, createSpendingOrIncomingOperationOptions{
 			metaData:        opts.stateMetaData,
 			user:            opts.user,
@@ -419,7 +614,10 @@ func (h handlerService) handleEnterOperationAmountFlowStep(ctx context.Context,
 			return "", fmt.Errorf("process %s operation: %w", operationType, err)
 		}
 
-		outputMessage = fmt.Sprintf("Operation successfully created!\n\n%s", operation.GetDetails())
+		outputMessage = fmt.Sprintf(
+			"Operation successfully created!\nCategory %s was chosen by your rule.\n\n%s",
+			categoryFromRule.Title, operation.GetDetails(),
+		)
 
 	case model.OperationTypeTransfer:
 		operationOut, operationIn, err := h.createTransferOperation(ctx, createTransferOperationOptions{
@@ -482,7 +680,8 @@ func (h handlerService) createSpendingOrIncomingOperation(ctx context.Context, o
 	}
 
 	category, err := h.stores.Category.Get(ctx, GetCategoryFilter{
-		Title: categoryTitle,
+		UserID: opts.user.ID,
+		Title:  categoryTitle,
 	})
 	if err != nil {
 		logger.Error().Err(err).Msg("get category from store")
@@ -508,14 +707,14 @@ func (h handlerService) createSpendingOrIncomingOperation(ctx context.Context, o
 		calculateSpendingOperation(&balanceAmount, opts.operationAmount)
 	}
 
-	balance.Amount = balanceAmount.StringFixed()
+	balance.Amount = balance.GetCurrency().StringifyAmount(balanceAmount)
 
 	operation := &model.Operation{
 		ID:          uuid.NewString(),
 		BalanceID:   balance.ID,
 		CategoryID:  category.ID,
 		Type:        opts.operationType,
-		Amount:      opts.operationAmount.StringFixed(),
+		Amount:      balance.GetCurrency().StringifyAmount(opts.operationAmount),
 		Description: operationDescription,
 		CreatedAt:   time.Now(),
 	}
@@ -579,7 +778,7 @@ func (h handlerService) createTransferOperation(ctx context.Context, opts create
 		CategoryID:        "",
 		ParentOperationID: operationIDIn,
 		Type:              model.OperationTypeTransferOut,
-		Amount:            opts.operationAmount.StringFixed(),
+		Amount:            balanceFrom.GetCurrency().StringifyAmount(opts.operationAmount),
 		Description:       fmt.Sprintf("Transfer: %s ➜ %s", balanceFrom.Name, balanceTo.Name),
 		CreatedAt:         time.Now(),
 	}
@@ -589,7 +788,7 @@ func (h handlerService) createTransferOperation(ctx context.Context, opts create
 		CategoryID:        "",
 		ParentOperationID: operationIDOut,
 		Type:              model.OperationTypeTransferIn,
-		Amount:            opts.operationAmount.StringFixed(),
+		Amount:            balanceTo.GetCurrency().StringifyAmount(opts.operationAmount),
 		Description:       fmt.Sprintf("Received transfer from %s", balanceFrom.Name),
 		CreatedAt:         time.Now(),
 	}
@@ -609,11 +808,17 @@ func (h handlerService) createTransferOperation(ctx context.Context, opts create
 		parsedExchangeRate, _ := money.NewFromString(exchangeRate)
 		operationIn.ExchangeRate = parsedExchangeRate.String()
 		operationOut.ExchangeRate = parsedExchangeRate.String()
+
+		marketExchangeRate, ok := model.GetTypedFromMetadata[string](opts.metaData, model.MarketExchangeRateMetadataKey)
+		if ok {
+			operationIn.MarketExchangeRate = marketExchangeRate
+			operationOut.MarketExchangeRate = marketExchangeRate
+		}
 		calculateOptions.exchangeRate = &parsedExchangeRate
 
 		operationAmountIn := opts.operationAmount
 		operationAmountIn.Mul(parsedExchangeRate)
-		operationIn.Amount = operationAmountIn.StringFixed()
+		operationIn.Amount = balanceTo.GetCurrency().StringifyAmount(operationAmountIn)
 	}
 
 	calculateTransferOperation(calculateOptions)
@@ -626,8 +831,8 @@ func (h handlerService) createTransferOperation(ctx context.Context, opts create
 		}
 	}
 
-	balanceFrom.Amount = balanceAmountFrom.StringFixed()
-	balanceTo.Amount = balanceAmountTo.StringFixed()
+	balanceFrom.Amount = balanceFrom.GetCurrency().StringifyAmount(balanceAmountFrom)
+	balanceTo.Amount = balanceTo.GetCurrency().StringifyAmount(balanceAmountTo)
 
 	for _, balance := range []*model.Balance{balanceFrom, balanceTo} {
 		err := h.stores.Balance.Update(ctx, balance)
@@ -663,83 +868,80 @@ func (h handlerService) handleChooseBalanceFlowStepForGetOperationsHistory(_ con
 	opts.stateMetaData.Add(model.BalanceNameMetadataKey, opts.message.GetText())
 	opts.stateMetaData.Add(model.PageMetadataKey, firstPage)
 
-	return model.ChooseTimePeriodForOperationsHistoryFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+	return model.ChoosePeriodTypeFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
 		ChatID:                opts.message.GetChatID(),
 		MessageID:             opts.message.GetMessageID(),
 		InlineMessageID:       opts.message.GetInlineMessageID(),
 		UpdatedMessage:        "Please select a period for operation history!",
-		UpdatedInlineKeyboard: operationHistoryPeriodKeyboard,
+		UpdatedInlineKeyboard: periodTypeKeyboard,
 	})
 }
 
-func (h handlerService) handleChooseTimePeriodForOperationsHistoryFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
-	logger := h.logger.With().Str("name", "handlerService.handleChooseTimePeriodForOperationsHistoryFlowStep").Logger()
+func (h handlerService) handleChoosePeriodFlowStepForGetOperationsHistory(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChoosePeriodFlowStepForGetOperationsHistory").Logger()
 	logger.Debug().Any("opts", opts).Msg("got args")
 
-	balanceName, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.BalanceNameMetadataKey)
-	if !ok {
-		logger.Error().Msg("balance name not found in metadata")
-		return "", fmt.Errorf("balance name not found in metadata")
+	period, err := getPeriodFromMessage(opts)
+	if err != nil {
+		logger.Info().Err(err).Msg("invalid period")
+		return "", err
 	}
 
-	balance, err := h.stores.Balance.Get(ctx, GetBalanceFilter{
-		Name:            balanceName,
-		PreloadCurrency: true,
-	})
+	balance, err := h.getBalanceFromMetadata(ctx, opts)
 	if err != nil {
-		logger.Error().Err(err).Msg("get balance from store")
-		return "", fmt.Errorf("get balance from store: %w", err)
+		logger.Error().Err(err).Msg("get balance from metadata")
+		return "", fmt.Errorf("get balance from metadata: %w", err)
 	}
-	if balance == nil {
-		logger.Error().Msg("balance not found")
-		return "", fmt.Errorf("balance not found")
+
+	message, keyboard, err := h.getOperationsHistoryKeyboard(
+		ctx,
+		getOperationsHistoryKeyboardOptions{
+			balance: balance,
+			period:  period,
+			page:    firstPage,
+		},
+	)
+	if err != nil {
+		logger.Error().Err(err).Msg("get operations keyboard")
+		return "", fmt.Errorf("get operations keyboard: %w", err)
 	}
-	logger.Debug().Any("balance", balance).Msg("got balance")
 
-	messageText := opts.message.GetText()
-	if isPaginationNeeded(messageText) {
-		creationPeriod, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.OperationCreationPeriodMetadataKey)
-		if !ok {
-			logger.Error().Msg("creation period not found in metadata")
-			return "", fmt.Errorf("creation period not found in metadata")
-		}
+	return model.ChooseTimePeriodForOperationsHistoryFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:                  opts.message.GetChatID(),
+		Message:                 message,
+		FormatMessageInMarkDown: true,
+		InlineKeyboard:          keyboard,
+	})
+}
 
-		nextPage := calculateNextPage(messageText, opts.stateMetaData)
-		opts.stateMetaData.Add(model.PageMetadataKey, nextPage)
-		parsedCreationPeriod := model.CreationPeriod(creationPeriod)
-
-		message, keyboard, err := h.getOperationsHistoryKeyboard(
-			ctx,
-			getOperationsHistoryKeyboardOptions{
-				balance:        balance,
-				creationPeriod: parsedCreationPeriod,
-				page:           nextPage,
-			},
-		)
-		if err != nil {
-			logger.Error().Err(err).Msg("get operations keyboard")
-			return "", fmt.Errorf("get operations keyboard: %w", err)
-		}
+func (h handlerService) handleChooseTimePeriodForOperationsHistoryFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseTimePeriodForOperationsHistoryFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
 
-		return model.ChooseTimePeriodForOperationsHistoryFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
-			ChatID:                  opts.message.GetChatID(),
-			MessageID:               opts.message.GetMessageID(),
-			InlineMessageID:         opts.message.GetInlineMessageID(),
-			FormatMessageInMarkDown: true,
-			UpdatedInlineKeyboard:   keyboard,
-			UpdatedMessage:          message,
-		})
+	period, err := getPeriodFromMetadata(opts.stateMetaData)
+	if err != nil {
+		logger.Error().Err(err).Msg("get period from metadata")
+		return "", fmt.Errorf("get period from metadata: %w", err)
 	}
 
-	creationPeriod := model.GetCreationPeriodFromText(messageText)
-	opts.stateMetaData.Add(model.OperationCreationPeriodMetadataKey, creationPeriod)
+	balance, err := h.getBalanceFromMetadata(ctx, opts)
+	if err != nil {
+		logger.Error().Err(err).Msg("get balance from metadata")
+		return "", fmt.Errorf("get balance from metadata: %w", err)
+	}
+
+	nextPage := firstPage
+	if isPaginationNeeded(opts.message.GetText()) {
+		nextPage = calculateNextPage(opts.message.GetText(), opts.stateMetaData)
+	}
+	opts.stateMetaData.Add(model.PageMetadataKey, nextPage)
 
 	message, keyboard, err := h.getOperationsHistoryKeyboard(
 		ctx,
 		getOperationsHistoryKeyboardOptions{
-			balance:        balance,
-			creationPeriod: creationPeriod,
-			page:           firstPage,
+			balance: balance,
+			period:  period,
+			page:    nextPage,
 		},
 	)
 	if err != nil {
@@ -748,11 +950,12 @@ func (h handlerService) handleChooseTimePeriodForOperationsHistoryFlowStep(ctx c
 	}
 
 	return model.ChooseTimePeriodForOperationsHistoryFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
-		UpdatedMessage:          message,
-		FormatMessageInMarkDown: true,
 		ChatID:                  opts.message.GetChatID(),
 		MessageID:               opts.message.GetMessageID(),
+		InlineMessageID:         opts.message.GetInlineMessageID(),
+		FormatMessageInMarkDown: true,
 		UpdatedInlineKeyboard:   keyboard,
+		UpdatedMessage:          message,
 	})
 }
 
@@ -1001,8 +1204,8 @@ func (h handlerService) deleteTransferOperation(ctx context.Context, initialOper
 		}
 	}
 
-	initialBalance.Amount = initialBalanceAmount.StringFixed()
-	pairedBalance.Amount = pairedBalanceAmount.StringFixed()
+	initialBalance.Amount = initialBalance.GetCurrency().StringifyAmount(initialBalanceAmount)
+	pairedBalance.Amount = pairedBalance.GetCurrency().StringifyAmount(pairedBalanceAmount)
 
 	for _, balance := range []*model.Balance{initialBalance, pairedBalance} {
 		err := h.stores.Balance.Update(ctx, balance)
@@ -1028,10 +1231,10 @@ func (h handlerService) deleteSpendingOrIncomeOperation(ctx context.Context, ope
 	switch operation.Type {
 	case model.OperationTypeSpending:
 		calculateDeletedSpendingOperation(&balanceAmount, operationAmount)
-		balance.Amount = balanceAmount.StringFixed()
+		balance.Amount = balance.GetCurrency().StringifyAmount(balanceAmount)
 	case model.OperationTypeIncoming:
 		calculateDeletedIncomingOperation(&balanceAmount, operationAmount)
-		balance.Amount = balanceAmount.StringFixed()
+		balance.Amount = balance.GetCurrency().StringifyAmount(balanceAmount)
 	}
 
 	err := h.stores.Balance.Update(ctx, balance)
@@ -1204,10 +1407,15 @@ func (h handlerService) handleChooseUpdateOperationOptionFlowStep(ctx context.Co
 		logger.Debug().Any("categories", categories).Msg("got categories from store")
 
 		var currentCategory string
-		categoriesWithoutAlreadyUsedCategory := slices.DeleteFunc(categories, func(category model.Category) bool {
-			currentCategory = category.Title
-			return category.ID == operation.CategoryID
-		})
+		for _, category := range categories {
+			if category.ID == operation.CategoryID {
+				currentCategory = model.GetCategoryFullTitle(category, categories)
+			}
+		}
+
+		categoriesWithoutAlreadyUsedCategory := getCategoriesToChoose(
+			model.FilterCategoriesByOperationType(categories, operation.Type), operation.CategoryID,
+		)
 
 		// User does not have enough categories to choose from
 		if len(categoriesWithoutAlreadyUsedCategory) == 0 {
@@ -1248,6 +1456,27 @@ func (h handlerService) handleChooseUpdateOperationOptionFlowStep(ctx context.Co
 				operation.CreatedAt.Format(operationTimeFormat),
 			),
 		})
+	case model.BotCreateCategoryRuleFromOperationCommand:
+		rule, err := h.createCategoryRuleFromOperation(ctx, opts.user.ID, operation)
+		if err != nil {
+			if errs.IsExpected(err) {
+				return "", err
+			}
+
+			logger.Error().Err(err).Msg("create category rule from operation")
+			return "", fmt.Errorf("create category rule from operation: %w", err)
+		}
+
+		return model.ChooseUpdateOperationOptionFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+			ChatID:          opts.message.GetChatID(),
+			MessageID:       opts.message.GetMessageID(),
+			InlineMessageID: opts.message.GetInlineMessageID(),
+			UpdatedMessage: fmt.Sprintf(
+				"Rule successfully created! Operations which description contains %s will be categorized automatically.\nPlease choose other update operation option or finish action by canceling it!",
+				rule.GetName(),
+			),
+			UpdatedInlineKeyboard: h.getUpdateOptionKeyboardByOperationType(operation.Type),
+		})
 	default:
 		return "", fmt.Errorf("received unknown update operation option: %s", opts.message.GetText())
 	}
@@ -1257,7 +1486,7 @@ func (h handlerService) handleEnterOperationAmountFlowStepForUpdate(ctx context.
 	logger := h.logger.With().Str("name", "handlerService.handleEnterOperationAmountFlowStepForUpdate").Logger()
 	logger.Debug().Any("opts", opts).Msg("got args")
 
-	operationAmount, err := money.NewFromString(opts.message.GetText())
+	operationAmount, err := money.NewFromExpression(opts.message.GetText())
 	if err != nil {
 		logger.Error().Err(err).Msg("parse operation amount")
 		return "", ErrInvalidAmountFormat
@@ -1362,7 +1591,7 @@ func (h handlerService) updateOperationAmountForSpendingOrIncomeOperation(ctx co
 	case model.OperationTypeSpending:
 		calculateUpdatedSpendingOperation(&balanceAmount, operationAmount, updatedOperationAmount)
 	}
-	balance.Amount = balanceAmount.StringFixed()
+	balance.Amount = balance.GetCurrency().StringifyAmount(balanceAmount)
 
 	err = h.stores.Balance.Update(ctx, balance)
 	if err != nil {
@@ -1370,7 +1599,7 @@ func (h handlerService) updateOperationAmountForSpendingOrIncomeOperation(ctx co
 		return fmt.Errorf("update balance in store: %w", err)
 	}
 
-	operation.Amount = updatedOperationAmount.StringFixed()
+	operation.Amount = balance.GetCurrency().StringifyAmount(updatedOperationAmount)
 	err = h.stores.Operation.Update(ctx, operation.ID, operation)
 	if err != nil {
 		logger.Error().Err(err).Msg("update operation in store")
@@ -1431,8 +1660,8 @@ func (h handlerService) updateOperationAmountForTransferOperation(ctx context.Co
 
 	calculateUpdatedTranferOperation(calculateOptions)
 
-	initialOperation.Amount = initialOperationAmount.StringFixed()
-	pairedOperation.Amount = pairedOperationAmount.StringFixed()
+	initialOperation.Amount = initialBalance.GetCurrency().StringifyAmount(initialOperationAmount)
+	pairedOperation.Amount = pairedBalance.GetCurrency().StringifyAmount(pairedOperationAmount)
 
 	for _, operation := range []*model.Operation{initialOperation, pairedOperation} {
 		err := h.stores.Operation.Update(ctx, operation.ID, operation)
@@ -1442,8 +1671,8 @@ func (h handlerService) updateOperationAmountForTransferOperation(ctx context.Co
 		}
 	}
 
-	initialBalance.Amount = initialBalanceAmount.StringFixed()
-	pairedBalance.Amount = pairedBalanceAmount.StringFixed()
+	initialBalance.Amount = initialBalance.GetCurrency().StringifyAmount(initialBalanceAmount)
+	pairedBalance.Amount = pairedBalance.GetCurrency().StringifyAmount(pairedBalanceAmount)
 
 	for _, balance := range []*model.Balance{initialBalance, pairedBalance} {
 		err := h.stores.Balance.Update(ctx, balance)
@@ -1534,6 +1763,25 @@ func (h handlerService) handleChooseCategoryFlowStepForOperationUpdate(ctx conte
 		return "", ErrOperationNotFound
 	}
 
+	err = h.checkCategoryCanBeChosen(ctx, opts.user.ID, category, operation.Type)
+	if err != nil {
+		if errs.IsExpected(err) {
+			logger.Info().Err(err).Msg(err.Error())
+			return "", err
+		}
+		logger.Error().Err(err).Msg("check category can be chosen")
+		return "", fmt.Errorf("check category can be chosen: %w", err)
+	}
+
+	subcategoriesShown, err := h.showSubcategoriesIfNeeded(ctx, opts, category, operation.Type)
+	if err != nil {
+		logger.Error().Err(err).Msg("show sub-categories")
+		return "", fmt.Errorf("show sub-categories: %w", err)
+	}
+	if subcategoriesShown {
+		return model.ChooseCategoryFlowStep, nil
+	}
+
 	operation.CategoryID = category.ID
 
 	err = h.stores.Operation.Update(ctx, operation.ID, operation)
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+)
+
+const (
+	periodYearsCount     = 5
+	periodWeeksCount     = 6
+	maxWeekPeriodsPerRow = 2
+	maxPeriodsPerRow     = 3
+)
+
+var periodTypeKeyboard = []InlineKeyboardRow{
+	{
+		Buttons: []InlineKeyboardButton{
+			{
+				Text: "Month 📅",
+				Data: string(model.PeriodTypeMonth),
+			},
+			{
+				Text: "Week 🗓️",
+				Data: string(model.PeriodTypeWeek),
+			},
+		},
+	},
+	{
+		Buttons: []InlineKeyboardButton{
+			{
+				Text: "Quarter 📆",
+				Data: string(model.PeriodTypeQuarter),
+			},
+			{
+				Text: "Custom Range ✍️",
+				Data: string(model.PeriodTypeCustom),
+			},
+		},
+	},
+}
+
+// NOTE: Period picker steps are shared between flows, each flow registers the picker handlers
+// and its own handler for model.ChoosePeriodFlowStep, which receives the chosen period.
+
+func (h handlerService) handleChoosePeriodTypeFlowStep(_ context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChoosePeriodTypeFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	updateMessageOptions := UpdateMessageOptions{
+		ChatID:                  opts.message.GetChatID(),
+		MessageID:               opts.message.GetMessageID(),
+		InlineMessageID:         opts.message.GetInlineMessageID(),
+		FormatMessageInMarkDown: true,
+	}
+
+	periodType := model.PeriodType(opts.message.GetText())
+	switch periodType {
+	case model.PeriodTypeMonth, model.PeriodTypeQuarter:
+		opts.stateMetaData.Add(model.PeriodTypeMetadataKey, string(periodType))
+
+		var yearsKeyboard InlineKeyboardRow
+		for _, year := range model.GetPeriodYears(time.Now(), periodYearsCount) {
+			yearsKeyboard.Buttons = append(yearsKeyboard.Buttons, InlineKeyboardButton{
+				Text: strconv.Itoa(year),
+			})
+		}
+
+		updateMessageOptions.UpdatedMessage = "Choose the year or enter it, e.g. `2023`:"
+		updateMessageOptions.UpdatedInlineKeyboard = []InlineKeyboardRow{yearsKeyboard}
+		return model.ChoosePeriodYearFlowStep, h.apis.Messenger.UpdateMessage(updateMessageOptions)
+
+	case model.PeriodTypeWeek:
+		updateMessageOptions.UpdatedMessage = "Choose the week or enter it in ISO format, e.g. `2025-W05`:"
+		updateMessageOptions.UpdatedInlineKeyboard = getPeriodsKeyboard(model.GetLastWeekPeriods(time.Now(), periodWeeksCount), maxWeekPeriodsPerRow)
+		return model.ChoosePeriodFlowStep, h.apis.Messenger.UpdateMessage(updateMessageOptions)
+
+	case model.PeriodTypeCustom:
+		updateMessageOptions.UpdatedMessage = "Enter the range of dates in format DD/MM/YYYY - DD/MM/YYYY, e.g. `01/01/2025 - 15/02/2025`:"
+		return model.ChoosePeriodFlowStep, h.apis.Messenger.UpdateMessage(updateMessageOptions)
+
+	default:
+		logger.Info().Any("periodType", periodType).Msg("received unknown period type")
+		return "", ErrInvalidPeriod
+	}
+}
+
+func (h handlerService) handleChoosePeriodYearFlowStep(_ context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChoosePeriodYearFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	now := time.Now()
+
+	year, err := strconv.Atoi(opts.message.GetText())
+	if err != nil || year < 1 || year > now.Year() {
+		logger.Info().Err(err).Msg("invalid period year")
+		return "", ErrInvalidPeriod
+	}
+
+	periodType, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.PeriodTypeMetadataKey)
+	if !ok {
+		logger.Error().Msg("period type not found in metadata")
+		return "", fmt.Errorf("period type not found in metadata")
+	}
+
+	var periods []model.Period
+	switch model.PeriodType(periodType) {
+	case model.PeriodTypeQuarter:
+		periods = model.GetQuarterPeriods(year, now)
+	default:
+		periods = model.GetMonthPeriods(year, now)
+	}
+
+	return model.ChoosePeriodFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:         opts.message.GetChatID(),
+		Message:        "Choose the period:",
+		InlineKeyboard: getPeriodsKeyboard(periods, maxPeriodsPerRow),
+	})
+}
+
+// getPeriodFromMessage parses the period chosen by the user and saves it into the state metadata,
+// so it could be used by the next steps of the flow.
+func getPeriodFromMessage(opts flowProcessingOptions) (model.Period, error) {
+	period, err := model.ParsePeriod(opts.message.GetText())
+	if err != nil {
+		return model.Period{}, ErrInvalidPeriod
+	}
+
+	opts.stateMetaData.Add(model.PeriodMetadataKey, period.ID)
+	return period, nil
+}
+
+func getPeriodFromMetadata(metadata model.Metadata) (model.Period, error) {
+	periodID, ok := model.GetTypedFromMetadata[string](metadata, model.PeriodMetadataKey)
+	if !ok {
+		return model.Period{}, fmt.Errorf("period not found in metadata")
+	}
+
+	period, err := model.ParsePeriod(periodID)
+	if err != nil {
+		return model.Period{}, fmt.Errorf("parse period: %w", err)
+	}
+
+	return period, nil
+}
+
+// getPeriodsKeyboard returns keyboard with periods, period ID is used as button data,
+// since period names are not unique, e.g. week names don't contain the year.
+func getPeriodsKeyboard(periods []model.Period, elementLimitPerRow int) []InlineKeyboardRow {
+	keyboard := make([]InlineKeyboardRow, 0)
+
+	var currentRow InlineKeyboardRow
+	for i, period := range periods {
+		currentRow.Buttons = append(currentRow.Buttons, InlineKeyboardButton{
+			Text: period.GetName(),
+			Data: period.GetID(),
+		})
+
+		if len(currentRow.Buttons) == elementLimitPerRow || i == len(periods)-1 {
+			keyboard = append(keyboard, currentRow)
+			currentRow = InlineKeyboardRow{}
+		}
+	}
+
+	return keyboard
+}
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/VladPetriv/finance_bot/pkg/money"
+	"github.com/google/uuid"
+)
+
+func (h handlerService) handleCreateCurrencyFlowStep(_ context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleCreateCurrencyFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	err := h.showCancelButton(opts.message.GetChatID(), "Enter currency code(e.g. BTC or MILES):")
+	if err != nil {
+		logger.Error().Err(err).Msg("show cancel button")
+		return "", fmt.Errorf("show cancel button: %w", err)
+	}
+
+	return model.EnterCurrencyCodeFlowStep, nil
+}
+
+func (h handlerService) handleEnterCurrencyCodeFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleEnterCurrencyCodeFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	code := model.NormalizeCurrencyCode(opts.message.GetText())
+	if !model.IsValidCurrencyCode(code) {
+		logger.Info().Str("code", code).Msg("invalid currency code")
+		return "", ErrInvalidCurrencyCode
+	}
+
+	// Private currency can't reuse the code of public currency, otherwise conversions would be ambiguous.
+	currency, err := h.stores.Currency.Get(ctx, GetCurrencyFilter{
+		Code:   code,
+		UserID: opts.user.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get currency from store")
+		return "", fmt.Errorf("get currency from store: %w", err)
+	}
+	if currency != nil {
+		logger.Info().Msg("currency already exists")
+		return "", ErrCurrencyAlreadyExists
+	}
+
+	opts.stateMetaData.Add(model.CurrencyCodeMetadataKey, code)
+	return model.EnterCurrencyNameFlowStep, h.apis.Messenger.SendMessage(opts.message.GetChatID(), "Enter currency name:")
+}
+
+func (h handlerService) handleEnterCurrencyNameFlowStep(_ context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleEnterCurrencyNameFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	opts.stateMetaData.Add(model.CurrencyNameMetadataKey, strings.TrimSpace(opts.message.GetText()))
+	return model.EnterCurrencySymbolFlowStep, h.apis.Messenger.SendMessage(opts.message.GetChatID(), "Enter currency symbol:")
+}
+
+func (h handlerService) handleEnterCurrencySymbolFlowStep(_ context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleEnterCurrencySymbolFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	opts.stateMetaData.Add(model.CurrencySymbolMetadataKey, strings.TrimSpace(opts.message.GetText()))
+	return model.EnterCurrencyDecimalPlacesFlowStep, h.apis.Messenger.SendMessage(
+		opts.message.GetChatID(),
+		fmt.Sprintf("Enter number of decimal places(from 0 to %d, e.g. 8 for BTC or 0 for miles):", model.MaxCurrencyDecimalPlaces),
+	)
+}
+
+func (h handlerService) handleEnterCurrencyDecimalPlacesFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleEnterCurrencyDecimalPlacesFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	decimalPlaces, err := strconv.Atoi(strings.TrimSpace(opts.message.GetText()))
+	if err != nil || decimalPlaces < 0 || decimalPlaces > model.MaxCurrencyDecimalPlaces {
+		logger.Info().Msg("invalid currency decimal places")
+		return "", ErrInvalidCurrencyDecimalPlaces
+	}
+
+	code, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.CurrencyCodeMetadataKey)
+	if !ok {
+		logger.Error().Msg("currency code not found in metadata")
+		return "", fmt.Errorf("currency code not found in metadata")
+	}
+
+	name, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.CurrencyNameMetadataKey)
+	if !ok {
+		logger.Error().Msg("currency name not found in metadata")
+		return "", fmt.Errorf("currency name not found in metadata")
+	}
+
+	symbol, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.CurrencySymbolMetadataKey)
+	if !ok {
+		logger.Error().Msg("currency symbol not found in metadata")
+		return "", fmt.Errorf("currency symbol not found in metadata")
+	}
+
+	currency := model.Currency{
+		ID:            uuid.NewString(),
+		Name:          name,
+		Code:          code,
+		Symbol:        symbol,
+		UserID:        opts.user.ID,
+		DecimalPlaces: decimalPlaces,
+	}
+	err = h.stores.Currency.CreateIfNotExists(ctx, &currency)
+	if err != nil {
+		logger.Error().Err(err).Msg("create currency in store")
+		return "", fmt.Errorf("create currency in store: %w", err)
+	}
+
+	return model.EndFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID: opts.message.GetChatID(),
+		Message: fmt.Sprintf(
+			"Currency %s created! It's available only to you and could be used for balances.\n"+
+				"Use \"%s\" to convert it to other currencies.",
+			currency.GetName(), model.BotSetCurrencyRateCommand,
+		),
+		Keyboard: balanceKeyboardRows,
+	})
+}
+
+func (h handlerService) handleListCurrenciesFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleListCurrenciesFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	currencies, err := h.listPrivateCurrencies(ctx, opts.user.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("list private currencies")
+		return "", fmt.Errorf("list private currencies: %w", err)
+	}
+	if len(currencies) == 0 {
+		return model.EndFlowStep, ErrPrivateCurrenciesNotFound
+	}
+
+	currencyIDs := make([]string, 0, len(currencies))
+	for _, currency := range currencies {
+		currencyIDs = append(currencyIDs, currency.ID)
+	}
+
+	rates, err := h.stores.Currency.ListRates(ctx, ListCurrencyRatesFilter{
+		CurrencyIDs: currencyIDs,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("list currency rates from store")
+		return "", fmt.Errorf("list currency rates from store: %w", err)
+	}
+
+	ratesByCurrencyID := make(map[string][]model.CurrencyRate, len(currencies))
+	for _, rate := range rates {
+		ratesByCurrencyID[rate.CurrencyID] = append(ratesByCurrencyID[rate.CurrencyID], rate)
+	}
+
+	return model.EndFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:   opts.message.GetChatID(),
+		Message:  model.BuildPrivateCurrenciesMessage(currencies, ratesByCurrencyID),
+		Keyboard: balanceKeyboardRows,
+	})
+}
+
+func (h handlerService) handleSetCurrencyRateFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleSetCurrencyRateFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	currencies, err := h.listPrivateCurrencies(ctx, opts.user.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("list private currencies")
+		return "", fmt.Errorf("list private currencies: %w", err)
+	}
+	if len(currencies) == 0 {
+		return model.EndFlowStep, ErrPrivateCurrenciesNotFound
+	}
+
+	err = h.showCancelButton(opts.message.GetChatID(), "")
+	if err != nil {
+		logger.Error().Err(err).Msg("show cancel button")
+		return "", fmt.Errorf("show cancel button: %w", err)
+	}
+
+	return model.ChooseCurrencyFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:         opts.message.GetChatID(),
+		Message:        "Choose currency to set the rate for:",
+		InlineKeyboard: getInlineKeyboardRows(currencies, 2),
+	})
+}
+
+func (h handlerService) handleChooseCurrencyFlowStepForSetRate(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseCurrencyFlowStepForSetRate").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	currency, err := h.stores.Currency.Get(ctx, GetCurrencyFilter{
+		ID:     opts.message.GetText(),
+		UserID: opts.user.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get currency from store")
+		return "", fmt.Errorf("get currency from store: %w", err)
+	}
+	if currency == nil || !currency.IsPrivate() {
+		logger.Info().Msg("private currency not found")
+		return "", ErrCurrencyNotFound
+	}
+
+	opts.stateMetaData.Add(model.CurrencyIDMetadataKey, currency.ID)
+	opts.stateMetaData.Add(model.CurrencyCodeMetadataKey, currency.Code)
+
+	return model.EnterCurrencyRateTargetFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:          opts.message.GetChatID(),
+		MessageID:       opts.message.GetMessageID(),
+		InlineMessageID: opts.message.GetInlineMessageID(),
+		UpdatedMessage:  fmt.Sprintf("Enter code of the currency in which the rate of %s is quoted(e.g. USD):", currency.Code),
+	})
+}
+
+func (h handlerService) handleEnterCurrencyRateTargetFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleEnterCurrencyRateTargetFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	currencyCode, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.CurrencyCodeMetadataKey)
+	if !ok {
+		logger.Error().Msg("currency code not found in metadata")
+		return "", fmt.Errorf("currency code not found in metadata")
+	}
+
+	// Rates are quoted only in public currencies, so cross rates could be always resolved through the currency exchanger.
+	targetCurrency, err := h.stores.Currency.Get(ctx, GetCurrencyFilter{
+		Code: model.NormalizeCurrencyCode(opts.message.GetText()),
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get currency from store")
+		return "", fmt.Errorf("get currency from store: %w", err)
+	}
+	if targetCurrency == nil {
+		logger.Info().Msg("target currency not found")
+		return "", ErrCurrencyNotFound
+	}
+
+	opts.stateMetaData.Add(model.CurrencyRateTargetMetadataKey, targetCurrency.Code)
+	return model.EnterCurrencyRateFlowStep, h.apis.Messenger.SendMessage(
+		opts.message.GetChatID(),
+		fmt.Sprintf("Enter the rate:\nFormula: 1 %s = X %s", currencyCode, targetCurrency.Code),
+	)
+}
+
+func (h handlerService) handleEnterCurrencyRateFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleEnterCurrencyRateFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	rate, err := money.NewFromExpression(opts.message.GetText())
+	if err != nil || !rate.GreaterThan(money.Zero) {
+		logger.Info().Msg("invalid currency rate")
+		return "", ErrInvalidExchangeRateFormat
+	}
+
+	currencyID, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.CurrencyIDMetadataKey)
+	if !ok {
+		logger.Error().Msg("currency id not found in metadata")
+		return "", fmt.Errorf("currency id not found in metadata")
+	}
+
+	currencyCode, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.CurrencyCodeMetadataKey)
+	if !ok {
+		logger.Error().Msg("currency code not found in metadata")
+		return "", fmt.Errorf("currency code not found in metadata")
+	}
+
+	targetCurrency, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.CurrencyRateTargetMetadataKey)
+	if !ok {
+		logger.Error().Msg("currency rate target not found in metadata")
+		return "", fmt.Errorf("currency rate target not found in metadata")
+	}
+
+	err = h.stores.Currency.UpsertRate(ctx, model.CurrencyRate{
+		ID:             uuid.NewString(),
+		CurrencyID:     currencyID,
+		TargetCurrency: targetCurrency,
+		Rate:           rate.String(),
+		EffectiveDate:  model.GetExchangeRateDate(time.Now()),
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("upsert currency rate in store")
+		return "", fmt.Errorf("upsert currency rate in store: %w", err)
+	}
+
+	return model.EndFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:   opts.message.GetChatID(),
+		Message:  fmt.Sprintf("Rate saved: 1 %s = %s %s", currencyCode, rate.String(), targetCurrency),
+		Keyboard: balanceKeyboardRows,
+	})
+}
+
+func (h handlerService) listPrivateCurrencies(ctx context.Context, userID string) ([]model.Currency, error) {
+	currencies, err := h.stores.Currency.List(ctx, ListCurrenciesFilter{
+		UserID:      userID,
+		OnlyPrivate: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list currencies from store: %w", err)
+	}
+
+	return currencies, nil
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/google/uuid"
+)
+
+// recurringPaymentsLookbackPeriod represents how far in the past operations are analyzed for recurring payments.
+const recurringPaymentsLookbackPeriod = 2 * 365 * 24 * time.Hour
+
+func (h *handlerService) handleDetectRecurringPaymentsFlowStep(_ context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleDetectRecurringPaymentsFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	err := h.showCancelButton(opts.message.GetChatID(), "")
+	if err != nil {
+		logger.Error().Err(err).Msg("show cancel button")
+		return "", fmt.Errorf("show cancel button: %w", err)
+	}
+
+	return model.ChooseBalanceFlowStep, h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:         opts.message.GetChatID(),
+		Message:        "Choose balance to search for recurring payments:",
+		InlineKeyboard: getInlineKeyboardRows(opts.user.Balances, 3),
+	})
+}
+
+func (h *handlerService) handleChooseBalanceFlowStepForDetectRecurringPayments(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleChooseBalanceFlowStepForDetectRecurringPayments").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	balance := opts.user.GetBalance(opts.message.GetText())
+	if balance == nil {
+		return model.EndFlowStep, ErrBalanceNotFound
+	}
+
+	recurringPayments, err := h.detectRecurringPayments(ctx, balance.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("detect recurring payments")
+		return "", fmt.Errorf("detect recurring payments: %w", err)
+	}
+	if len(recurringPayments) == 0 {
+		return model.EndFlowStep, ErrRecurringPaymentsNotFound
+	}
+
+	opts.stateMetaData.Add(model.BalanceIDMetadataKey, balance.ID)
+	opts.stateMetaData.Add(model.SkippedRecurringPaymentsMetadataKey, 0)
+
+	return model.ConfirmSubscriptionFromRecurringPaymentFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                opts.message.GetChatID(),
+		MessageID:             opts.message.GetMessageID(),
+		InlineMessageID:       opts.message.GetInlineMessageID(),
+		UpdatedMessage:        recurringPayments[0].GetSuggestionMessage(),
+		UpdatedInlineKeyboard: recurringPaymentSuggestionKeyboard,
+	})
+}
+
+func (h *handlerService) handleConfirmSubscriptionFromRecurringPaymentFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleConfirmSubscriptionFromRecurringPaymentFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	confirmCreation, err := strconv.ParseBool(opts.message.GetText())
+	if err != nil {
+		logger.Error().Err(err).Msg("parse callback data to bool")
+		return "", fmt.Errorf("parse callback data to bool: %w", err)
+	}
+
+	balanceID, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.BalanceIDMetadataKey)
+	if !ok {
+		logger.Error().Msg("balance id not found in metadata")
+		return "", fmt.Errorf("balance id not found in metadata")
+	}
+
+	// NOTE: Numbers are decoded from state metadata as float64.
+	skippedRecurringPayments, ok := model.GetTypedFromMetadata[float64](opts.stateMetaData, model.SkippedRecurringPaymentsMetadataKey)
+	if !ok {
+		logger.Error().Msg("skipped recurring payments not found in metadata")
+		return "", fmt.Errorf("skipped recurring payments not found in metadata")
+	}
+	skipped := int(skippedRecurringPayments)
+
+	recurringPayments, err := h.detectRecurringPayments(ctx, balanceID)
+	if err != nil {
+		logger.Error().Err(err).Msg("detect recurring payments")
+		return "", fmt.Errorf("detect recurring payments: %w", err)
+	}
+	if skipped >= len(recurringPayments) {
+		logger.Info().Msg("suggested recurring payment is no longer available")
+		return model.EndFlowStep, h.finishRecurringPaymentsDetection(opts.message, "")
+	}
+
+	var resultMessage string
+	if confirmCreation {
+		balanceSubscription, err := h.createBalanceSubscriptionFromRecurringPayment(ctx, recurringPayments[skipped])
+		if err != nil {
+			logger.Error().Err(err).Msg("create balance subscription from recurring payment")
+			return "", fmt.Errorf("create balance subscription from recurring payment: %w", err)
+		}
+
+		resultMessage = fmt.Sprintf("Balance subscription successfully created!\n\n%s", balanceSubscription.GetDetails())
+		// Operations of the created subscription are linked now, so the next suggestion takes the same index.
+		recurringPayments = append(recurringPayments[:skipped], recurringPayments[skipped+1:]...)
+	} else {
+		skipped++
+		opts.stateMetaData.Add(model.SkippedRecurringPaymentsMetadataKey, skipped)
+	}
+
+	if skipped >= len(recurringPayments) {
+		return model.EndFlowStep, h.finishRecurringPaymentsDetection(opts.message, resultMessage)
+	}
+
+	suggestionMessage := recurringPayments[skipped].GetSuggestionMessage()
+	if resultMessage != "" {
+		suggestionMessage = fmt.Sprintf("%s\n\n%s", resultMessage, suggestionMessage)
+	}
+
+	return model.ConfirmSubscriptionFromRecurringPaymentFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                opts.message.GetChatID(),
+		MessageID:             opts.message.GetMessageID(),
+		InlineMessageID:       opts.message.GetInlineMessageID(),
+		UpdatedMessage:        suggestionMessage,
+		UpdatedInlineKeyboard: recurringPaymentSuggestionKeyboard,
+	})
+}
+
+func (h *handlerService) detectRecurringPayments(ctx context.Context, balanceID string) ([]model.RecurringPayment, error) {
+	operations, err := h.stores.Operation.List(ctx, ListOperationsFilter{
+		BalanceID:                  balanceID,
+		Type:                       model.OperationTypeSpending,
+		CreatedAtFrom:              time.Now().Add(-recurringPaymentsLookbackPeriod),
+		WithoutBalanceSubscription: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list operations from store: %w", err)
+	}
+
+	return model.DetectRecurringPayments(operations), nil
+}
+
+func (h *handlerService) createBalanceSubscriptionFromRecurringPayment(ctx context.Context, recurringPayment model.RecurringPayment) (*model.BalanceSubscription, error) {
+	logger := h.logger.With().Str("name", "handlerService.createBalanceSubscriptionFromRecurringPayment").Logger()
+	logger.Debug().Any("recurringPayment", recurringPayment).Msg("got args")
+
+	balanceSubscription := model.BalanceSubscription{
+		ID:         uuid.NewString(),
+		BalanceID:  recurringPayment.BalanceID,
+		CategoryID: recurringPayment.CategoryID,
+		Name:       recurringPayment.Name,
+		Amount:     recurringPayment.Amount,
+		Period:     recurringPayment.Period,
+		StartAt:    recurringPayment.GetNextPaymentDate(),
+	}
+
+	err := h.stores.BalanceSubscription.Create(ctx, balanceSubscription)
+	if err != nil {
+		logger.Error().Err(err).Msg("create balance subscription in store")
+		return nil, fmt.Errorf("create balance subscription in store: %w", err)
+	}
+
+	for _, operationID := range recurringPayment.OperationIDs {
+		operation, err := h.stores.Operation.Get(ctx, GetOperationFilter{
+			ID: operationID,
+		})
+		if err != nil {
+			logger.Error().Err(err).Msg("get operation from store")
+			return nil, fmt.Errorf("get operation from store: %w", err)
+		}
+		if operation == nil {
+			logger.Warn().Str("operationID", operationID).Msg("operation not found, skip linking it to subscription")
+			continue
+		}
+
+		operation.BalanceSubscriptionID = balanceSubscription.ID
+		err = h.stores.Operation.Update(ctx, operation.ID, operation)
+		if err != nil {
+			logger.Error().Err(err).Msg("update operation in store")
+			return nil, fmt.Errorf("update operation in store: %w", err)
+		}
+	}
+
+	go h.services.BalanceSubscriptionEngine.ScheduleOperationsCreation(ctx, balanceSubscription)
+
+	return &balanceSubscription, nil
+}
+
+func (h *handlerService) finishRecurringPaymentsDetection(message Message, resultMessage string) error {
+	finalMessage := "No more recurring payments found."
+	if resultMessage != "" {
+		finalMessage = fmt.Sprintf("%s\n\n%s", resultMessage, finalMessage)
+	}
+
+	err := h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:          message.GetChatID(),
+		MessageID:       message.GetMessageID(),
+		InlineMessageID: message.GetInlineMessageID(),
+		UpdatedMessage:  finalMessage,
+	})
+	if err != nil {
+		return fmt.Errorf("update message: %w", err)
+	}
+
+	return h.apis.Messenger.SendWithKeyboard(SendWithKeyboardOptions{
+		ChatID:   message.GetChatID(),
+		Message:  "Please choose balance subscription command to execute:",
+		Keyboard: balanceSubscriptionKeyboardRows,
+	})
+}
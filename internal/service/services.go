@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/VladPetriv/finance_bot/internal/model"
 	"github.com/VladPetriv/finance_bot/pkg/errs"
@@ -15,6 +16,7 @@ type Services struct {
 	State                     StateService
 	Currency                  CurrencyService
 	BalanceSubscriptionEngine BalanceSubscriptionEngine
+	Digest                    DigestService
 }
 
 // HandlerService provides functionally for handling bot events.
@@ -39,6 +41,15 @@ type HandlerService interface {
 
 	// HandleAction process user actions with app entities (balance, category, operation).
 	HandleAction(ctx context.Context, msg Message) error
+	// HandleScheduledOperationAction processes actions from subscription payment notification buttons (skip or snooze).
+	// It doesn't depend on the current user flow, so the state is not used.
+	HandleScheduledOperationAction(ctx context.Context, event model.Event, msg Message) error
+	// HandleBalanceSubscriptionCancelAction processes cancellation of a balance subscription from the trial end reminder button.
+	// It doesn't depend on the current user flow, so the state is not used.
+	HandleBalanceSubscriptionCancelAction(ctx context.Context, msg Message) error
+	// HandleBalanceStatisticsChartAction draws charts of balance statistics from the button attached to the statistics message.
+	// It doesn't depend on the current user flow, so the state is not used.
+	HandleBalanceStatisticsChartAction(ctx context.Context, msg Message) error
 }
 
 type flowProcessingOptions struct {
@@ -117,6 +128,12 @@ var (
 		{
 			Buttons: []string{model.BotUpdateBalanceCommand, model.BotDeleteBalanceCommand},
 		},
+		{
+			Buttons: []string{model.BotGetNetWorthCommand, model.BotCompareBalancePeriodsCommand},
+		},
+		{
+			Buttons: []string{model.BotCreateCurrencyCommand, model.BotListCurrenciesCommand, model.BotSetCurrencyRateCommand},
+		},
 		{
 			Buttons: []string{model.BotBackCommand},
 		},
@@ -129,6 +146,12 @@ var (
 		{
 			Buttons: []string{model.BotUpdateCategoryCommand, model.BotDeleteCategoryCommand},
 		},
+		{
+			Buttons: []string{model.BotMergeCategoriesCommand, model.BotApplyCategoryTemplateCommand},
+		},
+		{
+			Buttons: []string{model.BotCreateCategoryRuleCommand, model.BotListCategoryRulesCommand, model.BotDeleteCategoryRuleCommand},
+		},
 		{
 			Buttons: []string{model.BotBackCommand},
 		},
@@ -153,6 +176,12 @@ var (
 		{
 			Buttons: []string{model.BotUpdateBalanceSubscriptionCommand, model.BotDeleteBalanceSubscriptionCommand},
 		},
+		{
+			Buttons: []string{model.BotGetBalanceSubscriptionsSummaryCommand, model.BotDetectRecurringPaymentsCommand},
+		},
+		{
+			Buttons: []string{model.BotExportBalanceSubscriptionsCalendarCommand},
+		},
 		{
 			Buttons: []string{model.BotBackCommand},
 		},
@@ -173,6 +202,27 @@ var (
 				},
 			},
 		},
+		{
+			Buttons: []InlineKeyboardButton{
+				{
+					Text: model.BotUpdateUserSubscriptionNotificationLeadTimeCommand,
+				},
+			},
+		},
+		{
+			Buttons: []InlineKeyboardButton{
+				{
+					Text: model.BotUpdateUserBaseCurrencyCommand,
+				},
+			},
+		},
+		{
+			Buttons: []InlineKeyboardButton{
+				{
+					Text: model.BotUpdateUserDigestCommand,
+				},
+			},
+		},
 	}
 
 	updateOperationOptionsKeyboardForIncomingAndSpendingOperations = []InlineKeyboardRow{
@@ -204,6 +254,13 @@ var (
 				},
 			},
 		},
+		{
+			Buttons: []InlineKeyboardButton{
+				{
+					Text: model.BotCreateCategoryRuleFromOperationCommand,
+				},
+			},
+		},
 	}
 
 	updateOperationOptionsKeyboardForTransferOperations = []InlineKeyboardRow{
@@ -223,6 +280,46 @@ var (
 		},
 	}
 
+	updateCategoryOptionsKeyboard = []InlineKeyboardRow{
+		{
+			Buttons: []InlineKeyboardButton{
+				{
+					Text: model.BotUpdateCategoryNameCommand,
+				},
+				{
+					Text: model.BotUpdateCategoryKindCommand,
+				},
+			},
+		},
+		{
+			Buttons: []InlineKeyboardButton{
+				{
+					Text: model.BotArchiveCategoryCommand,
+				},
+			},
+		},
+	}
+
+	categoryKindKeyboard = []InlineKeyboardRow{
+		{
+			Buttons: []InlineKeyboardButton{
+				{
+					Text: model.BotIncomeCategoryKindCommand,
+				},
+				{
+					Text: model.BotExpenseCategoryKindCommand,
+				},
+			},
+		},
+		{
+			Buttons: []InlineKeyboardButton{
+				{
+					Text: model.BotIncomeAndExpenseCategoryKindCommand,
+				},
+			},
+		},
+	}
+
 	updateBalanceOptionsKeyboard = []InlineKeyboardRow{
 		{
 			Buttons: []InlineKeyboardButton{
@@ -276,22 +373,32 @@ var (
 				},
 			},
 		},
-	}
-
-	operationHistoryPeriodKeyboard = []InlineKeyboardRow{
 		{
 			Buttons: []InlineKeyboardButton{
 				{
-					Text: string(model.CreationPeriodDay),
+					Text: model.BotUpdateBalanceSubscriptionNotificationLeadTimeCommand,
 				},
+			},
+		},
+		{
+			Buttons: []InlineKeyboardButton{
 				{
-					Text: string(model.CreationPeriodWeek),
+					Text: model.BotUpdateBalanceSubscriptionCurrencyCommand,
 				},
+			},
+		},
+	}
+
+	recurringPaymentSuggestionKeyboard = []InlineKeyboardRow{
+		{
+			Buttons: []InlineKeyboardButton{
 				{
-					Text: string(model.CreationPeriodMonth),
+					Text: "Create subscription ✅",
+					Data: "true",
 				},
 				{
-					Text: string(model.CreationPeriodYear),
+					Text: "Skip ⏭️",
+					Data: "false",
 				},
 			},
 		},
@@ -326,8 +433,34 @@ var (
 	ErrCategoriesNotFound = errs.New("Categories not found")
 	// ErrCategoryNotFound happens when received not category from store.
 	ErrCategoryNotFound = errs.New("Category not found. Please try again!")
+	// ErrCategoryNotAvailable happens when user enters the title of archived category or category of the other kind.
+	ErrCategoryNotAvailable = errs.New("This category can't be used here. Please choose one of the suggested categories!")
 	// ErrNotEnoughCategories happens when received 0 categories after filtering.
 	ErrNotEnoughCategories = errs.New("Not enough categories.")
+	// ErrCategoriesForOperationTypeNotFound happens when user doesn't have categories suitable for the operation type.
+	ErrCategoriesForOperationTypeNotFound = errs.New("There are no categories for this type of operation. Please create one or update the kind of existing category.")
+	// ErrSameCategoryChosen happens when user chooses the same category as the replacement or merge target.
+	ErrSameCategoryChosen = errs.New("Please choose another category.")
+	// ErrInvalidCategoryPosition happens when user entered position out of the categories list.
+	ErrInvalidCategoryPosition = errs.New("Invalid position! Please enter a number from the list.")
+	// ErrArchivedCategoriesNotFound happens when user doesn't have archived categories.
+	ErrArchivedCategoriesNotFound = errs.New("You don't have any archived categories.")
+
+	// ErrCategoryTemplateNotFound happens when user entered name of unknown category template.
+	ErrCategoryTemplateNotFound = errs.New("Category template not found. Please choose one from the list!")
+
+	// ErrCategoryRulesNotFound happens when user doesn't have any category rules.
+	ErrCategoryRulesNotFound = errs.New("You don't have any created rules yet!")
+	// ErrCategoryRuleNotFound happens when category rule not found in store.
+	ErrCategoryRuleNotFound = errs.New("Rule not found. Please try again!")
+	// ErrCategoryRuleAlreadyExists happens when user already has a rule with the same pattern.
+	ErrCategoryRuleAlreadyExists = errs.New("Rule with the same text already exists.")
+	// ErrInvalidCategoryRulePattern happens when user entered empty text or invalid regular expression.
+	ErrInvalidCategoryRulePattern = errs.New("Invalid rule text! It should not be empty and longer than 100 characters, regular expression should be valid. Please try again.")
+	// ErrInvalidCategoryRuleAmountRange happens when user entered amount range in invalid format.
+	ErrInvalidCategoryRuleAmountRange = errs.New("Invalid amount range! Please use one of the formats: `100-500`, `>100` or `<500`.")
+	// ErrOperationCannotBeUsedForCategoryRule happens when operation doesn't have description or category.
+	ErrOperationCannotBeUsedForCategoryRule = errs.New("Rule can't be created from operation without description or category.")
 
 	// ErrBalanceNotFound happens when don't receive balance from store.
 	ErrBalanceNotFound = errs.New("Balance not found")
@@ -343,17 +476,40 @@ var (
 	ErrInvalidAmountFormat = errs.New("Invalid amount format! Please try again.")
 	// ErrInvalidDateFormat happens when user enters date with invalid format
 	ErrInvalidDateFormat = errs.New("Invalid date format! Please try again.")
+	// ErrInvalidPeriod happens when user enters period in invalid format or the period start is after its end.
+	ErrInvalidPeriod = errs.New("Invalid period! Please choose it from the list or check the format and try again.")
 
 	// ErrInvalidExchangeRateFormat happens when user enters exchange rate with invalid format
 	ErrInvalidExchangeRateFormat = errs.New("Invalid exchange rate format! Please try again.")
 
 	// ErrCurrencyNotFound happens when don't receive currency from store.
 	ErrCurrencyNotFound = errs.New("Currency not found. Please try to select another currency.")
+	// ErrCurrencyAlreadyExists happens when try to create private currency with the code that is already used.
+	ErrCurrencyAlreadyExists = errs.New("Currency with this code already exists. Please use another code.")
+	// ErrInvalidCurrencyCode happens when user enters currency code with invalid format.
+	ErrInvalidCurrencyCode = errs.New("Invalid currency code! It should contain from 2 to 10 latin letters or digits. Please try again.")
+	// ErrInvalidCurrencyDecimalPlaces happens when user enters invalid number of decimal places.
+	ErrInvalidCurrencyDecimalPlaces = errs.New("Invalid number of decimal places! Please enter a number from 0 to 8.")
+	// ErrPrivateCurrenciesNotFound happens when user has no private currencies.
+	ErrPrivateCurrenciesNotFound = errs.New("You don't have any created currencies yet!")
+	// ErrBaseCurrencyNotSet happens when user tries to get net worth without choosing base currency.
+	ErrBaseCurrencyNotSet = errs.New("Base currency is not set! Please choose it in the user settings.")
 
 	// ErrNoBalanceSubscriptionsFound happens when don't receive balance subscriptions from store.
 	ErrNoBalanceSubscriptionsFound = errs.New("No balance subscriptions found. Please try to select another balance.")
 	// ErrBalanceSubscriptionNotFound happens when don't receive balance subscription from store.
 	ErrBalanceSubscriptionNotFound = errs.New("Balance subscription not found. Please try to select another balance subscription.")
+
+	// ErrScheduledOperationNotFound happens when don't receive scheduled operation from store.
+	ErrScheduledOperationNotFound = errs.New("Subscription payment not found. It was probably already charged or skipped.")
+	// ErrScheduledOperationCannotBeSnoozed happens when user tries to snooze a reminder about payment that charges today.
+	ErrScheduledOperationCannotBeSnoozed = errs.New("The payment charges today, so the reminder can't be snoozed.")
+	// ErrInvalidTrialEndDate happens when user enters free trial end date that is not after the subscription start date.
+	ErrInvalidTrialEndDate = errs.New("Free trial end date must be after the subscription start date. Please try again!")
+	// ErrRecurringPaymentsNotFound happens when no recurring payments were detected in balance operations.
+	ErrRecurringPaymentsNotFound = errs.New("No recurring payments found. Please try to select another balance.")
+	// ErrUpcomingSubscriptionPaymentsNotFound happens when user has no scheduled subscription payments to export.
+	ErrUpcomingSubscriptionPaymentsNotFound = errs.New("No upcoming subscription payments found.")
 )
 
 // StateService represents a service for managing and handling complex bot flow using state.
@@ -370,10 +526,18 @@ type HandleStateOutput struct {
 
 // CurrencyService represents a service for managing and handling currencies.
 type CurrencyService interface {
-	// InitCurrencies is used to initialize currencies by parsing them from the CurrencyExchanger API and saving them to the database.
+	// InitCurrencies is used to seed currencies by fetching them from the CurrencyCatalog API and saving them to the database.
+	// Seeding is skipped when public currencies are already stored.
 	InitCurrencies(ctx context.Context) error
+	// RefreshCurrencies synchronizes stored public currencies with the ones provided by the CurrencyCatalog API on start and then periodically.
+	// Currencies that are no longer provided are marked as deprecated instead of being deleted.
+	RefreshCurrencies(ctx context.Context)
 	// Convert is used to convert operations amount from base currency to target currency
 	Convert(ctx context.Context, opts ConvertCurrencyOptions) (*money.Money, error)
+	// GetExchangeRate returns the exchange rate between currencies.
+	// Rates are cached and persisted, so past dates are resolved with the rate that was actual on that day.
+	// When the current rate is unavailable, the latest stored one is returned and marked as outdated.
+	GetExchangeRate(ctx context.Context, opts GetExchangeRateOptions) (*GetExchangeRateOutput, error)
 }
 
 // ConvertCurrencyOptions represents options for converting currency.
@@ -381,6 +545,28 @@ type ConvertCurrencyOptions struct {
 	BaseCurrency   string
 	TargetCurrency string
 	Amount         money.Money
+	// Date is used to convert amount with the rate of the specific day. When it's zero, the current rate is used.
+	Date time.Time
+	// UserID is used to resolve private currencies of the user, which are converted with their manual rates.
+	UserID string
+}
+
+// GetExchangeRateOptions represents options for getting exchange rate.
+type GetExchangeRateOptions struct {
+	BaseCurrency   string
+	TargetCurrency string
+	// Date is used to get the rate of the specific day. When it's zero, the current rate is used.
+	Date time.Time
+	// UserID is used to resolve private currencies of the user, which are converted with their manual rates.
+	UserID string
+}
+
+// GetExchangeRateOutput represents the exchange rate with the date on which it was actual.
+type GetExchangeRateOutput struct {
+	Rate money.Money
+	Date time.Time
+	// Outdated is set when the rate of the requested date is unavailable and the latest stored one is used instead.
+	Outdated bool
 }
 
 // BalanceSubscriptionEngine represents a service for processing balance subscriptions and operation creations based on their details.
@@ -397,6 +583,19 @@ type BalanceSubscriptionEngine interface {
 	ExtendScheduledOperations(ctx context.Context)
 	// CreateOperations creates operations based on balance subscriptions details.
 	CreateOperations(ctx context.Context)
-	// NotifyAboutSubscriptionPayment sends a notification a day before subscription payment.
+	// NotifyAboutSubscriptionPayment sends a notification before subscription payment.
+	// The lead time is taken from the subscription or from the user settings when the subscription doesn't override it.
 	NotifyAboutSubscriptionPayment(ctx context.Context)
+	// NotifyAboutTrialEnd reminds users that the free trial converts into a paid subscription,
+	// so they could cancel the subscription before the first charge.
+	// The lead time is taken from the subscription or from the user settings when the subscription doesn't override it.
+	NotifyAboutTrialEnd(ctx context.Context)
+}
+
+// DigestService represents a service for sending periodic digests with the summary of user finances.
+type DigestService interface {
+	// SendDigests sends weekly or monthly digests to users who enabled them, when it's time to send them.
+	// The digest contains income, spending by categories and the biggest operations for the previous period
+	// of every user balance and subscription charges expected until the next digest.
+	SendDigests(ctx context.Context)
 }
@@ -56,6 +56,11 @@ func (s stateService) HandleState(ctx context.Context, message Message) (*Handle
 	event := getEventFromMsg(user, message)
 	logger.Debug().Any("event", event).Msg("got event based on bot message")
 
+	// Notification actions are not related to any flow, so the current state should stay untouched.
+	if s.isNotificationActionEvent(event) {
+		return &HandleStateOutput{Event: event}, nil
+	}
+
 	state, err := s.stores.State.Get(ctx, GetStateFilter{
 		UserID: message.GetSenderName(),
 	})
@@ -131,6 +136,15 @@ func (s stateService) isSimpleEvent(event model.Event) bool {
 	}, event)
 }
 
+func (s stateService) isNotificationActionEvent(event model.Event) bool {
+	return slices.Contains([]model.Event{
+		model.SkipScheduledOperationEvent,
+		model.SnoozeScheduledOperationEvent,
+		model.CancelBalanceSubscriptionEvent,
+		model.BalanceStatisticsChartEvent,
+	}, event)
+}
+
 func (s stateService) handleSimpleEvent(ctx context.Context, message Message, state *model.State, event model.Event) (*HandleStateOutput, error) {
 	logger := s.logger.With().Str("name", "stateService.handleSimpleEvent").Logger()
 
@@ -15,7 +15,9 @@ type Stores struct {
 	User                UserStore
 	State               StateStore
 	Currency            CurrencyStore
+	ExchangeRate        ExchangeRateStore
 	BalanceSubscription BalanceSubscriptionStore
+	CategoryRule        CategoryRuleStore
 }
 
 // UserStore provides functionality for work with users store.
@@ -26,6 +28,8 @@ type UserStore interface {
 	Create(ctx context.Context, user *model.User) error
 	// GetByUsername returns a user from store by username.
 	Get(ctx context.Context, filters GetUserFilter) (*model.User, error)
+	// List returns a list of users from store.
+	List(ctx context.Context, filter ListUsersFilter) ([]model.User, error)
 	// CreateSettings creates a new user settings in store.
 	CreateSettings(ctx context.Context, settings *model.UserSettings) error
 	// UpdateSettings updates user settings in store.
@@ -40,6 +44,12 @@ type GetUserFilter struct {
 	PreloadSettings bool
 }
 
+// ListUsersFilter represents a filters for List method.
+type ListUsersFilter struct {
+	// WithEnabledDigest returns only users who receive periodic digest.
+	WithEnabledDigest bool
+}
+
 // BalanceStore provides functionality for work with balance store.
 //
 //go:generate mockery --dir . --name BalanceStore --output ./mocks
@@ -92,11 +102,15 @@ type GetOperationFilter struct {
 
 // ListOperationsFilter represents filters for list operations from store.
 type ListOperationsFilter struct {
-	BalanceID            string
-	CreationPeriod       model.CreationPeriod
-	Month                model.Month
-	OrderByCreatedAtDesc bool
-	Pagination           *Pagination
+	BalanceID                  string
+	CategoryID                 string
+	Type                       model.OperationType
+	CreationPeriod             model.CreationPeriod
+	CreatedAtFrom              time.Time
+	CreatedAtTo                time.Time
+	WithoutBalanceSubscription bool
+	OrderByCreatedAtDesc       bool
+	Pagination                 *Pagination
 }
 
 // CategoryStore provides functionality for work with categories store.
@@ -111,13 +125,18 @@ type CategoryStore interface {
 	Create(ctx context.Context, category *model.Category) error
 	// Update  updates category in store.
 	Update(ctx context.Context, category *model.Category) error
-	// Delete delete category from store.
+	// Delete deletes category from store together with its rules, sub-categories of the category become top-level ones.
+	// Everything is done in a single transaction.
 	Delete(ctx context.Context, categoryID string) error
+	// Merge moves operations, balance subscriptions, rules and sub-categories of the source category
+	// to the target one and deletes the source category in a single transaction.
+	Merge(ctx context.Context, sourceCategoryID, targetCategoryID string) error
 }
 
 // ListCategoriesFilter represents a filters for GetAll method.
 type ListCategoriesFilter struct {
-	UserID string
+	UserID   string
+	ParentID string
 }
 
 // GetCategoryFilter represents a filters for Get method.
@@ -127,6 +146,31 @@ type GetCategoryFilter struct {
 	Title  string
 }
 
+// CategoryRuleStore provides functionality for work with category rules store.
+//
+//go:generate mockery --dir . --name CategoryRuleStore --output ./mocks
+type CategoryRuleStore interface {
+	// Create creates a new category rule in store.
+	Create(ctx context.Context, rule *model.CategoryRule) error
+	// Get returns a category rule by filter.
+	Get(ctx context.Context, filter GetCategoryRuleFilter) (*model.CategoryRule, error)
+	// List returns a list of category rules ordered by creation time.
+	List(ctx context.Context, filter ListCategoryRulesFilter) ([]model.CategoryRule, error)
+	// Delete deletes category rule from store.
+	Delete(ctx context.Context, ruleID string) error
+}
+
+// GetCategoryRuleFilter represents a filters for Get method.
+type GetCategoryRuleFilter struct {
+	ID     string
+	UserID string
+}
+
+// ListCategoryRulesFilter represents a filters for List method.
+type ListCategoryRulesFilter struct {
+	UserID string
+}
+
 // StateStore represents a store for user states.
 type StateStore interface {
 	// Create creates a new state in store.
@@ -147,24 +191,64 @@ type GetStateFilter struct {
 // CurrencyStore represents a store for currencies.
 type CurrencyStore interface {
 	// Create creates a new currency in store(only in case if currency not exists).
-	// The check for existence is based on currency code(model.Currency.Code).
+	// The check for existence is based on currency code(model.Currency.Code) and owner of the currency(model.Currency.UserID).
 	CreateIfNotExists(ctx context.Context, currency *model.Currency) error
-	// Count returns a count of all currencies from store.
-	Count(ctx context.Context) (int, error)
-	// List returns a list of all currencies from store.
+	// Update updates name, symbol, decimal places and deprecation of the currency.
+	Update(ctx context.Context, currency *model.Currency) error
+	// Count returns a count of currencies from store.
+	Count(ctx context.Context, filter ListCurrenciesFilter) (int, error)
+	// List returns a list of currencies from store.
 	List(ctx context.Context, filter ListCurrenciesFilter) ([]model.Currency, error)
-	// Get returns a currency from store by id.
+	// Get returns a currency from store by id or code.
 	Get(ctx context.Context, filter GetCurrencyFilter) (*model.Currency, error)
+
+	// UpsertRate creates a manual rate of the private currency or updates the existing one for the same target currency and effective date.
+	UpsertRate(ctx context.Context, rate model.CurrencyRate) error
+	// ListRates returns manual rates of private currencies ordered by effective date.
+	ListRates(ctx context.Context, filter ListCurrencyRatesFilter) ([]model.CurrencyRate, error)
 }
 
 // ListCurrenciesFilter represents a filter for CurrencyStore.List method.
 type ListCurrenciesFilter struct {
 	Pagination *Pagination
+	// UserID is used to include private currencies of the user, public currencies are always included.
+	UserID string
+	// OnlyPrivate excludes public currencies, so only private currencies of the user are returned.
+	OnlyPrivate bool
+	// ExcludeDeprecated excludes currencies that are no longer provided by the currency exchanger.
+	ExcludeDeprecated bool
 }
 
 // GetCurrencyFilter represents a filter for CurrencyStore.Get method.
 type GetCurrencyFilter struct {
-	ID string
+	ID   string
+	Code string
+	// UserID limits the search to public currencies and private currencies of the user.
+	// When it's empty, currencies searched by code are limited to public ones.
+	UserID string
+}
+
+// ListCurrencyRatesFilter represents a filter for CurrencyStore.ListRates method.
+type ListCurrencyRatesFilter struct {
+	CurrencyIDs []string
+}
+
+// ExchangeRateStore provides functionality for work with exchange rates store.
+//
+//go:generate mockery --dir . --name ExchangeRateStore --output ./mocks
+type ExchangeRateStore interface {
+	// Upsert creates a new exchange rate or updates the existing one for the same currencies and date.
+	Upsert(ctx context.Context, exchangeRate model.ExchangeRate) error
+	// Get returns the latest exchange rate from store based on input filter.
+	Get(ctx context.Context, filter GetExchangeRateFilter) (*model.ExchangeRate, error)
+}
+
+// GetExchangeRateFilter represents a filter for exchange rate store.Get method.
+type GetExchangeRateFilter struct {
+	BaseCurrency   string
+	TargetCurrency string
+	// DateTo is used to get the latest rate that was actual on the provided date.
+	DateTo time.Time
 }
 
 // BalanceSubscriptionStore represents a store for balance subscriptions.
@@ -179,24 +263,36 @@ type BalanceSubscriptionStore interface {
 	Count(ctx context.Context, filter ListBalanceSubscriptionFilter) (int, error)
 	// List returns a list of all balance subscriptions from store based on filter.
 	List(ctx context.Context, filter ListBalanceSubscriptionFilter) ([]model.BalanceSubscription, error)
+	// GetScheduledOperation retrieves scheduled operation from store based on input filter.
+	GetScheduledOperation(ctx context.Context, filter GetScheduledOperationFilter) (*model.ScheduledOperation, error)
 	// ListScheduledOperation returns a list of all scheduled operation based on input filters.
 	ListScheduledOperation(ctx context.Context, filter ListScheduledOperation) ([]model.ScheduledOperation, error)
 	// Update updates balance subscription model in store.
 	Update(ctx context.Context, subscription *model.BalanceSubscription) error
 	// MarkScheduledOperationAsNotified marks a scheduled operation as notified in store.
 	MarkScheduledOperationAsNotified(ctx context.Context, scheduledOperationID string) error
+	// MarkTrialReminderAsSent marks that the user was reminded about the balance subscription trial end.
+	MarkTrialReminderAsSent(ctx context.Context, subscriptionID string) error
+	// SnoozeScheduledOperation postpones the notification about scheduled operation until the provided time.
+	SnoozeScheduledOperation(ctx context.Context, scheduledOperationID string, snoozedUntil time.Time) error
 	// Delete deletes balance subscription from store.
 	Delete(ctx context.Context, subscriptionID string) error
 	// DeleteScheduledOperation deletes scheduled operation from store.
 	DeleteScheduledOperation(ctx context.Context, id string) error
+	// CreateChange creates a new balance subscription change in store.
+	CreateChange(ctx context.Context, change model.BalanceSubscriptionChange) error
+	// ListChanges returns a list of balance subscription changes ordered by creation date based on input filter.
+	ListChanges(ctx context.Context, filter ListBalanceSubscriptionChangesFilter) ([]model.BalanceSubscriptionChange, error)
 }
 
 // ListBalanceSubscriptionFilter represents a filter for store.List and store.Count methods.
 type ListBalanceSubscriptionFilter struct {
 	BalanceID                                                  string
+	CategoryID                                                 string
 	OrderByCreatedAtDesc                                       bool
 	SubscriptionsWithLastScheduledOperation                    bool
 	SubscriptionsForUserWhoHasEnabledSubscriptionNotifications bool
+	SubscriptionsWithPendingTrialReminder                      bool
 	Pagination                                                 *Pagination
 }
 
@@ -206,6 +302,11 @@ type GetBalanceSubscriptionFilter struct {
 	Name string
 }
 
+// GetScheduledOperationFilter represents a filter for store.GetScheduledOperation method.
+type GetScheduledOperationFilter struct {
+	ID string
+}
+
 // ListScheduledOperation represents a filter for store.ListScheduledOperation method.
 type ListScheduledOperation struct {
 	BetweenFilter          *BetweenFilter
@@ -213,6 +314,12 @@ type ListScheduledOperation struct {
 	NotNotified            bool
 }
 
+// ListBalanceSubscriptionChangesFilter represents a filter for store.ListChanges method.
+type ListBalanceSubscriptionChangesFilter struct {
+	SubscriptionIDs []string
+	CreatedAtFrom   time.Time
+}
+
 // BetweenFilter represents a time range filter with inclusive From and To boundaries
 // for filtering data between two points in time.
 type BetweenFilter struct {
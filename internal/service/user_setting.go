@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/VladPetriv/finance_bot/internal/model"
 )
@@ -76,6 +77,46 @@ func (h *handlerService) handleChooseUpdateUserSettingsOptionFlowStep(ctx contex
 			UpdatedInlineKeyboard:   []InlineKeyboardRow{toggleSettingResult.KeyboardRow},
 			UpdatedMessage:          toggleSettingResult.OutputMessage,
 		})
+	case model.BotUpdateUserSubscriptionNotificationLeadTimeCommand:
+		return model.UpdateSubscriptionNotificationLeadTimeUserSettingFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+			ChatID:                  opts.message.GetChatID(),
+			MessageID:               opts.message.GetMessageID(),
+			InlineMessageID:         opts.message.GetInlineMessageID(),
+			FormatMessageInMarkDown: true,
+			UpdatedInlineKeyboard:   getNotificationLeadTimeKeyboard(false),
+			UpdatedMessage: fmt.Sprintf(
+				"⏰ *Notification Lead Time Settings*\nCurrent lead time: `%s`\nChoose when you want to be notified about subscription payments:",
+				opts.user.Settings.SubscriptionNotificationLeadTime,
+			),
+		})
+	case model.BotUpdateUserBaseCurrencyCommand:
+		opts.stateMetaData.Add(model.PageMetadataKey, firstPage)
+		currenciesKeyboard, err := h.getCurrenciesKeyboard(ctx, opts.user.ID, firstPage)
+		if err != nil {
+			logger.Error().Err(err).Msg("get currencies keyboard")
+			return "", fmt.Errorf("get currencies keyboard: %w", err)
+		}
+
+		return model.UpdateBaseCurrencyUserSettingFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+			ChatID:                  opts.message.GetChatID(),
+			MessageID:               opts.message.GetMessageID(),
+			InlineMessageID:         opts.message.GetInlineMessageID(),
+			FormatMessageInMarkDown: true,
+			UpdatedInlineKeyboard:   currenciesKeyboard,
+			UpdatedMessage:          "💱 *Base Currency Settings*\nChoose currency in which the net worth will be calculated:",
+		})
+	case model.BotUpdateUserDigestCommand:
+		return model.UpdateDigestFrequencyUserSettingFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+			ChatID:                  opts.message.GetChatID(),
+			MessageID:               opts.message.GetMessageID(),
+			InlineMessageID:         opts.message.GetInlineMessageID(),
+			FormatMessageInMarkDown: true,
+			UpdatedInlineKeyboard:   getDigestFrequencyKeyboard(),
+			UpdatedMessage: fmt.Sprintf(
+				"📬 *Digest Settings*\nCurrent digest: `%s`\nChoose how often you want to receive the summary of income, spending and upcoming subscription charges:",
+				opts.user.Settings.DigestFrequency,
+			),
+		})
 	default:
 		logger.Debug().Str("option", opts.message.GetText()).Msg("received unknown update user settings option")
 		return "", fmt.Errorf("received unknown update user settings option: %s", opts.message.GetText())
@@ -141,3 +182,180 @@ func (h *handlerService) handleUpdateSubscriptionNotificationUserSettingFlowStep
 		UpdatedInlineKeyboard:   updateUserSettingsOptionsKeyboard,
 	})
 }
+
+func (h *handlerService) handleUpdateSubscriptionNotificationLeadTimeUserSettingFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleUpdateSubscriptionNotificationLeadTimeUserSettingFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	leadTime, err := model.ParseNotificationLeadTime(opts.message.GetText())
+	if err != nil {
+		logger.Error().Err(err).Msg("parse notification lead time from input")
+		return "", fmt.Errorf("parse notification lead time: %w", err)
+	}
+
+	settings := opts.user.Settings
+	settings.SubscriptionNotificationLeadTime = leadTime
+
+	err = h.stores.User.UpdateSettings(ctx, settings)
+	if err != nil {
+		logger.Error().Err(err).Msg("update user settings in store")
+		return "", fmt.Errorf("update user settings in store: %w", err)
+	}
+
+	return model.ChooseUpdateUserSettingsOptionFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                  opts.message.GetChatID(),
+		MessageID:               opts.message.GetMessageID(),
+		InlineMessageID:         opts.message.GetInlineMessageID(),
+		FormatMessageInMarkDown: true,
+		UpdatedMessage: fmt.Sprintf(
+			"Notification lead time successfully updated to *%s*\nPlease choose other update user settings option or finish action by canceling it!",
+			leadTime,
+		),
+		UpdatedInlineKeyboard: updateUserSettingsOptionsKeyboard,
+	})
+}
+
+func (h *handlerService) handleUpdateBaseCurrencyUserSettingFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleUpdateBaseCurrencyUserSettingFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	messageText := opts.message.GetText()
+	if isPaginationNeeded(messageText) {
+		nextPage := calculateNextPage(messageText, opts.stateMetaData)
+		opts.stateMetaData.Add(model.PageMetadataKey, nextPage)
+
+		currenciesKeyboard, err := h.getCurrenciesKeyboard(ctx, opts.user.ID, nextPage)
+		if err != nil {
+			logger.Error().Err(err).Msg("get currencies keyboard")
+			return "", fmt.Errorf("get currencies keyboard: %w", err)
+		}
+
+		return model.UpdateBaseCurrencyUserSettingFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+			ChatID:                  opts.message.GetChatID(),
+			MessageID:               opts.message.GetMessageID(),
+			InlineMessageID:         opts.message.GetInlineMessageID(),
+			FormatMessageInMarkDown: true,
+			UpdatedInlineKeyboard:   currenciesKeyboard,
+			UpdatedMessage:          "💱 *Base Currency Settings*\nChoose currency in which the net worth will be calculated:",
+		})
+	}
+
+	currency, err := h.stores.Currency.Get(ctx, GetCurrencyFilter{
+		ID:     messageText,
+		UserID: opts.user.ID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("get currency from store")
+		return "", fmt.Errorf("get currency from store: %w", err)
+	}
+	if currency == nil {
+		logger.Info().Msg("currency not found")
+		return model.UpdateBaseCurrencyUserSettingFlowStep, ErrCurrencyNotFound
+	}
+
+	settings := opts.user.Settings
+	settings.BaseCurrencyCode = currency.Code
+
+	err = h.stores.User.UpdateSettings(ctx, settings)
+	if err != nil {
+		logger.Error().Err(err).Msg("update user settings in store")
+		return "", fmt.Errorf("update user settings in store: %w", err)
+	}
+
+	return model.ChooseUpdateUserSettingsOptionFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                  opts.message.GetChatID(),
+		MessageID:               opts.message.GetMessageID(),
+		InlineMessageID:         opts.message.GetInlineMessageID(),
+		FormatMessageInMarkDown: true,
+		UpdatedMessage: fmt.Sprintf(
+			"Base currency successfully updated to *%s*\nPlease choose other update user settings option or finish action by canceling it!",
+			currency.Code,
+		),
+		UpdatedInlineKeyboard: updateUserSettingsOptionsKeyboard,
+	})
+}
+
+func (h *handlerService) handleUpdateDigestFrequencyUserSettingFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleUpdateDigestFrequencyUserSettingFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	frequency, err := model.ParseDigestFrequency(opts.message.GetText())
+	if err != nil {
+		logger.Error().Err(err).Msg("parse digest frequency from input")
+		return "", fmt.Errorf("parse digest frequency: %w", err)
+	}
+
+	if frequency != model.DigestFrequencyOff {
+		opts.stateMetaData.Add(model.DigestFrequencyMetadataKey, string(frequency))
+
+		return model.UpdateDigestSendTimeUserSettingFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+			ChatID:                  opts.message.GetChatID(),
+			MessageID:               opts.message.GetMessageID(),
+			InlineMessageID:         opts.message.GetInlineMessageID(),
+			FormatMessageInMarkDown: true,
+			UpdatedInlineKeyboard:   getDigestSendTimeKeyboard(),
+			UpdatedMessage:          "⏰ Choose the time (UTC) when the digest will be sent:",
+		})
+	}
+
+	settings := opts.user.Settings
+	settings.DigestFrequency = frequency
+
+	err = h.stores.User.UpdateSettings(ctx, settings)
+	if err != nil {
+		logger.Error().Err(err).Msg("update user settings in store")
+		return "", fmt.Errorf("update user settings in store: %w", err)
+	}
+
+	return model.ChooseUpdateUserSettingsOptionFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                  opts.message.GetChatID(),
+		MessageID:               opts.message.GetMessageID(),
+		InlineMessageID:         opts.message.GetInlineMessageID(),
+		FormatMessageInMarkDown: true,
+		UpdatedMessage:          "Digest successfully *disabled*\nPlease choose other update user settings option or finish action by canceling it!",
+		UpdatedInlineKeyboard:   updateUserSettingsOptionsKeyboard,
+	})
+}
+
+func (h *handlerService) handleUpdateDigestSendTimeUserSettingFlowStep(ctx context.Context, opts flowProcessingOptions) (model.FlowStep, error) {
+	logger := h.logger.With().Str("name", "handlerService.handleUpdateDigestSendTimeUserSettingFlowStep").Logger()
+	logger.Debug().Any("opts", opts).Msg("got args")
+
+	sendTime, err := model.ParseDigestSendTime(opts.message.GetText())
+	if err != nil {
+		logger.Error().Err(err).Msg("parse digest send time from input")
+		return "", fmt.Errorf("parse digest send time: %w", err)
+	}
+
+	frequency, ok := model.GetTypedFromMetadata[string](opts.stateMetaData, model.DigestFrequencyMetadataKey)
+	if !ok {
+		logger.Error().Msg("digest frequency not found in metadata")
+		return "", fmt.Errorf("digest frequency not found")
+	}
+
+	// The digest for the period that already ended is not sent right after enabling it,
+	// the user receives the first digest at the next scheduled time.
+	now := time.Now()
+	settings := opts.user.Settings
+	settings.DigestFrequency = model.DigestFrequency(frequency)
+	settings.DigestSendTime = sendTime
+	settings.LastDigestSentAt = &now
+
+	err = h.stores.User.UpdateSettings(ctx, settings)
+	if err != nil {
+		logger.Error().Err(err).Msg("update user settings in store")
+		return "", fmt.Errorf("update user settings in store: %w", err)
+	}
+
+	return model.ChooseUpdateUserSettingsOptionFlowStep, h.apis.Messenger.UpdateMessage(UpdateMessageOptions{
+		ChatID:                  opts.message.GetChatID(),
+		MessageID:               opts.message.GetMessageID(),
+		InlineMessageID:         opts.message.GetInlineMessageID(),
+		FormatMessageInMarkDown: true,
+		UpdatedMessage: fmt.Sprintf(
+			"Digest successfully updated to *%s at %s*\nPlease choose other update user settings option or finish action by canceling it!",
+			settings.DigestFrequency, sendTime,
+		),
+		UpdatedInlineKeyboard: updateUserSettingsOptionsKeyboard,
+	})
+}
@@ -66,7 +66,7 @@ func (b *balanceStore) Get(ctx context.Context, filter service.GetBalanceFilter)
 
 	if filter.PreloadCurrency && balance.CurrencyID != "" {
 		var currency model.Currency
-		err = b.DB.GetContext(ctx, &currency, "SELECT id, name, code, symbol FROM currencies WHERE id = $1;", balance.CurrencyID)
+		err = b.DB.GetContext(ctx, &currency, "SELECT id, name, code, symbol, user_id, decimal_places, deprecated FROM currencies WHERE id = $1;", balance.CurrencyID)
 		if err != nil {
 			return nil, err
 		}
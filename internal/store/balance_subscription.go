@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/VladPetriv/finance_bot/internal/model"
@@ -16,6 +17,14 @@ type balanceSubscriptionStore struct {
 	db *database.PostgreSQL
 }
 
+// balanceSubscriptionCurrencyIDColumn is used to select currency_id, since subscriptions that are charged
+// in the balance currency have NULL value there.
+const balanceSubscriptionCurrencyIDColumn = "COALESCE(currency_id, '') AS currency_id"
+
+// balanceSubscriptionAmountAfterTrialColumn is used to select amount_after_trial, since subscriptions without
+// a separate amount after the free trial have NULL value there.
+const balanceSubscriptionAmountAfterTrialColumn = "COALESCE(amount_after_trial, '') AS amount_after_trial"
+
 // NewBalanceSubscription creates a new instance of balance subscription store.
 func NewBalanceSubscription(db *database.PostgreSQL) *balanceSubscriptionStore {
 	return &balanceSubscriptionStore{
@@ -27,10 +36,10 @@ func (b *balanceSubscriptionStore) Create(ctx context.Context, subscription mode
 	_, err := b.db.DB.ExecContext(
 		ctx,
 		`INSERT INTO
-			balance_subscriptions (id, balance_id, category_id, name, amount, period, start_at)
+			balance_subscriptions (id, balance_id, category_id, currency_id, name, amount, period, notification_lead_time, trial_end_at, amount_after_trial, start_at)
     	VALUES
-     		($1, $2, $3, $4, $5, $6, $7);`,
-		subscription.ID, subscription.BalanceID, subscription.CategoryID, subscription.Name, subscription.Amount, subscription.Period, subscription.StartAt,
+     		($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9, NULLIF($10, ''), $11);`,
+		subscription.ID, subscription.BalanceID, subscription.CategoryID, subscription.CurrencyID, subscription.Name, subscription.Amount, subscription.Period, subscription.NotificationLeadTime, subscription.TrialEndAt, subscription.AmountAfterTrial, subscription.StartAt,
 	)
 	return err
 }
@@ -51,7 +60,10 @@ func (b *balanceSubscriptionStore) Get(ctx context.Context, filter service.GetBa
 	stmt := sq.
 		StatementBuilder.
 		PlaceholderFormat(sq.Dollar).
-		Select("id", "balance_id", "category_id", "name", "amount", "period", "start_at", "created_at", "updated_at").
+		Select(
+			"id", "balance_id", "category_id", balanceSubscriptionCurrencyIDColumn, "name", "amount", "period", "notification_lead_time",
+			"trial_end_at", balanceSubscriptionAmountAfterTrialColumn, "trial_reminder_sent", "start_at", "created_at", "updated_at",
+		).
 		From("balance_subscriptions")
 
 	if filter.ID != "" {
@@ -129,7 +141,10 @@ func applyListBalanceSubscriptionFilter(options applyListBalanceSubscriptionOpti
 	if options.listQuery {
 		expectedColumns = []string{
 			"balance_subscriptions.id", "balance_subscriptions.balance_id", "balance_subscriptions.category_id",
+			"COALESCE(balance_subscriptions.currency_id, '') AS currency_id",
 			"balance_subscriptions.name", "balance_subscriptions.amount", "balance_subscriptions.period",
+			"balance_subscriptions.notification_lead_time", "balance_subscriptions.trial_end_at",
+			"COALESCE(balance_subscriptions.amount_after_trial, '') AS amount_after_trial", "balance_subscriptions.trial_reminder_sent",
 			"balance_subscriptions.start_at", "balance_subscriptions.created_at", "balance_subscriptions.updated_at",
 		}
 	}
@@ -144,6 +159,10 @@ func applyListBalanceSubscriptionFilter(options applyListBalanceSubscriptionOpti
 		stmt = stmt.Where(sq.Eq{"balance_subscriptions.balance_id": filter.BalanceID})
 	}
 
+	if filter.CategoryID != "" {
+		stmt = stmt.Where(sq.Eq{"balance_subscriptions.category_id": filter.CategoryID})
+	}
+
 	if filter.SubscriptionsWithLastScheduledOperation {
 		stmt = stmt.Join("scheduled_operations ON scheduled_operations.subscription_id = balance_subscriptions.id").
 			GroupBy("balance_subscriptions.id").
@@ -157,6 +176,18 @@ func applyListBalanceSubscriptionFilter(options applyListBalanceSubscriptionOpti
 			Where(sq.Eq{"user_settings.notify_about_subscription_payments": true})
 	}
 
+	if filter.SubscriptionsWithPendingTrialReminder {
+		// Trial reminders could be sent until the end of the trial end day.
+		now := time.Now().UTC()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+		stmt = stmt.Where(sq.And{
+			sq.NotEq{"balance_subscriptions.trial_end_at": nil},
+			sq.GtOrEq{"balance_subscriptions.trial_end_at": today},
+			sq.Eq{"balance_subscriptions.trial_reminder_sent": false},
+		})
+	}
+
 	if filter.Pagination != nil {
 		stmt = applyLimitAndOffsetForStatement(stmt, filter.Pagination)
 	}
@@ -173,7 +204,10 @@ func (b *balanceSubscriptionStore) ListScheduledOperation(ctx context.Context, f
 	stmt := sq.
 		StatementBuilder.
 		PlaceholderFormat(sq.Dollar).
-		Select("scheduled_operations.id", "scheduled_operations.subscription_id", "scheduled_operations.notified", "scheduled_operations.creation_date").
+		Select(
+			"scheduled_operations.id", "scheduled_operations.subscription_id", "scheduled_operations.notified",
+			"scheduled_operations.creation_date", "scheduled_operations.snoozed_until",
+		).
 		From("scheduled_operations")
 
 	if filter.BetweenFilter != nil {
@@ -203,6 +237,35 @@ func (b *balanceSubscriptionStore) ListScheduledOperation(ctx context.Context, f
 	return scheduledOperations, nil
 }
 
+func (b *balanceSubscriptionStore) GetScheduledOperation(ctx context.Context, filter service.GetScheduledOperationFilter) (*model.ScheduledOperation, error) {
+	stmt := sq.
+		StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select("id", "subscription_id", "notified", "creation_date", "snoozed_until").
+		From("scheduled_operations")
+
+	if filter.ID != "" {
+		stmt = stmt.Where(sq.Eq{"id": filter.ID})
+	}
+
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build get scheduled operation query: %w", err)
+	}
+
+	var scheduledOperation model.ScheduledOperation
+	err = b.db.DB.GetContext(ctx, &scheduledOperation, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &scheduledOperation, nil
+}
+
 func (b *balanceSubscriptionStore) Update(ctx context.Context, subscription *model.BalanceSubscription) error {
 	_, err := b.db.DB.ExecContext(
 		ctx,
@@ -210,14 +273,17 @@ func (b *balanceSubscriptionStore) Update(ctx context.Context, subscription *mod
 		UPDATE balance_subscriptions
 		SET
 			category_id = $1,
-			name = $2,
-			amount = $3,
-			period = $4,
-			start_at = $5,
+			currency_id = NULLIF($2, ''),
+			name = $3,
+			amount = $4,
+			period = $5,
+			notification_lead_time = $6,
+			amount_after_trial = NULLIF($7, ''),
+			start_at = $8,
 			updated_at = NOW()
 		WHERE
-			id = $6;`,
-		subscription.CategoryID, subscription.Name, subscription.Amount, subscription.Period, subscription.StartAt, subscription.ID,
+			id = $9;`,
+		subscription.CategoryID, subscription.CurrencyID, subscription.Name, subscription.Amount, subscription.Period, subscription.NotificationLeadTime, subscription.AmountAfterTrial, subscription.StartAt, subscription.ID,
 	)
 	return err
 }
@@ -236,6 +302,35 @@ func (b *balanceSubscriptionStore) MarkScheduledOperationAsNotified(ctx context.
 	return err
 }
 
+func (b *balanceSubscriptionStore) MarkTrialReminderAsSent(ctx context.Context, subscriptionID string) error {
+	_, err := b.db.DB.ExecContext(
+		ctx,
+		`
+		UPDATE balance_subscriptions
+		SET
+			trial_reminder_sent = true
+		WHERE
+			id = $1;`,
+		subscriptionID,
+	)
+	return err
+}
+
+func (b *balanceSubscriptionStore) SnoozeScheduledOperation(ctx context.Context, scheduledOperationID string, snoozedUntil time.Time) error {
+	_, err := b.db.DB.ExecContext(
+		ctx,
+		`
+		UPDATE scheduled_operations
+		SET
+			notified = false,
+			snoozed_until = $1
+		WHERE
+			id = $2;`,
+		snoozedUntil, scheduledOperationID,
+	)
+	return err
+}
+
 func (b *balanceSubscriptionStore) Delete(ctx context.Context, subscriptionID string) error {
 	_, err := b.db.DB.ExecContext(ctx, "DELETE FROM balance_subscriptions WHERE id = $1;", subscriptionID)
 	return err
@@ -245,3 +340,44 @@ func (b *balanceSubscriptionStore) DeleteScheduledOperation(ctx context.Context,
 	_, err := b.db.DB.ExecContext(ctx, "DELETE FROM scheduled_operations WHERE id = $1;", shceduledOperationID)
 	return err
 }
+
+func (b *balanceSubscriptionStore) CreateChange(ctx context.Context, change model.BalanceSubscriptionChange) error {
+	_, err := b.db.DB.ExecContext(
+		ctx,
+		`INSERT INTO
+			balance_subscription_changes (id, subscription_id, previous_amount, new_amount, previous_period, new_period, previous_currency_id, new_currency_id)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8);`,
+		change.ID, change.SubscriptionID, change.PreviousAmount, change.NewAmount, change.PreviousPeriod, change.NewPeriod, change.PreviousCurrencyID, change.NewCurrencyID,
+	)
+	return err
+}
+
+func (b *balanceSubscriptionStore) ListChanges(ctx context.Context, filter service.ListBalanceSubscriptionChangesFilter) ([]model.BalanceSubscriptionChange, error) {
+	stmt := sq.
+		StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select("id", "subscription_id", "previous_amount", "new_amount", "previous_period", "new_period", "previous_currency_id", "new_currency_id", "created_at").
+		From("balance_subscription_changes").
+		OrderBy("created_at")
+
+	if len(filter.SubscriptionIDs) != 0 {
+		stmt = stmt.Where(sq.Eq{"subscription_id": filter.SubscriptionIDs})
+	}
+	if !filter.CreatedAtFrom.IsZero() {
+		stmt = stmt.Where(sq.GtOrEq{"created_at": filter.CreatedAtFrom})
+	}
+
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build list balance subscription changes query: %w", err)
+	}
+
+	var changes []model.BalanceSubscriptionChange
+	err = b.db.DB.SelectContext(ctx, &changes, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
@@ -2,6 +2,7 @@ package store_test
 
 import (
 	"context"
+	"slices"
 	"testing"
 	"time"
 
@@ -166,6 +167,7 @@ func TestBalanceSubscription_Get(t *testing.T) {
 	currencyID := uuid.NewString()
 	categoryID := uuid.NewString()
 	balanceSubscriptionID := uuid.NewString()
+	trialBalanceSubscriptionID := uuid.NewString()
 
 	err := currencyStore.CreateIfNotExists(ctx, &model.Currency{
 		ID:   currencyID,
@@ -233,6 +235,30 @@ func TestBalanceSubscription_Get(t *testing.T) {
 				Period:     model.SubscriptionPeriodMonthly,
 			},
 		},
+		{
+			desc: "balance subscription with amount after trial received by id",
+			preconditions: &model.BalanceSubscription{
+				ID:               trialBalanceSubscriptionID,
+				BalanceID:        balanceID,
+				CategoryID:       categoryID,
+				Name:             "test_trial",
+				Amount:           amount100,
+				AmountAfterTrial: "150",
+				Period:           model.SubscriptionPeriodMonthly,
+			},
+			args: service.GetBalanceSubscriptionFilter{
+				ID: trialBalanceSubscriptionID,
+			},
+			expected: &model.BalanceSubscription{
+				ID:               trialBalanceSubscriptionID,
+				BalanceID:        balanceID,
+				CategoryID:       categoryID,
+				Name:             "test_trial",
+				Amount:           amount100,
+				AmountAfterTrial: "150",
+				Period:           model.SubscriptionPeriodMonthly,
+			},
+		},
 		{
 			desc: "balance subscription not found",
 			args: service.GetBalanceSubscriptionFilter{
@@ -271,6 +297,7 @@ func TestBalanceSubscription_Get(t *testing.T) {
 			assert.Equal(t, tc.expected.CategoryID, actual.CategoryID)
 			assert.Equal(t, tc.expected.Name, actual.Name)
 			assert.Equal(t, tc.expected.Amount, actual.Amount)
+			assert.Equal(t, tc.expected.AmountAfterTrial, actual.AmountAfterTrial)
 			assert.Equal(t, tc.expected.Period, actual.Period)
 		})
 	}
@@ -1386,8 +1413,254 @@ func TestBalanceSubscription_DeleteScheduledOperation(t *testing.T) {
 	}
 }
 
+func TestBalanceSubscription_SnoozeScheduledOperation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background() //nolint: forbidigo
+
+	testCaseDB := createTestDB(t, "balance_subscription_snooze_scheduled_operation")
+	currencyStore := store.NewCurrency(testCaseDB)
+	userStore := store.NewUser(testCaseDB)
+	balanceStore := store.NewBalance(testCaseDB)
+	categoryStore := store.NewCategory(testCaseDB)
+	balanceSubscriptionStore := store.NewBalanceSubscription(testCaseDB)
+
+	userID := uuid.NewString()
+	balanceID := uuid.NewString()
+	currencyID := uuid.NewString()
+	categoryID := uuid.NewString()
+	balanceSubscriptionID := uuid.NewString()
+	scheduledOperationID := uuid.NewString()
+
+	err := currencyStore.CreateIfNotExists(ctx, &model.Currency{
+		ID:   currencyID,
+		Code: "USD",
+	})
+	require.NoError(t, err)
+
+	err = userStore.Create(ctx, &model.User{
+		ID:       userID,
+		Username: "test" + userID,
+	})
+	require.NoError(t, err)
+
+	err = balanceStore.Create(ctx, &model.Balance{
+		ID:         balanceID,
+		UserID:     userID,
+		CurrencyID: currencyID,
+	})
+	require.NoError(t, err)
+
+	err = categoryStore.Create(ctx, &model.Category{
+		ID:     categoryID,
+		UserID: userID,
+		Title:  "test_category",
+	})
+	require.NoError(t, err)
+
+	err = balanceSubscriptionStore.Create(ctx, model.BalanceSubscription{
+		ID:         balanceSubscriptionID,
+		BalanceID:  balanceID,
+		CategoryID: categoryID,
+		Name:       "test",
+		Amount:     amount100,
+		Period:     model.SubscriptionPeriodMonthly,
+	})
+	require.NoError(t, err)
+
+	err = balanceSubscriptionStore.CreateScheduledOperation(ctx, model.ScheduledOperation{
+		ID:             scheduledOperationID,
+		SubscriptionID: balanceSubscriptionID,
+		Notified:       true,
+		CreationDate:   time.Date(2025, time.March, 11, 10, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err = balanceSubscriptionStore.Delete(ctx, balanceSubscriptionID)
+		require.NoError(t, err)
+		err = balanceStore.Delete(ctx, balanceID)
+		require.NoError(t, err)
+		err = categoryStore.Delete(ctx, categoryID)
+		require.NoError(t, err)
+		err := deleteCurrencyByID(testCaseDB.DB, currencyID)
+		require.NoError(t, err)
+		err = deleteUserByID(testCaseDB.DB, userID)
+		require.NoError(t, err)
+	})
+
+	snoozedUntil := time.Date(2025, time.March, 9, 10, 0, 0, 0, time.UTC)
+
+	err = balanceSubscriptionStore.SnoozeScheduledOperation(ctx, scheduledOperationID, snoozedUntil)
+	assert.NoError(t, err)
+
+	actual, err := balanceSubscriptionStore.GetScheduledOperation(ctx, service.GetScheduledOperationFilter{
+		ID: scheduledOperationID,
+	})
+	assert.NoError(t, err)
+	require.NotNil(t, actual)
+	assert.False(t, actual.Notified)
+	require.NotNil(t, actual.SnoozedUntil)
+	assert.Equal(t, snoozedUntil, actual.SnoozedUntil.UTC())
+}
+
 func getScheledOperationByID(db *sqlx.DB, id string) (*model.ScheduledOperation, error) {
 	var scheduleOperation model.ScheduledOperation
 	err := db.Get(&scheduleOperation, "SELECT * FROM scheduled_operations WHERE id = $1;", id)
 	return &scheduleOperation, err
 }
+
+func TestBalanceSubscription_ListChanges(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background() //nolint: forbidigo
+
+	testCaseDB := createTestDB(t, "balance_subscription_list_changes")
+	currencyStore := store.NewCurrency(testCaseDB)
+	userStore := store.NewUser(testCaseDB)
+	balanceStore := store.NewBalance(testCaseDB)
+	categoryStore := store.NewCategory(testCaseDB)
+	balanceSubscriptionStore := store.NewBalanceSubscription(testCaseDB)
+
+	userID := uuid.NewString()
+	balanceID := uuid.NewString()
+	currencyID := uuid.NewString()
+	categoryID := uuid.NewString()
+	balanceSubscriptionID1, balanceSubscriptionID2 := uuid.NewString(), uuid.NewString()
+	changeID1, changeID2, changeID3 := uuid.NewString(), uuid.NewString(), uuid.NewString()
+
+	err := currencyStore.CreateIfNotExists(ctx, &model.Currency{
+		ID:   currencyID,
+		Code: "USD",
+	})
+	require.NoError(t, err)
+
+	err = userStore.Create(ctx, &model.User{
+		ID:       userID,
+		Username: "test" + userID,
+	})
+	require.NoError(t, err)
+
+	err = balanceStore.Create(ctx, &model.Balance{
+		ID:         balanceID,
+		UserID:     userID,
+		CurrencyID: currencyID,
+	})
+	require.NoError(t, err)
+
+	err = categoryStore.Create(ctx, &model.Category{
+		ID:     categoryID,
+		UserID: userID,
+		Title:  "test_category",
+	})
+	require.NoError(t, err)
+
+	for _, balanceSubscriptionID := range []string{balanceSubscriptionID1, balanceSubscriptionID2} {
+		err = balanceSubscriptionStore.Create(ctx, model.BalanceSubscription{
+			ID:         balanceSubscriptionID,
+			BalanceID:  balanceID,
+			CategoryID: categoryID,
+			Name:       "test" + balanceSubscriptionID,
+			Amount:     amount100,
+			Period:     model.SubscriptionPeriodMonthly,
+		})
+		require.NoError(t, err)
+	}
+
+	changes := []model.BalanceSubscriptionChange{
+		{
+			ID:             changeID1,
+			SubscriptionID: balanceSubscriptionID1,
+			PreviousAmount: amount100,
+			NewAmount:      amount200,
+			PreviousPeriod: model.SubscriptionPeriodMonthly,
+			NewPeriod:      model.SubscriptionPeriodMonthly,
+		},
+		{
+			ID:             changeID2,
+			SubscriptionID: balanceSubscriptionID1,
+			PreviousAmount: amount200,
+			NewAmount:      amount200,
+			PreviousPeriod: model.SubscriptionPeriodMonthly,
+			NewPeriod:      model.SubscriptionPeriodYearly,
+		},
+		{
+			ID:             changeID3,
+			SubscriptionID: balanceSubscriptionID2,
+			PreviousAmount: amount100,
+			NewAmount:      amount200,
+			PreviousPeriod: model.SubscriptionPeriodMonthly,
+			NewPeriod:      model.SubscriptionPeriodMonthly,
+		},
+	}
+	for _, change := range changes {
+		err = balanceSubscriptionStore.CreateChange(ctx, change)
+		require.NoError(t, err)
+	}
+
+	t.Cleanup(func() {
+		// NOTE: Changes are deleted together with subscriptions.
+		err = balanceSubscriptionStore.Delete(ctx, balanceSubscriptionID1)
+		require.NoError(t, err)
+		err = balanceSubscriptionStore.Delete(ctx, balanceSubscriptionID2)
+		require.NoError(t, err)
+		err = balanceStore.Delete(ctx, balanceID)
+		require.NoError(t, err)
+		err = categoryStore.Delete(ctx, categoryID)
+		require.NoError(t, err)
+		err := deleteCurrencyByID(testCaseDB.DB, currencyID)
+		require.NoError(t, err)
+		err = deleteUserByID(testCaseDB.DB, userID)
+		require.NoError(t, err)
+	})
+
+	testCases := [...]struct {
+		desc     string
+		args     service.ListBalanceSubscriptionChangesFilter
+		expected []model.BalanceSubscriptionChange
+	}{
+		{
+			desc: "received changes of the subscription",
+			args: service.ListBalanceSubscriptionChangesFilter{
+				SubscriptionIDs: []string{balanceSubscriptionID1},
+			},
+			expected: changes[:2],
+		},
+		{
+			desc: "received changes of all subscriptions",
+			args: service.ListBalanceSubscriptionChangesFilter{
+				SubscriptionIDs: []string{balanceSubscriptionID1, balanceSubscriptionID2},
+			},
+			expected: changes,
+		},
+		{
+			desc: "received no changes, because all of them were created before the filter date",
+			args: service.ListBalanceSubscriptionChangesFilter{
+				SubscriptionIDs: []string{balanceSubscriptionID1},
+				CreatedAtFrom:   time.Now().Add(time.Hour),
+			},
+			expected: nil,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := balanceSubscriptionStore.ListChanges(ctx, tc.args)
+			assert.NoError(t, err)
+			assert.Len(t, actual, len(tc.expected))
+
+			for _, expected := range tc.expected {
+				assert.True(t, slices.ContainsFunc(actual, func(change model.BalanceSubscriptionChange) bool {
+					return change.ID == expected.ID &&
+						change.SubscriptionID == expected.SubscriptionID &&
+						change.PreviousAmount == expected.PreviousAmount &&
+						change.NewAmount == expected.NewAmount &&
+						change.PreviousPeriod == expected.PreviousPeriod &&
+						change.NewPeriod == expected.NewPeriod
+				}))
+			}
+		})
+	}
+}
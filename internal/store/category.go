@@ -25,8 +25,8 @@ func NewCategory(db *database.PostgreSQL) *categoryStore {
 
 func (c *categoryStore) Create(ctx context.Context, category *model.Category) error {
 	_, err := c.DB.ExecContext(ctx,
-		"INSERT INTO categories (id, user_id, title) VALUES ($1, $2, $3);",
-		category.ID, category.UserID, category.Title,
+		"INSERT INTO categories (id, user_id, title, parent_id, kind, archived, position) VALUES ($1, $2, $3, $4, $5, $6, $7);",
+		category.ID, category.UserID, category.Title, category.ParentID, category.Kind, category.Archived, category.Position,
 	)
 
 	return err
@@ -36,7 +36,7 @@ func (c *categoryStore) Get(ctx context.Context, filter service.GetCategoryFilte
 	stmt := sq.
 		StatementBuilder.
 		PlaceholderFormat(sq.Dollar).
-		Select("id", "user_id", "title").
+		Select("id", "user_id", "title", "parent_id", "kind", "archived", "position").
 		From("categories")
 
 	if filter.ID != "" {
@@ -70,12 +70,16 @@ func (c *categoryStore) List(ctx context.Context, filter *service.ListCategories
 	stmt := sq.
 		StatementBuilder.
 		PlaceholderFormat(sq.Dollar).
-		Select("id", "user_id", "title").
-		From("categories")
+		Select("id", "user_id", "title", "parent_id", "kind", "archived", "position").
+		From("categories").
+		OrderBy("position", "title")
 
 	if filter.UserID != "" {
 		stmt = stmt.Where(sq.Eq{"user_id": filter.UserID})
 	}
+	if filter.ParentID != "" {
+		stmt = stmt.Where(sq.Eq{"parent_id": filter.ParentID})
+	}
 
 	query, args, err := stmt.ToSql()
 	if err != nil {
@@ -94,13 +98,65 @@ func (c *categoryStore) List(ctx context.Context, filter *service.ListCategories
 func (c *categoryStore) Update(ctx context.Context, category *model.Category) error {
 	_, err := c.DB.ExecContext(
 		ctx,
-		"UPDATE categories SET user_id = $2, title = $3 WHERE id = $1;",
-		category.ID, category.UserID, category.Title,
+		"UPDATE categories SET user_id = $2, title = $3, parent_id = $4, kind = $5, archived = $6, position = $7 WHERE id = $1;",
+		category.ID, category.UserID, category.Title, category.ParentID, category.Kind, category.Archived, category.Position,
 	)
 	return err
 }
 
 func (c *categoryStore) Delete(ctx context.Context, categoryID string) error {
-	_, err := c.DB.ExecContext(ctx, "DELETE FROM categories WHERE id = $1;", categoryID)
-	return err
+	tx, err := c.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	queries := []string{
+		// Sub-categories of the deleted category become top-level ones.
+		"UPDATE categories SET parent_id = '' WHERE parent_id = $1;",
+		// Rules can't point to the missing category, so they're deleted with it.
+		"DELETE FROM category_rules WHERE category_id = $1;",
+		"DELETE FROM categories WHERE id = $1;",
+	}
+	for _, query := range queries {
+		_, err = tx.ExecContext(ctx, query, categoryID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (c *categoryStore) Merge(ctx context.Context, sourceCategoryID, targetCategoryID string) error {
+	tx, err := c.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	queries := []string{
+		"UPDATE operations SET category_id = $2 WHERE category_id = $1;",
+		"UPDATE balance_subscriptions SET category_id = $2 WHERE category_id = $1;",
+		"UPDATE category_rules SET category_id = $2 WHERE category_id = $1;",
+		// Target category can't stay a sub-category of the deleted one.
+		"UPDATE categories SET parent_id = '' WHERE id = $2 AND parent_id = $1;",
+		// Sub-categories are moved under the target one or its parent, since only one level of nesting is supported.
+		`UPDATE categories SET parent_id = (
+			SELECT COALESCE(NULLIF(target.parent_id, ''), target.id) FROM categories AS target WHERE target.id = $2
+		) WHERE parent_id = $1;`,
+	}
+	for _, query := range queries {
+		_, err = tx.ExecContext(ctx, query, sourceCategoryID, targetCategoryID)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM categories WHERE id = $1;", sourceCategoryID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
@@ -0,0 +1,108 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/VladPetriv/finance_bot/internal/service"
+	"github.com/VladPetriv/finance_bot/pkg/database"
+)
+
+type categoryRuleStore struct {
+	*database.PostgreSQL
+}
+
+// NewCategoryRule returns a new instance of category rule store.
+func NewCategoryRule(db *database.PostgreSQL) *categoryRuleStore {
+	return &categoryRuleStore{
+		db,
+	}
+}
+
+func (c *categoryRuleStore) Create(ctx context.Context, rule *model.CategoryRule) error {
+	_, err := c.DB.ExecContext(ctx,
+		`INSERT INTO category_rules (id, user_id, category_id, balance_id, match_type, pattern, min_amount, max_amount, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);`,
+		rule.ID, rule.UserID, rule.CategoryID, rule.BalanceID, rule.MatchType, rule.Pattern, rule.MinAmount, rule.MaxAmount, rule.CreatedAt,
+	)
+
+	return err
+}
+
+func (c *categoryRuleStore) Get(ctx context.Context, filter service.GetCategoryRuleFilter) (*model.CategoryRule, error) {
+	stmt := sq.
+		StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select("id", "user_id", "category_id", "balance_id", "match_type", "pattern", "min_amount", "max_amount", "created_at").
+		From("category_rules")
+
+	if filter.ID != "" {
+		stmt = stmt.Where(sq.Eq{"id": filter.ID})
+	}
+	if filter.UserID != "" {
+		stmt = stmt.Where(sq.Eq{"user_id": filter.UserID})
+	}
+
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build get category rule query: %w", err)
+	}
+
+	var rule model.CategoryRule
+	err = c.DB.GetContext(ctx, &rule, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	err = rule.CompilePattern()
+	if err != nil {
+		return nil, fmt.Errorf("compile category rule pattern: %w", err)
+	}
+
+	return &rule, nil
+}
+
+func (c *categoryRuleStore) List(ctx context.Context, filter service.ListCategoryRulesFilter) ([]model.CategoryRule, error) {
+	stmt := sq.
+		StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select("id", "user_id", "category_id", "balance_id", "match_type", "pattern", "min_amount", "max_amount", "created_at").
+		From("category_rules").
+		OrderBy("created_at ASC")
+
+	if filter.UserID != "" {
+		stmt = stmt.Where(sq.Eq{"user_id": filter.UserID})
+	}
+
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build list category rules query: %w", err)
+	}
+
+	var rules []model.CategoryRule
+	err = c.DB.SelectContext(ctx, &rules, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rules {
+		err = rules[i].CompilePattern()
+		if err != nil {
+			return nil, fmt.Errorf("compile category rule pattern: %w", err)
+		}
+	}
+
+	return rules, nil
+}
+
+func (c *categoryRuleStore) Delete(ctx context.Context, ruleID string) error {
+	_, err := c.DB.ExecContext(ctx, "DELETE FROM category_rules WHERE id = $1;", ruleID)
+	return err
+}
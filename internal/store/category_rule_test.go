@@ -0,0 +1,141 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/VladPetriv/finance_bot/internal/service"
+	"github.com/VladPetriv/finance_bot/internal/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryRule_CreateAndList(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO() //nolint: forbidigo
+	testCaseDB := createTestDB(t, "category_rule_create_and_list")
+	userStore := store.NewUser(testCaseDB)
+	categoryStore := store.NewCategory(testCaseDB)
+	categoryRuleStore := store.NewCategoryRule(testCaseDB)
+
+	userID, categoryID := uuid.NewString(), uuid.NewString()
+	err := userStore.Create(ctx, &model.User{
+		ID:       userID,
+		Username: "test" + userID,
+	})
+	require.NoError(t, err)
+
+	err = categoryStore.Create(ctx, &model.Category{
+		ID:     categoryID,
+		UserID: userID,
+		Title:  "rule_category",
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err := categoryStore.Delete(ctx, categoryID)
+		require.NoError(t, err)
+		err = deleteUserByID(testCaseDB.DB, userID)
+		require.NoError(t, err)
+	})
+
+	createdAt := time.Now().UTC().Truncate(time.Second)
+	rules := []model.CategoryRule{
+		{
+			ID:         uuid.NewString(),
+			UserID:     userID,
+			CategoryID: categoryID,
+			MatchType:  model.CategoryRuleMatchTypeContains,
+			Pattern:    "coffee",
+			CreatedAt:  createdAt,
+		},
+		{
+			ID:         uuid.NewString(),
+			UserID:     userID,
+			CategoryID: categoryID,
+			BalanceID:  uuid.NewString(),
+			MatchType:  model.CategoryRuleMatchTypeRegex,
+			Pattern:    "^uber",
+			MinAmount:  "10.00",
+			MaxAmount:  amount100,
+			CreatedAt:  createdAt.Add(time.Minute),
+		},
+	}
+	for _, rule := range rules {
+		err = categoryRuleStore.Create(ctx, &rule)
+		require.NoError(t, err)
+	}
+
+	actual, err := categoryRuleStore.List(ctx, service.ListCategoryRulesFilter{UserID: userID})
+	require.NoError(t, err)
+	require.Len(t, actual, len(rules))
+	for i := range rules {
+		assert.Equal(t, rules[i].ID, actual[i].ID)
+		assert.Equal(t, rules[i].BalanceID, actual[i].BalanceID)
+		assert.Equal(t, rules[i].MatchType, actual[i].MatchType)
+		assert.Equal(t, rules[i].Pattern, actual[i].Pattern)
+		assert.Equal(t, rules[i].MinAmount, actual[i].MinAmount)
+		assert.Equal(t, rules[i].MaxAmount, actual[i].MaxAmount)
+	}
+
+	rule, err := categoryRuleStore.Get(ctx, service.GetCategoryRuleFilter{ID: rules[0].ID, UserID: userID})
+	require.NoError(t, err)
+	require.NotNil(t, rule)
+	assert.Equal(t, rules[0].Pattern, rule.Pattern)
+
+	rule, err = categoryRuleStore.Get(ctx, service.GetCategoryRuleFilter{ID: rules[0].ID, UserID: uuid.NewString()})
+	assert.NoError(t, err)
+	assert.Nil(t, rule)
+}
+
+func TestCategoryRule_Delete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO() //nolint: forbidigo
+	testCaseDB := createTestDB(t, "category_rule_delete")
+	userStore := store.NewUser(testCaseDB)
+	categoryStore := store.NewCategory(testCaseDB)
+	categoryRuleStore := store.NewCategoryRule(testCaseDB)
+
+	userID, categoryID, ruleID := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	err := userStore.Create(ctx, &model.User{
+		ID:       userID,
+		Username: "test" + userID,
+	})
+	require.NoError(t, err)
+
+	err = categoryStore.Create(ctx, &model.Category{
+		ID:     categoryID,
+		UserID: userID,
+		Title:  "rule_category",
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err := categoryStore.Delete(ctx, categoryID)
+		require.NoError(t, err)
+		err = deleteUserByID(testCaseDB.DB, userID)
+		require.NoError(t, err)
+	})
+
+	err = categoryRuleStore.Create(ctx, &model.CategoryRule{
+		ID:         ruleID,
+		UserID:     userID,
+		CategoryID: categoryID,
+		MatchType:  model.CategoryRuleMatchTypeContains,
+		Pattern:    "coffee",
+		CreatedAt:  time.Now(),
+	})
+	require.NoError(t, err)
+
+	err = categoryRuleStore.Delete(ctx, ruleID)
+	assert.NoError(t, err)
+
+	actual, err := categoryRuleStore.Get(ctx, service.GetCategoryRuleFilter{ID: ruleID})
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+}
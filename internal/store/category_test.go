@@ -48,6 +48,34 @@ func TestCategory_Create(t *testing.T) {
 				Title:  "test_create_1",
 			},
 		},
+		{
+			desc: "created sub-category",
+			args: &model.Category{
+				ID:       uuid.NewString(),
+				UserID:   userID,
+				Title:    "test_create_3",
+				ParentID: uuid.NewString(),
+			},
+		},
+		{
+			desc: "created category with kind",
+			args: &model.Category{
+				ID:     uuid.NewString(),
+				UserID: userID,
+				Title:  "test_create_4",
+				Kind:   model.CategoryKindIncome,
+			},
+		},
+		{
+			desc: "created archived category with position",
+			args: &model.Category{
+				ID:       uuid.NewString(),
+				UserID:   userID,
+				Title:    "test_create_5",
+				Archived: true,
+				Position: 3,
+			},
+		},
 		{
 			desc: "category not created because already exists",
 			preconditions: &model.Category{
@@ -98,6 +126,10 @@ func TestCategory_Create(t *testing.T) {
 			assert.Equal(t, tc.args.ID, actual.ID)
 			assert.Equal(t, tc.args.UserID, actual.UserID)
 			assert.Equal(t, tc.args.Title, actual.Title)
+			assert.Equal(t, tc.args.ParentID, actual.ParentID)
+			assert.Equal(t, tc.args.Kind, actual.Kind)
+			assert.Equal(t, tc.args.Archived, actual.Archived)
+			assert.Equal(t, tc.args.Position, actual.Position)
 		})
 	}
 }
@@ -466,3 +498,160 @@ func TestCategory_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestCategory_Merge(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO() //nolint: forbidigo
+	testCaseDB := createTestDB(t, "category_merge")
+	currencyStore := store.NewCurrency(testCaseDB)
+	userStore := store.NewUser(testCaseDB)
+	balanceStore := store.NewBalance(testCaseDB)
+	categoryStore := store.NewCategory(testCaseDB)
+	operationStore := store.NewOperation(testCaseDB)
+	balanceSubscriptionStore := store.NewBalanceSubscription(testCaseDB)
+
+	userID, balanceID, currencyID := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	sourceCategoryID, targetCategoryID, subcategoryID := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	operationID, balanceSubscriptionID := uuid.NewString(), uuid.NewString()
+
+	err := currencyStore.CreateIfNotExists(ctx, &model.Currency{
+		ID:   currencyID,
+		Code: "USD",
+	})
+	require.NoError(t, err)
+
+	err = userStore.Create(ctx, &model.User{
+		ID:       userID,
+		Username: "test" + userID,
+	})
+	require.NoError(t, err)
+
+	err = balanceStore.Create(ctx, &model.Balance{
+		ID:         balanceID,
+		UserID:     userID,
+		CurrencyID: currencyID,
+	})
+	require.NoError(t, err)
+
+	for _, category := range []model.Category{
+		{ID: sourceCategoryID, UserID: userID, Title: "merge_source"},
+		{ID: targetCategoryID, UserID: userID, Title: "merge_target"},
+		{ID: subcategoryID, UserID: userID, Title: "merge_subcategory", ParentID: sourceCategoryID},
+	} {
+		err = categoryStore.Create(ctx, &category)
+		require.NoError(t, err)
+	}
+
+	err = operationStore.Create(ctx, &model.Operation{
+		ID:         operationID,
+		BalanceID:  balanceID,
+		CategoryID: sourceCategoryID,
+		Type:       model.OperationTypeSpending,
+		Amount:     "10.00",
+	})
+	require.NoError(t, err)
+
+	err = balanceSubscriptionStore.Create(ctx, model.BalanceSubscription{
+		ID:         balanceSubscriptionID,
+		BalanceID:  balanceID,
+		CategoryID: sourceCategoryID,
+		Name:       "merge_subscription",
+		Amount:     amount100,
+		Period:     model.SubscriptionPeriodMonthly,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err := operationStore.Delete(ctx, operationID)
+		require.NoError(t, err)
+		err = balanceSubscriptionStore.Delete(ctx, balanceSubscriptionID)
+		require.NoError(t, err)
+		for _, categoryID := range [...]string{sourceCategoryID, targetCategoryID, subcategoryID} {
+			err = categoryStore.Delete(ctx, categoryID)
+			require.NoError(t, err)
+		}
+		err = balanceStore.Delete(ctx, balanceID)
+		require.NoError(t, err)
+		err = deleteCurrencyByID(testCaseDB.DB, currencyID)
+		require.NoError(t, err)
+		err = deleteUserByID(testCaseDB.DB, userID)
+		require.NoError(t, err)
+	})
+
+	err = categoryStore.Merge(ctx, sourceCategoryID, targetCategoryID)
+	require.NoError(t, err)
+
+	sourceCategory, err := categoryStore.Get(ctx, service.GetCategoryFilter{ID: sourceCategoryID})
+	assert.NoError(t, err)
+	assert.Nil(t, sourceCategory)
+
+	subcategory, err := categoryStore.Get(ctx, service.GetCategoryFilter{ID: subcategoryID})
+	assert.NoError(t, err)
+	assert.Equal(t, targetCategoryID, subcategory.ParentID)
+
+	operation, err := operationStore.Get(ctx, service.GetOperationFilter{ID: operationID})
+	assert.NoError(t, err)
+	assert.Equal(t, targetCategoryID, operation.CategoryID)
+
+	balanceSubscription, err := balanceSubscriptionStore.Get(ctx, service.GetBalanceSubscriptionFilter{ID: balanceSubscriptionID})
+	assert.NoError(t, err)
+	assert.Equal(t, targetCategoryID, balanceSubscription.CategoryID)
+}
+
+func TestCategory_DeleteWithSubcategoriesAndRules(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO() //nolint: forbidigo
+	testCaseDB := createTestDB(t, "category_delete_with_subcategories_and_rules")
+	userStore := store.NewUser(testCaseDB)
+	categoryStore := store.NewCategory(testCaseDB)
+	categoryRuleStore := store.NewCategoryRule(testCaseDB)
+
+	userID, categoryID, subcategoryID, ruleID := uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString()
+
+	err := userStore.Create(ctx, &model.User{
+		ID:       userID,
+		Username: "test" + userID,
+	})
+	require.NoError(t, err)
+
+	for _, category := range []model.Category{
+		{ID: categoryID, UserID: userID, Title: "delete_parent"},
+		{ID: subcategoryID, UserID: userID, Title: "delete_subcategory", ParentID: categoryID},
+	} {
+		err = categoryStore.Create(ctx, &category)
+		require.NoError(t, err)
+	}
+
+	err = categoryRuleStore.Create(ctx, &model.CategoryRule{
+		ID:         ruleID,
+		UserID:     userID,
+		CategoryID: categoryID,
+		MatchType:  model.CategoryRuleMatchTypeContains,
+		Pattern:    "uber",
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err := categoryStore.Delete(ctx, subcategoryID)
+		require.NoError(t, err)
+		err = deleteUserByID(testCaseDB.DB, userID)
+		require.NoError(t, err)
+	})
+
+	err = categoryStore.Delete(ctx, categoryID)
+	require.NoError(t, err)
+
+	category, err := categoryStore.Get(ctx, service.GetCategoryFilter{ID: categoryID})
+	assert.NoError(t, err)
+	assert.Nil(t, category)
+
+	subcategory, err := categoryStore.Get(ctx, service.GetCategoryFilter{ID: subcategoryID})
+	assert.NoError(t, err)
+	assert.Empty(t, subcategory.ParentID)
+
+	rule, err := categoryRuleStore.Get(ctx, service.GetCategoryRuleFilter{ID: ruleID, UserID: userID})
+	assert.NoError(t, err)
+	assert.Nil(t, rule)
+}
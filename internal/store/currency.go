@@ -25,16 +25,43 @@ func NewCurrency(db *database.PostgreSQL) *currencyStore {
 func (c *currencyStore) CreateIfNotExists(ctx context.Context, currency *model.Currency) error {
 	_, err := c.DB.ExecContext(
 		ctx,
-		"INSERT INTO currencies (id, name, code, symbol) VALUES ($1, $2, $3, $4) ON CONFLICT (code) DO NOTHING;",
-		currency.ID, currency.Name, currency.Code, currency.Symbol,
+		"INSERT INTO currencies (id, name, code, symbol, user_id, decimal_places) VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (code, user_id) DO NOTHING;",
+		currency.ID, currency.Name, currency.Code, currency.Symbol, currency.UserID, currency.DecimalPlaces,
 	)
 
 	return err
 }
 
-func (c *currencyStore) Count(ctx context.Context) (int, error) {
+func (c *currencyStore) Update(ctx context.Context, currency *model.Currency) error {
+	_, err := c.DB.ExecContext(
+		ctx,
+		"UPDATE currencies SET name = $1, symbol = $2, decimal_places = $3, deprecated = $4 WHERE id = $5;",
+		currency.Name, currency.Symbol, currency.DecimalPlaces, currency.Deprecated, currency.ID,
+	)
+
+	return err
+}
+
+func (c *currencyStore) Count(ctx context.Context, filter service.ListCurrenciesFilter) (int, error) {
+	stmt := applyCurrencyUserFilter(
+		sq.
+			StatementBuilder.
+			PlaceholderFormat(sq.Dollar).
+			Select("COUNT(*)").
+			From("currencies"),
+		filter.UserID,
+	)
+	if filter.ExcludeDeprecated {
+		stmt = stmt.Where(sq.Eq{"currencies.deprecated": false})
+	}
+
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
 	var count int
-	err := c.DB.GetContext(ctx, &count, "SELECT COUNT(*) FROM currencies;")
+	err = c.DB.GetContext(ctx, &count, query, args...)
 	if err != nil {
 		return 0, err
 	}
@@ -46,9 +73,25 @@ func (c *currencyStore) List(ctx context.Context, filter service.ListCurrenciesF
 	stmt := sq.
 		StatementBuilder.
 		PlaceholderFormat(sq.Dollar).
-		Select("id", "name", "code", "symbol").
+		Select("id", "name", "code", "symbol", "user_id", "decimal_places", "deprecated").
 		From("currencies")
 
+	switch filter.OnlyPrivate {
+	case true:
+		stmt = stmt.Where(sq.Eq{"user_id": filter.UserID})
+	case false:
+		stmt = applyCurrencyUserFilter(stmt, filter.UserID)
+	}
+
+	if filter.ExcludeDeprecated {
+		stmt = stmt.Where(sq.Eq{"currencies.deprecated": false})
+	}
+
+	if filter.UserID != "" {
+		// Private currencies are shown first, since the user is more likely to look for them.
+		stmt = stmt.OrderBy("user_id DESC")
+	}
+
 	if filter.Pagination != nil {
 		stmt = applyLimitAndOffsetForStatement(stmt, filter.Pagination)
 	}
@@ -71,12 +114,23 @@ func (c *currencyStore) Get(ctx context.Context, filter service.GetCurrencyFilte
 	stmt := sq.
 		StatementBuilder.
 		PlaceholderFormat(sq.Dollar).
-		Select("currencies.id", "currencies.name", "currencies.code", "currencies.symbol").
+		Select("currencies.id", "currencies.name", "currencies.code", "currencies.symbol", "currencies.user_id", "currencies.decimal_places", "currencies.deprecated").
 		From("currencies")
 
 	if filter.ID != "" {
 		stmt = stmt.Where(sq.Eq{"currencies.id": filter.ID})
 	}
+	if filter.Code != "" {
+		stmt = stmt.Where(sq.Eq{"currencies.code": filter.Code})
+	}
+	// Currencies requested by ID without user are resolved regardless of the owner,
+	// since the ID is already taken from the entity the currency belongs to.
+	if filter.UserID != "" || filter.ID == "" {
+		stmt = applyCurrencyUserFilter(stmt, filter.UserID)
+	}
+
+	// Private currency takes precedence over the public one with the same code.
+	stmt = stmt.OrderBy("currencies.user_id DESC").Limit(1)
 
 	query, args, err := stmt.ToSql()
 	if err != nil {
@@ -95,3 +149,55 @@ func (c *currencyStore) Get(ctx context.Context, filter service.GetCurrencyFilte
 
 	return &currency, nil
 }
+
+// applyCurrencyUserFilter limits currencies to public ones and private currencies of the user.
+func applyCurrencyUserFilter(stmt sq.SelectBuilder, userID string) sq.SelectBuilder {
+	if userID == "" {
+		return stmt.Where(sq.Eq{"currencies.user_id": ""})
+	}
+
+	return stmt.Where(sq.Eq{"currencies.user_id": []string{"", userID}})
+}
+
+func (c *currencyStore) UpsertRate(ctx context.Context, rate model.CurrencyRate) error {
+	_, err := c.DB.ExecContext(
+		ctx,
+		`INSERT INTO
+			currency_rates (id, currency_id, target_currency, rate, effective_date)
+		VALUES
+			($1, $2, $3, $4, $5)
+		ON CONFLICT (currency_id, target_currency, effective_date) DO UPDATE
+		SET
+			rate = EXCLUDED.rate,
+			updated_at = NOW();`,
+		rate.ID, rate.CurrencyID, rate.TargetCurrency, rate.Rate, rate.EffectiveDate,
+	)
+
+	return err
+}
+
+func (c *currencyStore) ListRates(ctx context.Context, filter service.ListCurrencyRatesFilter) ([]model.CurrencyRate, error) {
+	stmt := sq.
+		StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select("id", "currency_id", "target_currency", "rate", "effective_date", "created_at", "updated_at").
+		From("currency_rates").
+		OrderBy("effective_date", "created_at")
+
+	if len(filter.CurrencyIDs) != 0 {
+		stmt = stmt.Where(sq.Eq{"currency_id": filter.CurrencyIDs})
+	}
+
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var rates []model.CurrencyRate
+	err = c.DB.SelectContext(ctx, &rates, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
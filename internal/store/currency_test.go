@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/VladPetriv/finance_bot/internal/model"
 	"github.com/VladPetriv/finance_bot/internal/service"
@@ -11,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCurrency_Create(t *testing.T) {
@@ -97,6 +99,78 @@ func TestCurrency_Create(t *testing.T) {
 	}
 }
 
+func TestCurrency_Update(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background() //nolint: forbidigo
+
+	testCaseDB := createTestDB(t, "currency_update")
+	currencyStore := store.NewCurrency(testCaseDB)
+
+	testCases := [...]struct {
+		desc          string
+		preconditions *model.Currency
+		args          *model.Currency
+	}{
+		{
+			desc: "updated currency name and symbol",
+			preconditions: &model.Currency{
+				ID:            uuid.NewString(),
+				Name:          "Hryvnia",
+				Code:          "UA_test_update_1",
+				Symbol:        "UAH",
+				DecimalPlaces: 2,
+			},
+			args: &model.Currency{
+				Name:          "Ukrainian Hryvnia",
+				Code:          "UA_test_update_1",
+				Symbol:        "₴",
+				DecimalPlaces: 2,
+			},
+		},
+		{
+			desc: "marked currency as deprecated",
+			preconditions: &model.Currency{
+				ID:            uuid.NewString(),
+				Name:          "Belarusian Ruble",
+				Code:          "BY_test_update_2",
+				Symbol:        "Br",
+				DecimalPlaces: 2,
+			},
+			args: &model.Currency{
+				Name:          "Belarusian Ruble",
+				Code:          "BY_test_update_2",
+				Symbol:        "Br",
+				DecimalPlaces: 2,
+				Deprecated:    true,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := currencyStore.CreateIfNotExists(ctx, tc.preconditions)
+			assert.NoError(t, err)
+
+			t.Cleanup(func() {
+				err := deleteCurrencyByID(testCaseDB.DB, tc.preconditions.ID)
+				assert.NoError(t, err)
+			})
+
+			tc.args.ID = tc.preconditions.ID
+			err = currencyStore.Update(ctx, tc.args)
+			assert.NoError(t, err)
+
+			var updatedCurrency model.Currency
+			err = testCaseDB.DB.Get(&updatedCurrency, "SELECT * FROM currencies WHERE id=$1;", tc.args.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, *tc.args, updatedCurrency)
+		})
+	}
+}
+
 func TestCurrency_Count(t *testing.T) {
 	t.Parallel()
 
@@ -148,7 +222,7 @@ func TestCurrency_Count(t *testing.T) {
 				}
 			})
 
-			count, err := currencyStore.Count(ctx)
+			count, err := currencyStore.Count(ctx, service.ListCurrenciesFilter{})
 			assert.NoError(t, err)
 			assert.Equal(t, tc.expected, count)
 		})
@@ -405,6 +479,51 @@ func TestCurrency_Get(t *testing.T) {
 	}
 }
 
+func TestCurrency_UpsertRate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background() //nolint: forbidigo
+
+	testCaseDB := createTestDB(t, "currency_upsert_rate")
+	currencyStore := store.NewCurrency(testCaseDB)
+
+	currencyID := uuid.NewString()
+	err := currencyStore.CreateIfNotExists(ctx, &model.Currency{
+		ID:     currencyID,
+		UserID: uuid.NewString(),
+		Name:   "Bitcoin",
+		Symbol: "₿",
+		Code:   "BTC_test_upsert_rate",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := deleteCurrencyByID(testCaseDB.DB, currencyID)
+		assert.NoError(t, err)
+	})
+
+	date := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	for _, rate := range []model.CurrencyRate{
+		{ID: uuid.NewString(), CurrencyID: currencyID, TargetCurrency: "USD", Rate: "80000", EffectiveDate: date.AddDate(0, 0, -1)},
+		{ID: uuid.NewString(), CurrencyID: currencyID, TargetCurrency: "USD", Rate: "81000", EffectiveDate: date},
+		{ID: uuid.NewString(), CurrencyID: currencyID, TargetCurrency: "USD", Rate: "82000", EffectiveDate: date},
+	} {
+		err := currencyStore.UpsertRate(ctx, rate)
+		require.NoError(t, err)
+	}
+
+	actual, err := currencyStore.ListRates(ctx, service.ListCurrencyRatesFilter{
+		CurrencyIDs: []string{currencyID},
+	})
+	require.NoError(t, err)
+	require.Len(t, actual, 2)
+
+	// Rate set on the same day replaces the previous one, while rates of earlier days are kept.
+	assert.Equal(t, "80000", actual[0].Rate)
+	assert.True(t, date.AddDate(0, 0, -1).Equal(actual[0].EffectiveDate))
+	assert.Equal(t, "82000", actual[1].Rate)
+	assert.True(t, date.Equal(actual[1].EffectiveDate))
+}
+
 func deleteCurrencyByID(db *sqlx.DB, currencyID string) error {
 	_, err := db.Exec("DELETE FROM currencies WHERE id = $1;", currencyID)
 	return err
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/VladPetriv/finance_bot/internal/service"
+	"github.com/VladPetriv/finance_bot/pkg/database"
+)
+
+type exchangeRateStore struct {
+	*database.PostgreSQL
+}
+
+// NewExchangeRate creates a new exchange rate store.
+func NewExchangeRate(db *database.PostgreSQL) *exchangeRateStore {
+	return &exchangeRateStore{
+		db,
+	}
+}
+
+func (e *exchangeRateStore) Upsert(ctx context.Context, exchangeRate model.ExchangeRate) error {
+	_, err := e.DB.ExecContext(
+		ctx,
+		`INSERT INTO
+			exchange_rates (id, base_currency, target_currency, date, rate)
+		VALUES
+			($1, $2, $3, $4, $5)
+		ON CONFLICT (base_currency, target_currency, date) DO UPDATE
+		SET
+			rate = EXCLUDED.rate,
+			updated_at = NOW();`,
+		exchangeRate.ID, exchangeRate.BaseCurrency, exchangeRate.TargetCurrency, exchangeRate.Date, exchangeRate.Rate,
+	)
+
+	return err
+}
+
+func (e *exchangeRateStore) Get(ctx context.Context, filter service.GetExchangeRateFilter) (*model.ExchangeRate, error) {
+	stmt := sq.
+		StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select("id", "base_currency", "target_currency", "date", "rate", "created_at", "updated_at").
+		From("exchange_rates").
+		Where(sq.Eq{
+			"base_currency":   filter.BaseCurrency,
+			"target_currency": filter.TargetCurrency,
+		}).
+		OrderBy("date DESC").
+		Limit(1)
+
+	if !filter.DateTo.IsZero() {
+		stmt = stmt.Where(sq.LtOrEq{"date": filter.DateTo})
+	}
+
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build get exchange rate query: %w", err)
+	}
+
+	var exchangeRate model.ExchangeRate
+	err = e.DB.GetContext(ctx, &exchangeRate, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &exchangeRate, nil
+}
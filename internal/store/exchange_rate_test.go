@@ -0,0 +1,157 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VladPetriv/finance_bot/internal/model"
+	"github.com/VladPetriv/finance_bot/internal/service"
+	"github.com/VladPetriv/finance_bot/internal/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExchangeRate_Upsert(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background() //nolint: forbidigo
+
+	testCaseDB := createTestDB(t, "exchange_rate_upsert")
+	exchangeRateStore := store.NewExchangeRate(testCaseDB)
+
+	date := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	err := exchangeRateStore.Upsert(ctx, model.ExchangeRate{
+		ID:             uuid.NewString(),
+		BaseCurrency:   "USD",
+		TargetCurrency: "EUR",
+		Date:           date,
+		Rate:           "0.92",
+	})
+	require.NoError(t, err)
+
+	err = exchangeRateStore.Upsert(ctx, model.ExchangeRate{
+		ID:             uuid.NewString(),
+		BaseCurrency:   "USD",
+		TargetCurrency: "EUR",
+		Date:           date,
+		Rate:           "0.93",
+	})
+	require.NoError(t, err)
+
+	var count int
+	err = testCaseDB.DB.GetContext(ctx, &count, "SELECT COUNT(*) FROM exchange_rates WHERE base_currency = 'USD' AND target_currency = 'EUR';")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	actual, err := exchangeRateStore.Get(ctx, service.GetExchangeRateFilter{
+		BaseCurrency:   "USD",
+		TargetCurrency: "EUR",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, actual)
+	assert.Equal(t, "0.93", actual.Rate)
+}
+
+func TestExchangeRate_Get(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background() //nolint: forbidigo
+
+	testCaseDB := createTestDB(t, "exchange_rate_get")
+	exchangeRateStore := store.NewExchangeRate(testCaseDB)
+
+	for _, exchangeRate := range []model.ExchangeRate{
+		{
+			ID:             uuid.NewString(),
+			BaseCurrency:   "USD",
+			TargetCurrency: "UAH",
+			Date:           time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+			Rate:           "41.00",
+		},
+		{
+			ID:             uuid.NewString(),
+			BaseCurrency:   "USD",
+			TargetCurrency: "UAH",
+			Date:           time.Date(2025, time.March, 5, 0, 0, 0, 0, time.UTC),
+			Rate:           "41.50",
+		},
+		{
+			ID:             uuid.NewString(),
+			BaseCurrency:   "USD",
+			TargetCurrency: "UAH",
+			Date:           time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC),
+			Rate:           "42.00",
+		},
+	} {
+		err := exchangeRateStore.Upsert(ctx, exchangeRate)
+		require.NoError(t, err)
+	}
+
+	testCases := [...]struct {
+		desc     string
+		args     service.GetExchangeRateFilter
+		expected string
+	}{
+		{
+			desc: "received the latest exchange rate",
+			args: service.GetExchangeRateFilter{
+				BaseCurrency:   "USD",
+				TargetCurrency: "UAH",
+			},
+			expected: "42.00",
+		},
+		{
+			desc: "received exchange rate of the exact date",
+			args: service.GetExchangeRateFilter{
+				BaseCurrency:   "USD",
+				TargetCurrency: "UAH",
+				DateTo:         time.Date(2025, time.March, 5, 0, 0, 0, 0, time.UTC),
+			},
+			expected: "41.50",
+		},
+		{
+			desc: "received the closest exchange rate before the date",
+			args: service.GetExchangeRateFilter{
+				BaseCurrency:   "USD",
+				TargetCurrency: "UAH",
+				DateTo:         time.Date(2025, time.March, 9, 0, 0, 0, 0, time.UTC),
+			},
+			expected: "41.50",
+		},
+		{
+			desc: "exchange rate not found, because there are no rates before the date",
+			args: service.GetExchangeRateFilter{
+				BaseCurrency:   "USD",
+				TargetCurrency: "UAH",
+				DateTo:         time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			desc: "exchange rate not found, because rates are stored only for the requested direction",
+			args: service.GetExchangeRateFilter{
+				BaseCurrency:   "UAH",
+				TargetCurrency: "USD",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := exchangeRateStore.Get(ctx, tc.args)
+			assert.NoError(t, err)
+
+			if tc.expected == "" {
+				assert.Nil(t, actual)
+				return
+			}
+
+			require.NotNil(t, actual)
+			assert.Equal(t, tc.expected, actual.Rate)
+		})
+	}
+}
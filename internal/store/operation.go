@@ -13,6 +13,21 @@ import (
 	"github.com/VladPetriv/finance_bot/pkg/database"
 )
 
+// balanceSubscriptionIDColumn is used to select balance_subscription_id, since operations that were created
+// before the column was introduced have NULL value there.
+const balanceSubscriptionIDColumn = "COALESCE(balance_subscription_id, '') AS balance_subscription_id"
+
+// originalAmountColumn and originalCurrencyColumn are used to select original amount and currency,
+// since only operations converted from another currency have them.
+const (
+	originalAmountColumn   = "COALESCE(original_amount, '') AS original_amount"
+	originalCurrencyColumn = "COALESCE(original_currency, '') AS original_currency"
+)
+
+// marketExchangeRateColumn is used to select market exchange rate, since only transfers between balances
+// with different currencies have it.
+const marketExchangeRateColumn = "COALESCE(market_exchange_rate, '') AS market_exchange_rate"
+
 type operationStore struct {
 	*database.PostgreSQL
 }
@@ -36,12 +51,12 @@ func (o *operationStore) Create(ctx context.Context, operation *model.Operation)
 	_, err := o.DB.ExecContext(
 		ctx,
 		`INSERT INTO
-			operations (id, category_id, balance_id, parent_operation_id, type, amount, exchange_rate, description, created_at)
+			operations (id, category_id, balance_id, balance_subscription_id, parent_operation_id, type, amount, exchange_rate, market_exchange_rate, original_amount, original_currency, description, created_at)
 		VALUES
-			($1, $2, $3, $4, $5, $6, $7, $8, $9);
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);
 		`,
 
-		operation.ID, operation.CategoryID, operation.BalanceID, operation.ParentOperationID, operation.Type, operation.Amount, operation.ExchangeRate, operation.Description, createdAt,
+		operation.ID, operation.CategoryID, operation.BalanceID, operation.BalanceSubscriptionID, operation.ParentOperationID, operation.Type, operation.Amount, operation.ExchangeRate, operation.MarketExchangeRate, operation.OriginalAmount, operation.OriginalCurrency, operation.Description, createdAt,
 	)
 	return err
 }
@@ -50,7 +65,7 @@ func (o *operationStore) Get(ctx context.Context, filter service.GetOperationFil
 	stmt := sq.
 		StatementBuilder.
 		PlaceholderFormat(sq.Dollar).
-		Select("id", "category_id", "balance_id", "parent_operation_id", "type", "amount", "exchange_rate", "description", "created_at", "updated_at").
+		Select("id", "category_id", "balance_id", balanceSubscriptionIDColumn, "parent_operation_id", "type", "amount", "exchange_rate", marketExchangeRateColumn, originalAmountColumn, originalCurrencyColumn, "description", "created_at", "updated_at").
 		From("operations")
 
 	if filter.ID != "" {
@@ -139,7 +154,7 @@ func applyListOperationsFilter(options applyListOperationsOptions, filter servic
 	}
 
 	if options.listQuery {
-		expectedColumns = []string{"id", "category_id", "balance_id", "parent_operation_id", "type", "amount", "exchange_rate", "description", "created_at", "updated_at"}
+		expectedColumns = []string{"id", "category_id", "balance_id", balanceSubscriptionIDColumn, "parent_operation_id", "type", "amount", "exchange_rate", marketExchangeRateColumn, originalAmountColumn, originalCurrencyColumn, "description", "created_at", "updated_at"}
 	}
 
 	stmt := sq.
@@ -152,14 +167,32 @@ func applyListOperationsFilter(options applyListOperationsOptions, filter servic
 		stmt = stmt.Where(sq.Eq{"balance_id": filter.BalanceID})
 	}
 
+	if filter.CategoryID != "" {
+		stmt = stmt.Where(sq.Eq{"category_id": filter.CategoryID})
+	}
+
 	if filter.CreationPeriod != "" {
 		startDate, endDate := filter.CreationPeriod.CalculateTimeRange()
 		stmt = stmt.Where(sq.GtOrEq{"created_at": startDate}).Where(sq.LtOrEq{"created_at": endDate})
 	}
 
-	if filter.Month != "" {
-		startDate, endDate := filter.Month.GetTimeRange(time.Now())
-		stmt = stmt.Where(sq.GtOrEq{"created_at": startDate}).Where(sq.LtOrEq{"created_at": endDate})
+	if !filter.CreatedAtFrom.IsZero() {
+		stmt = stmt.Where(sq.GtOrEq{"created_at": filter.CreatedAtFrom})
+	}
+
+	if !filter.CreatedAtTo.IsZero() {
+		stmt = stmt.Where(sq.LtOrEq{"created_at": filter.CreatedAtTo})
+	}
+
+	if filter.Type != "" {
+		stmt = stmt.Where(sq.Eq{"type": filter.Type})
+	}
+
+	if filter.WithoutBalanceSubscription {
+		stmt = stmt.Where(sq.Or{
+			sq.Eq{"balance_subscription_id": nil},
+			sq.Eq{"balance_subscription_id": ""},
+		})
 	}
 
 	if filter.Pagination != nil {
@@ -167,7 +200,7 @@ func applyListOperationsFilter(options applyListOperationsOptions, filter servic
 	}
 
 	if filter.OrderByCreatedAtDesc {
-		stmt = stmt.GroupBy("id", "category_id", "balance_id", "parent_operation_id", "type", "amount", "exchange_rate", "description", "created_at", "updated_at").
+		stmt = stmt.GroupBy("id", "category_id", "balance_id", "balance_subscription_id", "parent_operation_id", "type", "amount", "exchange_rate", "market_exchange_rate", "original_amount", "original_currency", "description", "created_at", "updated_at").
 			OrderBy("created_at DESC")
 	}
 
@@ -181,14 +214,15 @@ func (o *operationStore) Update(ctx context.Context, operationID string, operati
 		SET
 			category_id = $1,
 			balance_id = $2,
-			type = $3,
-			amount = $4,
-			description = $5,
-			created_at = $6,
+			balance_subscription_id = $3,
+			type = $4,
+			amount = $5,
+			description = $6,
+			created_at = $7,
 			updated_at = NOW()
 		WHERE
-			id = $7;`,
-		operation.CategoryID, operation.BalanceID, operation.Type, operation.Amount, operation.Description, operation.CreatedAt, operationID,
+			id = $8;`,
+		operation.CategoryID, operation.BalanceID, operation.BalanceSubscriptionID, operation.Type, operation.Amount, operation.Description, operation.CreatedAt, operationID,
 	)
 
 	return err
@@ -418,6 +418,8 @@ func TestOperation_List(t *testing.T) {
 		uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString(),
 		uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString(),
 		uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString()
+	balanceID9 := uuid.NewString()
+	operationID25, operationID26, operationID27 := uuid.NewString(), uuid.NewString(), uuid.NewString()
 
 	currency := &model.Currency{
 		ID:   uuid.NewString(),
@@ -436,7 +438,7 @@ func TestOperation_List(t *testing.T) {
 	for _, balanceID := range [...]string{
 		balanceID1, balanceID2, balanceID3,
 		balanceID4, balanceID5, balanceID6,
-		balanceID7, balanceID8,
+		balanceID7, balanceID8, balanceID9,
 	} {
 		err = balanceStore.Create(ctx, &model.Balance{
 			ID:         balanceID,
@@ -808,6 +810,46 @@ func TestOperation_List(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "received all operations created in the time range",
+			preconditions: []model.Operation{
+				{
+					ID:         operationID25,
+					CategoryID: categoryID,
+					BalanceID:  balanceID9,
+					Type:       model.OperationTypeIncoming,
+					CreatedAt:  time.Date(2024, time.December, 31, 23, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:         operationID26,
+					CategoryID: categoryID,
+					BalanceID:  balanceID9,
+					Type:       model.OperationTypeIncoming,
+					CreatedAt:  time.Date(2025, time.January, 15, 12, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:         operationID27,
+					CategoryID: categoryID,
+					BalanceID:  balanceID9,
+					Type:       model.OperationTypeIncoming,
+					CreatedAt:  time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			args: service.ListOperationsFilter{
+				BalanceID:     balanceID9,
+				CreatedAtFrom: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+				CreatedAtTo:   time.Date(2025, time.January, 31, 23, 59, 59, 0, time.UTC),
+			},
+			expected: []model.Operation{
+				{
+					ID:         operationID26,
+					CategoryID: categoryID,
+					BalanceID:  balanceID9,
+					Type:       model.OperationTypeIncoming,
+					CreatedAt:  time.Date(2025, time.January, 15, 12, 0, 0, 0, time.UTC),
+				},
+			},
+		},
 		{
 			desc: "negative: operations not found",
 			args: service.ListOperationsFilter{
@@ -35,8 +35,8 @@ func (u *userStore) Create(ctx context.Context, user *model.User) error {
 func (u *userStore) CreateSettings(ctx context.Context, settings *model.UserSettings) error {
 	_, err := u.DB.ExecContext(
 		ctx,
-		"INSERT INTO user_settings (id, user_id, ai_parser_enabled, notify_about_subscription_payments) VALUES ($1, $2, $3, $4);",
-		settings.ID, settings.UserID, settings.AIParserEnabled, settings.NotifyAboutSubscriptionPayments,
+		"INSERT INTO user_settings (id, user_id, ai_parser_enabled, notify_about_subscription_payments, subscription_notification_lead_time, base_currency_code, digest_frequency, digest_send_time, last_digest_sent_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);",
+		settings.ID, settings.UserID, settings.AIParserEnabled, settings.NotifyAboutSubscriptionPayments, settings.SubscriptionNotificationLeadTime, settings.BaseCurrencyCode, settings.DigestFrequency, settings.DigestSendTime, settings.LastDigestSentAt,
 	)
 
 	return err
@@ -45,8 +45,8 @@ func (u *userStore) CreateSettings(ctx context.Context, settings *model.UserSett
 func (u *userStore) UpdateSettings(ctx context.Context, settings *model.UserSettings) error {
 	_, err := u.DB.ExecContext(
 		ctx,
-		"UPDATE user_settings SET ai_parser_enabled = $1, notify_about_subscription_payments = $2 WHERE id = $3;",
-		settings.AIParserEnabled, settings.NotifyAboutSubscriptionPayments, settings.ID,
+		"UPDATE user_settings SET ai_parser_enabled = $1, notify_about_subscription_payments = $2, subscription_notification_lead_time = $3, base_currency_code = $4, digest_frequency = $5, digest_send_time = $6, last_digest_sent_at = $7 WHERE id = $8;",
+		settings.AIParserEnabled, settings.NotifyAboutSubscriptionPayments, settings.SubscriptionNotificationLeadTime, settings.BaseCurrencyCode, settings.DigestFrequency, settings.DigestSendTime, settings.LastDigestSentAt, settings.ID,
 	)
 
 	return err
@@ -87,10 +87,22 @@ func (u *userStore) Get(ctx context.Context, filter service.GetUserFilter) (*mod
 		stmt := sq.
 			StatementBuilder.
 			PlaceholderFormat(sq.Dollar).
-			Select("id", "user_id", "currency_id", "name", "amount", "created_at", "updated_at").
+			Select(
+				"balances.id", "balances.user_id", "balances.currency_id", "balances.name", "balances.amount",
+				"balances.created_at", "balances.updated_at",
+				// Currency is preloaded, so amounts could be rounded to its minor units without additional queries.
+				`COALESCE(currencies.id, '') AS "currency.id"`,
+				`COALESCE(currencies.name, '') AS "currency.name"`,
+				`COALESCE(currencies.code, '') AS "currency.code"`,
+				`COALESCE(currencies.symbol, '') AS "currency.symbol"`,
+				`COALESCE(currencies.user_id, '') AS "currency.user_id"`,
+				`COALESCE(currencies.decimal_places, 0) AS "currency.decimal_places"`,
+				`COALESCE(currencies.deprecated, FALSE) AS "currency.deprecated"`,
+			).
 			From("balances").
-			OrderBy("created_at").
-			Where(sq.Eq{"user_id": user.ID})
+			LeftJoin("currencies ON currencies.id = balances.currency_id").
+			OrderBy("balances.created_at").
+			Where(sq.Eq{"balances.user_id": user.ID})
 
 		query, args, err := stmt.ToSql()
 		if err != nil {
@@ -109,7 +121,7 @@ func (u *userStore) Get(ctx context.Context, filter service.GetUserFilter) (*mod
 		stmt := sq.
 			StatementBuilder.
 			PlaceholderFormat(sq.Dollar).
-			Select("id", "user_id", "ai_parser_enabled", "notify_about_subscription_payments", "created_at", "updated_at").
+			Select("id", "user_id", "ai_parser_enabled", "notify_about_subscription_payments", "subscription_notification_lead_time", "base_currency_code", "digest_frequency", "digest_send_time", "last_digest_sent_at", "created_at", "updated_at").
 			From("user_settings").
 			Where(sq.Eq{"user_id": user.ID})
 
@@ -129,3 +141,31 @@ func (u *userStore) Get(ctx context.Context, filter service.GetUserFilter) (*mod
 
 	return &user, nil
 }
+
+func (u *userStore) List(ctx context.Context, filter service.ListUsersFilter) ([]model.User, error) {
+	stmt := sq.
+		StatementBuilder.
+		PlaceholderFormat(sq.Dollar).
+		Select("users.id", "users.chat_id", "users.username").
+		From("users").
+		OrderBy("users.username")
+
+	if filter.WithEnabledDigest {
+		stmt = stmt.
+			InnerJoin("user_settings ON user_settings.user_id = users.id").
+			Where(sq.Eq{"user_settings.digest_frequency": []model.DigestFrequency{model.DigestFrequencyWeekly, model.DigestFrequencyMonthly}})
+	}
+
+	query, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var users []model.User
+	err = u.DB.SelectContext(ctx, &users, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
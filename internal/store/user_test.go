@@ -3,6 +3,7 @@ package store_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/VladPetriv/finance_bot/internal/model"
 	"github.com/VladPetriv/finance_bot/internal/service"
@@ -191,9 +192,10 @@ func TestUser_UpdateSettings(t *testing.T) {
 	testCaseDB := createTestDB(t, "user_settings_update")
 	userStore := store.NewUser(testCaseDB)
 
-	userSettingsID1, userSettingsID2 := uuid.NewString(), uuid.NewString()
-	userID1, userID2 := uuid.NewString(), uuid.NewString()
-	for _, userID := range [...]string{userID1, userID2} {
+	userSettingsID1, userSettingsID2, userSettingsID3 := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	userID1, userID2, userID3 := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	lastDigestSentAt := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	for _, userID := range [...]string{userID1, userID2, userID3} {
 		err := userStore.Create(ctx, &model.User{
 			ID:       userID,
 			Username: "test" + userID,
@@ -202,7 +204,7 @@ func TestUser_UpdateSettings(t *testing.T) {
 	}
 
 	t.Cleanup(func() {
-		for _, userID := range [...]string{userID1, userID2} {
+		for _, userID := range [...]string{userID1, userID2, userID3} {
 			err := deleteUserByID(testCaseDB.DB, userID)
 			require.NoError(t, err)
 		}
@@ -256,6 +258,29 @@ func TestUser_UpdateSettings(t *testing.T) {
 				NotifyAboutSubscriptionPayments: true,
 			},
 		},
+		{
+			desc: "user setting digest successfully updated",
+			args: &model.UserSettings{
+				ID:               userSettingsID3,
+				UserID:           userID3,
+				DigestFrequency:  model.DigestFrequencyWeekly,
+				DigestSendTime:   18,
+				LastDigestSentAt: &lastDigestSentAt,
+			},
+			preconditions: &model.UserSettings{
+				ID:              userSettingsID3,
+				UserID:          userID3,
+				DigestFrequency: model.DigestFrequencyOff,
+				DigestSendTime:  model.DefaultDigestSendTime,
+			},
+			expected: &model.UserSettings{
+				ID:               userSettingsID3,
+				UserID:           userID3,
+				DigestFrequency:  model.DigestFrequencyWeekly,
+				DigestSendTime:   18,
+				LastDigestSentAt: &lastDigestSentAt,
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -283,6 +308,95 @@ func TestUser_UpdateSettings(t *testing.T) {
 			assert.Equal(t, tc.expected.UserID, updatedUserSettings.UserID)
 			assert.Equal(t, tc.expected.AIParserEnabled, updatedUserSettings.AIParserEnabled)
 			assert.Equal(t, tc.expected.NotifyAboutSubscriptionPayments, updatedUserSettings.NotifyAboutSubscriptionPayments)
+			assert.Equal(t, tc.expected.DigestFrequency, updatedUserSettings.DigestFrequency)
+			assert.Equal(t, tc.expected.DigestSendTime, updatedUserSettings.DigestSendTime)
+			if tc.expected.LastDigestSentAt != nil {
+				require.NotNil(t, updatedUserSettings.LastDigestSentAt)
+				assert.True(t, tc.expected.LastDigestSentAt.Equal(*updatedUserSettings.LastDigestSentAt))
+			}
+		})
+	}
+}
+
+func TestUser_List(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background() //nolint: forbidigo
+
+	testCaseDB := createTestDB(t, "user_list")
+	userStore := store.NewUser(testCaseDB)
+
+	users := []struct {
+		user            model.User
+		digestFrequency model.DigestFrequency
+	}{
+		{
+			user:            model.User{ID: uuid.NewString(), ChatID: 1, Username: "test_list_1"},
+			digestFrequency: model.DigestFrequencyWeekly,
+		},
+		{
+			user:            model.User{ID: uuid.NewString(), ChatID: 2, Username: "test_list_2"},
+			digestFrequency: model.DigestFrequencyOff,
+		},
+		{
+			user:            model.User{ID: uuid.NewString(), ChatID: 3, Username: "test_list_3"},
+			digestFrequency: model.DigestFrequencyMonthly,
+		},
+	}
+	for _, u := range users {
+		err := userStore.Create(ctx, &u.user)
+		require.NoError(t, err)
+
+		userSettingsID := uuid.NewString()
+		err = userStore.CreateSettings(ctx, &model.UserSettings{
+			ID:              userSettingsID,
+			UserID:          u.user.ID,
+			DigestFrequency: u.digestFrequency,
+			DigestSendTime:  model.DefaultDigestSendTime,
+		})
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			err := deleteUserSettingsByID(testCaseDB.DB, userSettingsID)
+			assert.NoError(t, err)
+
+			err = deleteUserByID(testCaseDB.DB, u.user.ID)
+			assert.NoError(t, err)
+		})
+	}
+
+	testCases := [...]struct {
+		desc     string
+		args     service.ListUsersFilter
+		expected []model.User
+	}{
+		{
+			desc: "listed all users",
+			args: service.ListUsersFilter{},
+			expected: []model.User{
+				users[0].user,
+				users[1].user,
+				users[2].user,
+			},
+		},
+		{
+			desc: "listed users with enabled digest",
+			args: service.ListUsersFilter{
+				WithEnabledDigest: true,
+			},
+			expected: []model.User{
+				users[0].user,
+				users[2].user,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := userStore.List(ctx, tc.args)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
 		})
 	}
 }
@@ -0,0 +1,372 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// ErrNoValues happens when there is nothing to draw on the chart.
+var ErrNoValues = errors.New("no values to draw")
+
+// Value represents a single labeled value of the chart.
+type Value struct {
+	Label string
+	Value float64
+}
+
+const (
+	width         = 800
+	height        = 500
+	padding       = 40
+	titleHeight   = 40
+	axisLabelSize = 60
+
+	// maxDonutSlices limits the number of slices on the donut chart, the rest of values are merged into one slice.
+	maxDonutSlices = 8
+	gridLinesCount = 4
+)
+
+var (
+	backgroundColor = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	textColor       = color.RGBA{R: 33, G: 33, B: 33, A: 255}
+	gridColor       = color.RGBA{R: 224, G: 224, B: 224, A: 255}
+	barColor        = color.RGBA{R: 239, G: 83, B: 80, A: 255}
+	lineColor       = color.RGBA{R: 33, G: 150, B: 243, A: 255}
+
+	palette = []color.RGBA{
+		{R: 239, G: 83, B: 80, A: 255},
+		{R: 66, G: 165, B: 245, A: 255},
+		{R: 102, G: 187, B: 106, A: 255},
+		{R: 255, G: 167, B: 38, A: 255},
+		{R: 171, G: 71, B: 188, A: 255},
+		{R: 38, G: 198, B: 218, A: 255},
+		{R: 255, G: 213, B: 79, A: 255},
+		{R: 141, G: 110, B: 99, A: 255},
+		{R: 158, G: 158, B: 158, A: 255},
+	}
+
+	face = basicfont.Face7x13
+)
+
+// Donut draws a donut chart with a legend, which shows the share of each value in the total.
+// Only positive values are drawn.
+func Donut(title string, values []Value) ([]byte, error) {
+	slices := make([]Value, 0, len(values))
+	total := 0.0
+	for _, value := range values {
+		if value.Value <= 0 {
+			continue
+		}
+
+		slices = append(slices, value)
+		total += value.Value
+	}
+	if total == 0 {
+		return nil, ErrNoValues
+	}
+
+	if len(slices) > maxDonutSlices {
+		other := Value{Label: "Other"}
+		for _, value := range slices[maxDonutSlices-1:] {
+			other.Value += value.Value
+		}
+		slices = append(slices[:maxDonutSlices-1], other)
+	}
+
+	// Each slice ends at the fraction of the full circle, starting from the top and going clockwise.
+	ends := make([]float64, len(slices))
+	cumulative := 0.0
+	for i, value := range slices {
+		cumulative += value.Value / total
+		ends[i] = cumulative
+	}
+
+	c := newCanvas(title)
+
+	radius := (height - titleHeight - 2*padding) / 2
+	innerRadius := float64(radius) * 0.55
+	centerX, centerY := padding+radius, titleHeight+padding+radius
+
+	for y := centerY - radius; y <= centerY+radius; y++ {
+		for x := centerX - radius; x <= centerX+radius; x++ {
+			dx, dy := float64(x-centerX), float64(y-centerY)
+			distance := math.Hypot(dx, dy)
+			if distance > float64(radius) || distance < innerRadius {
+				continue
+			}
+
+			angle := math.Atan2(dx, -dy)
+			if angle < 0 {
+				angle += 2 * math.Pi
+			}
+			fraction := angle / (2 * math.Pi)
+
+			for i, end := range ends {
+				if fraction <= end || i == len(ends)-1 {
+					c.img.Set(x, y, palette[i%len(palette)])
+					break
+				}
+			}
+		}
+	}
+
+	legendX := centerX + radius + padding
+	maxLabelLength := (width - legendX - padding) / face.Advance
+	for i, value := range slices {
+		rowY := titleHeight + padding + i*28
+
+		c.fillRect(image.Rect(legendX, rowY, legendX+14, rowY+14), palette[i%len(palette)])
+		c.drawText(
+			legendX+22, rowY+12,
+			truncate(fmt.Sprintf("%s (%.1f%%)", sanitize(value.Label), value.Value/total*100), maxLabelLength),
+		)
+	}
+
+	return c.encode()
+}
+
+// Bars draws a bar chart, values are drawn in the same order as they are provided.
+// Negative values are drawn as zero ones.
+func Bars(title string, values []Value) ([]byte, error) {
+	maxValue := 0.0
+	for _, value := range values {
+		maxValue = math.Max(maxValue, value.Value)
+	}
+	if maxValue == 0 {
+		return nil, ErrNoValues
+	}
+
+	c := newCanvas(title)
+	plot := getPlotArea()
+
+	slotWidth := float64(plot.Dx()) / float64(len(values))
+	barWidth := int(math.Max(1, slotWidth*0.7))
+
+	positions := make([]int, len(values))
+	for i := range values {
+		positions[i] = plot.Min.X + int(slotWidth*float64(i)+slotWidth/2)
+	}
+
+	c.drawAxes(plot, 0, maxValue, values, positions)
+
+	for i, value := range values {
+		if value.Value <= 0 {
+			continue
+		}
+
+		barHeight := int(value.Value / maxValue * float64(plot.Dy()))
+		c.fillRect(
+			image.Rect(positions[i]-barWidth/2, plot.Max.Y-barHeight, positions[i]-barWidth/2+barWidth, plot.Max.Y),
+			barColor,
+		)
+	}
+
+	return c.encode()
+}
+
+// Line draws a line chart, values are connected in the same order as they are provided.
+func Line(title string, values []Value) ([]byte, error) {
+	if len(values) == 0 {
+		return nil, ErrNoValues
+	}
+
+	minValue, maxValue := values[0].Value, values[0].Value
+	for _, value := range values {
+		minValue = math.Min(minValue, value.Value)
+		maxValue = math.Max(maxValue, value.Value)
+	}
+	// Flat line should be drawn in the middle of the chart.
+	if minValue == maxValue {
+		minValue, maxValue = minValue-1, maxValue+1
+	}
+
+	c := newCanvas(title)
+	plot := getPlotArea()
+
+	positions := make([]int, len(values))
+	for i := range values {
+		if len(values) == 1 {
+			positions[i] = plot.Min.X + plot.Dx()/2
+			continue
+		}
+
+		positions[i] = plot.Min.X + i*plot.Dx()/(len(values)-1)
+	}
+
+	c.drawAxes(plot, minValue, maxValue, values, positions)
+
+	getY := func(value float64) int {
+		return plot.Max.Y - int((value-minValue)/(maxValue-minValue)*float64(plot.Dy()))
+	}
+
+	for i := 1; i < len(values); i++ {
+		c.drawLine(positions[i-1], getY(values[i-1].Value), positions[i], getY(values[i].Value), lineColor)
+	}
+	if len(values) == 1 {
+		c.fillRect(image.Rect(positions[0]-2, getY(values[0].Value)-2, positions[0]+3, getY(values[0].Value)+3), lineColor)
+	}
+
+	return c.encode()
+}
+
+type canvas struct {
+	img *image.RGBA
+}
+
+func newCanvas(title string) *canvas {
+	c := &canvas{
+		img: image.NewRGBA(image.Rect(0, 0, width, height)),
+	}
+	c.fillRect(c.img.Bounds(), backgroundColor)
+
+	title = truncate(sanitize(title), (width-2*padding)/face.Advance)
+	c.drawText((width-textWidth(title))/2, titleHeight/2+padding/4, title)
+
+	return c
+}
+
+func getPlotArea() image.Rectangle {
+	return image.Rect(padding+axisLabelSize, titleHeight+padding/2, width-padding, height-padding)
+}
+
+// drawAxes draws horizontal grid lines with values on the left side of the plot and labels of values under it.
+// Labels are skipped when they don't fit, so they don't overlap each other.
+func (c *canvas) drawAxes(plot image.Rectangle, minValue, maxValue float64, values []Value, positions []int) {
+	for i := 0; i <= gridLinesCount; i++ {
+		y := plot.Max.Y - i*plot.Dy()/gridLinesCount
+		c.fillRect(image.Rect(plot.Min.X, y, plot.Max.X, y+1), gridColor)
+
+		label := formatValue(minValue + (maxValue-minValue)*float64(i)/gridLinesCount)
+		c.drawText(plot.Min.X-textWidth(label)-8, y+4, label)
+	}
+
+	maxLabelWidth := 0
+	for _, value := range values {
+		maxLabelWidth = max(maxLabelWidth, textWidth(sanitize(value.Label)))
+	}
+
+	step := 1
+	if len(positions) > 1 {
+		distance := max(1, positions[1]-positions[0])
+		step = int(math.Ceil(float64(maxLabelWidth+8) / float64(distance)))
+	}
+
+	for i := 0; i < len(values); i += step {
+		label := sanitize(values[i].Label)
+		c.drawText(positions[i]-textWidth(label)/2, plot.Max.Y+18, label)
+	}
+}
+
+func (c *canvas) fillRect(rect image.Rectangle, col color.Color) {
+	draw.Draw(c.img, rect, &image.Uniform{C: col}, image.Point{}, draw.Src)
+}
+
+// drawLine draws a line using Bresenham's algorithm, the line is thickened to be visible on small screens.
+func (c *canvas) drawLine(x0, y0, x1, y1 int, col color.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	stepX, stepY := 1, 1
+	if x0 > x1 {
+		stepX = -1
+	}
+	if y0 > y1 {
+		stepY = -1
+	}
+
+	err := dx + dy
+	for {
+		c.fillRect(image.Rect(x0-1, y0-1, x0+2, y0+2), col)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+
+		doubledErr := 2 * err
+		if doubledErr >= dy {
+			err += dy
+			x0 += stepX
+		}
+		if doubledErr <= dx {
+			err += dx
+			y0 += stepY
+		}
+	}
+}
+
+// drawText draws the text with its baseline at the y coordinate.
+func (c *canvas) drawText(x, y int, text string) {
+	drawer := font.Drawer{
+		Dst:  c.img,
+		Src:  image.NewUniform(textColor),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	drawer.DrawString(text)
+}
+
+func (c *canvas) encode() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	err := png.Encode(&buffer, c.img)
+	if err != nil {
+		return nil, fmt.Errorf("encode png: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func textWidth(text string) int {
+	return font.MeasureString(face, text).Round()
+}
+
+// sanitize removes characters which can't be drawn by the font, e.g. emojis in category titles.
+func sanitize(text string) string {
+	text = strings.Map(func(r rune) rune {
+		_, ok := face.GlyphAdvance(r)
+		if !ok {
+			return -1
+		}
+
+		return r
+	}, text)
+
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func truncate(text string, maxLength int) string {
+	if utf8.RuneCountInString(text) <= maxLength {
+		return text
+	}
+
+	return string([]rune(text)[:maxLength-3]) + "..."
+}
+
+// formatValue returns short representation of the value, e.g. 1500 -> "1.5k".
+func formatValue(value float64) string {
+	switch {
+	case math.Abs(value) >= 1_000_000:
+		return fmt.Sprintf("%.1fM", value/1_000_000)
+	case math.Abs(value) >= 1_000:
+		return fmt.Sprintf("%.1fk", value/1_000)
+	default:
+		return fmt.Sprintf("%.0f", value)
+	}
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+
+	return value
+}
@@ -0,0 +1,92 @@
+package chart_test
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"testing"
+
+	"github.com/VladPetriv/finance_bot/pkg/chart"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharts(t *testing.T) {
+	t.Parallel()
+
+	manyValues := make([]chart.Value, 0, 31)
+	for i := 1; i <= 31; i++ {
+		manyValues = append(manyValues, chart.Value{Label: fmt.Sprintf("%02d Jan", i), Value: float64(i * 10)})
+	}
+
+	testCases := [...]struct {
+		desc        string
+		draw        func(title string, values []chart.Value) ([]byte, error)
+		values      []chart.Value
+		expectedErr error
+	}{
+		{
+			desc: "donut with labels which contain emojis",
+			draw: chart.Donut,
+			values: []chart.Value{
+				{Label: "🍔 Food", Value: 150},
+				{Label: "🚕 Taxi", Value: 50},
+			},
+		},
+		{
+			desc:   "donut with more values than slices",
+			draw:   chart.Donut,
+			values: manyValues,
+		},
+		{
+			desc:        "donut without positive values",
+			draw:        chart.Donut,
+			values:      []chart.Value{{Label: "Food", Value: 0}},
+			expectedErr: chart.ErrNoValues,
+		},
+		{
+			desc:   "bars with a lot of values",
+			draw:   chart.Bars,
+			values: manyValues,
+		},
+		{
+			desc:        "bars with only zero values",
+			draw:        chart.Bars,
+			values:      []chart.Value{{Label: "01 Jan", Value: 0}, {Label: "02 Jan", Value: 0}},
+			expectedErr: chart.ErrNoValues,
+		},
+		{
+			desc:   "line with negative values",
+			draw:   chart.Line,
+			values: []chart.Value{{Label: "01 Jan", Value: -100}, {Label: "02 Jan", Value: 1500.5}},
+		},
+		{
+			desc:   "line with a single value",
+			draw:   chart.Line,
+			values: []chart.Value{{Label: "01 Jan", Value: 100}},
+		},
+		{
+			desc:        "line without values",
+			draw:        chart.Line,
+			expectedErr: chart.ErrNoValues,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := tc.draw("Spending by categories", tc.values)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			img, err := png.Decode(bytes.NewReader(actual))
+			require.NoError(t, err)
+			assert.Equal(t, 800, img.Bounds().Dx())
+			assert.Equal(t, 500, img.Bounds().Dy())
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/shopspring/decimal"
+)
+
+// maxExpressionLength limits the length of the expression, so the evaluation of user input is always cheap.
+const maxExpressionLength = 100
+
+var (
+	// ErrInvalidExpression happens when the expression can't be parsed.
+	ErrInvalidExpression = errors.New("invalid expression")
+	// ErrDivisionByZero happens when the expression contains division by zero.
+	ErrDivisionByZero = errors.New("division by zero")
+)
+
+// NewFromExpression evaluates arithmetic expression and returns its result, e.g. "1250/3" or "99,90 + 45".
+// Supported operators are +, -, * and /, their typographic variants (−, ×, x, ÷) and parentheses.
+// Whitespaces around operators are ignored, leading and trailing currency codes or symbols are stripped,
+// so "$1,250.50" and "100 UAH" are valid as well.
+// Letters and currency symbols inside the expression are rejected, so a typo like "1O0" isn't silently parsed as 10.
+//
+// Numbers may use both dot and comma as a decimal separator. When both are used, the last one is the decimal separator.
+// When only one of them is used several times, it's treated as a thousands separator.
+// Single dot or comma is always a decimal separator, so both "1.500" and "1,500" are 1.5.
+// Space or apostrophe could separate thousands only when it's followed by a group of exactly 3 digits, e.g. "1 500" or "1'500",
+// so separate numbers like "10 5" are rejected instead of being merged.
+func NewFromExpression(s string) (Money, error) {
+	normalized, err := normalizeExpression(s)
+	if err != nil {
+		return Zero, err
+	}
+
+	p := &expressionParser{input: normalized}
+	result, err := p.parseExpression()
+	if err != nil {
+		return Zero, err
+	}
+	if p.pos != len(p.input) {
+		return Zero, fmt.Errorf("%w: unexpected character %q", ErrInvalidExpression, p.input[p.pos])
+	}
+
+	return Money{result}, nil
+}
+
+func normalizeExpression(s string) (string, error) {
+	if len(s) > maxExpressionLength {
+		return "", fmt.Errorf("%w: expression is too long", ErrInvalidExpression)
+	}
+
+	runes := trimCurrency([]rune(s))
+
+	var builder strings.Builder
+	for i, r := range runes {
+		switch {
+		case r >= '0' && r <= '9', r == '.', r == ',', r == '(', r == ')', r == '+', r == '-', r == '*', r == '/':
+			builder.WriteRune(r)
+		case r == '−' || r == '–':
+			builder.WriteRune('-')
+		case r == '×', (r == 'x' || r == 'X') && isMultiplicationSign(runes, i):
+			builder.WriteRune('*')
+		case r == '÷' || r == ':':
+			builder.WriteRune('/')
+		case unicode.IsSpace(r), r == '\'':
+			err := checkSeparatorBetweenDigits(runes, i)
+			if err != nil {
+				return "", err
+			}
+		default:
+			return "", fmt.Errorf("%w: unexpected character %q", ErrInvalidExpression, r)
+		}
+	}
+
+	if builder.Len() == 0 {
+		return "", fmt.Errorf("%w: expression is empty", ErrInvalidExpression)
+	}
+
+	return builder.String(), nil
+}
+
+// checkSeparatorBetweenDigits checks the whitespace or apostrophe at the given position.
+// Between digits it's allowed only as a thousands separator, i.e. a single one followed by a group of exactly 3 digits.
+// Whitespaces between other characters are ignored, while apostrophes are allowed only between digits.
+func checkSeparatorBetweenDigits(runes []rune, i int) error {
+	isSeparator := func(r rune) bool {
+		return unicode.IsSpace(r) || r == '\''
+	}
+
+	start, end := i, i
+	for start > 0 && isSeparator(runes[start-1]) {
+		start--
+	}
+	for end < len(runes)-1 && isSeparator(runes[end+1]) {
+		end++
+	}
+
+	betweenDigits := start > 0 && end < len(runes)-1 && unicode.IsDigit(runes[start-1]) && unicode.IsDigit(runes[end+1])
+	if !betweenDigits {
+		if runes[i] == '\'' {
+			return fmt.Errorf("%w: unexpected character %q", ErrInvalidExpression, runes[i])
+		}
+
+		return nil
+	}
+
+	groupLength := 0
+	for j := end + 1; j < len(runes) && unicode.IsDigit(runes[j]); j++ {
+		groupLength++
+	}
+	if start != end || groupLength != 3 {
+		return fmt.Errorf("%w: numbers should be separated by an operator", ErrInvalidExpression)
+	}
+
+	return nil
+}
+
+// trimCurrency strips currency code or symbol written before or after the expression, e.g. "$12.50" or "100 UAH".
+func trimCurrency(runes []rune) []rune {
+	isCurrencyRune := func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsLetter(r) || unicode.Is(unicode.Sc, r)
+	}
+
+	start, end := 0, len(runes)
+	for start < end && isCurrencyRune(runes[start]) {
+		start++
+	}
+	for end > start && isCurrencyRune(runes[end-1]) {
+		end--
+	}
+
+	return runes[start:end]
+}
+
+// isMultiplicationSign checks if the letter x at the given position is used as a multiplication sign,
+// so it's not confused with the letter of currency code like MXN.
+func isMultiplicationSign(runes []rune, i int) bool {
+	isOperand := func(r rune, isLeft bool) bool {
+		if isLeft {
+			return unicode.IsDigit(r) || r == ')'
+		}
+
+		return unicode.IsDigit(r) || r == '('
+	}
+
+	left, right := i-1, i+1
+	for left >= 0 && unicode.IsSpace(runes[left]) {
+		left--
+	}
+	for right < len(runes) && unicode.IsSpace(runes[right]) {
+		right++
+	}
+
+	return left >= 0 && right < len(runes) && isOperand(runes[left], true) && isOperand(runes[right], false)
+}
+
+type expressionParser struct {
+	input string
+	pos   int
+}
+
+// parseExpression parses sum or difference of terms.
+func (p *expressionParser) parseExpression() (decimal.Decimal, error) {
+	result, err := p.parseTerm()
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	for p.pos < len(p.input) {
+		operator := p.input[p.pos]
+		if operator != '+' && operator != '-' {
+			break
+		}
+		p.pos++
+
+		term, err := p.parseTerm()
+		if err != nil {
+			return decimal.Zero, err
+		}
+
+		if operator == '+' {
+			result = result.Add(term)
+		} else {
+			result = result.Sub(term)
+		}
+	}
+
+	return result, nil
+}
+
+// parseTerm parses product or quotient of factors.
+func (p *expressionParser) parseTerm() (decimal.Decimal, error) {
+	result, err := p.parseFactor()
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	for p.pos < len(p.input) {
+		operator := p.input[p.pos]
+		if operator != '*' && operator != '/' {
+			break
+		}
+		p.pos++
+
+		factor, err := p.parseFactor()
+		if err != nil {
+			return decimal.Zero, err
+		}
+
+		if operator == '*' {
+			result = result.Mul(factor)
+			continue
+		}
+
+		if factor.IsZero() {
+			return decimal.Zero, ErrDivisionByZero
+		}
+		result = result.Div(factor)
+	}
+
+	return result, nil
+}
+
+// parseFactor parses a number, an expression in parentheses or a factor with unary sign.
+func (p *expressionParser) parseFactor() (decimal.Decimal, error) {
+	if p.pos >= len(p.input) {
+		return decimal.Zero, fmt.Errorf("%w: unexpected end of expression", ErrInvalidExpression)
+	}
+
+	switch p.input[p.pos] {
+	case '+':
+		p.pos++
+		return p.parseFactor()
+	case '-':
+		p.pos++
+		factor, err := p.parseFactor()
+		if err != nil {
+			return decimal.Zero, err
+		}
+
+		return factor.Neg(), nil
+	case '(':
+		p.pos++
+		result, err := p.parseExpression()
+		if err != nil {
+			return decimal.Zero, err
+		}
+		if p.pos >= len(p.input) || p.input[p.pos] != ')' {
+			return decimal.Zero, fmt.Errorf("%w: missing closing parenthesis", ErrInvalidExpression)
+		}
+		p.pos++
+
+		return result, nil
+	}
+
+	return p.parseNumber()
+}
+
+func (p *expressionParser) parseNumber() (decimal.Decimal, error) {
+	start := p.pos
+	for p.pos < len(p.input) && (isDigit(p.input[p.pos]) || p.input[p.pos] == '.' || p.input[p.pos] == ',') {
+		p.pos++
+	}
+	if start == p.pos {
+		return decimal.Zero, fmt.Errorf("%w: unexpected character %q", ErrInvalidExpression, p.input[p.pos])
+	}
+
+	number, err := decimal.NewFromString(normalizeNumber(p.input[start:p.pos]))
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("%w: invalid number %q", ErrInvalidExpression, p.input[start:p.pos])
+	}
+
+	return number, nil
+}
+
+// normalizeNumber removes thousands separators from the number and replaces decimal separator with a dot.
+func normalizeNumber(number string) string {
+	lastDot, lastComma := strings.LastIndex(number, "."), strings.LastIndex(number, ",")
+
+	switch {
+	case lastDot != -1 && lastComma != -1:
+		decimalSeparator, thousandsSeparator := ".", ","
+		if lastComma > lastDot {
+			decimalSeparator, thousandsSeparator = ",", "."
+		}
+
+		number = strings.ReplaceAll(number, thousandsSeparator, "")
+		return strings.Replace(number, decimalSeparator, ".", 1)
+	case lastComma != -1:
+		if strings.Count(number, ",") > 1 {
+			return strings.ReplaceAll(number, ",", "")
+		}
+
+		return strings.Replace(number, ",", ".", 1)
+	case lastDot != -1 && strings.Count(number, ".") > 1:
+		return strings.ReplaceAll(number, ".", "")
+	}
+
+	return number
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
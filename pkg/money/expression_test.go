@@ -0,0 +1,158 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFromExpression(t *testing.T) {
+	t.Parallel()
+
+	testCases := [...]struct {
+		desc        string
+		expression  string
+		expected    string
+		expectedErr error
+	}{
+		{
+			desc:       "Should parse plain number",
+			expression: "99.90",
+			expected:   "99.90",
+		},
+		{
+			desc:       "Should add numbers",
+			expression: "99.90+45",
+			expected:   "144.90",
+		},
+		{
+			desc:       "Should divide numbers",
+			expression: "1250/3",
+			expected:   "416.67",
+		},
+		{
+			desc:       "Should respect operators precedence and parentheses",
+			expression: "2 + 3 × (10 − 4) ÷ 2",
+			expected:   "11.00",
+		},
+		{
+			desc:       "Should parse multiplication with letter x",
+			expression: "3x15",
+			expected:   "45.00",
+		},
+		{
+			desc:       "Should parse negative numbers",
+			expression: "-5*(-2)",
+			expected:   "10.00",
+		},
+		{
+			desc:       "Should parse decimal comma",
+			expression: "99,90 + 0,1",
+			expected:   "100.00",
+		},
+		{
+			desc:       "Should parse thousands separators",
+			expression: "1,250.50 + 1.000,50 + 1 000",
+			expected:   "3251.00",
+		},
+		{
+			desc:       "Should parse space and apostrophe thousands separators",
+			expression: "1 000 000 + 2'500",
+			expected:   "1002500.00",
+		},
+		{
+			desc:       "Should parse single comma followed by 3 digits as decimal separator",
+			expression: "1,500",
+			expected:   "1.50",
+		},
+		{
+			desc:       "Should parse single dot followed by 3 digits as decimal separator",
+			expression: "1.500",
+			expected:   "1.50",
+		},
+		{
+			desc:        "Should return error on numbers separated by space",
+			expression:  "10 5",
+			expectedErr: ErrInvalidExpression,
+		},
+		{
+			desc:        "Should return error on decimal number followed by number after space",
+			expression:  "99.90 45",
+			expectedErr: ErrInvalidExpression,
+		},
+		{
+			desc:        "Should return error on several spaces between digits",
+			expression:  "1  000",
+			expectedErr: ErrInvalidExpression,
+		},
+		{
+			desc:        "Should return error on apostrophe outside of number",
+			expression:  "'100",
+			expectedErr: ErrInvalidExpression,
+		},
+		{
+			desc:       "Should strip leading and trailing currency symbols and codes",
+			expression: "$12.50 + 100 MXN",
+			expected:   "112.50",
+		},
+		{
+			desc:       "Should strip leading currency code",
+			expression: "UAH 1 000",
+			expected:   "1000.00",
+		},
+		{
+			desc:        "Should return error on letter inside number",
+			expression:  "1O0",
+			expectedErr: ErrInvalidExpression,
+		},
+		{
+			desc:        "Should return error on letters between numbers",
+			expression:  "12abc34",
+			expectedErr: ErrInvalidExpression,
+		},
+		{
+			desc:        "Should return error on currency code inside expression",
+			expression:  "10 USD + 5",
+			expectedErr: ErrInvalidExpression,
+		},
+		{
+			desc:        "Should return error on division by zero",
+			expression:  "10/0",
+			expectedErr: ErrDivisionByZero,
+		},
+		{
+			desc:        "Should return error on missing parenthesis",
+			expression:  "(10+5",
+			expectedErr: ErrInvalidExpression,
+		},
+		{
+			desc:        "Should return error on missing operand",
+			expression:  "10+",
+			expectedErr: ErrInvalidExpression,
+		},
+		{
+			desc:        "Should return error on empty expression",
+			expression:  "abc",
+			expectedErr: ErrInvalidExpression,
+		},
+		{
+			desc:        "Should return error on unsupported character",
+			expression:  "10^2",
+			expectedErr: ErrInvalidExpression,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := NewFromExpression(tc.expression)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual.StringFixed())
+		})
+	}
+}
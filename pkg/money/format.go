@@ -0,0 +1,71 @@
+package money
+
+import "strings"
+
+// DefaultDecimalPlaces represents the number of places after digit used by most of currencies.
+const DefaultDecimalPlaces int32 = 2
+
+// decimalPlacesByCurrencyCode contains minor units of currencies that differ from DefaultDecimalPlaces.
+// Values are taken from ISO 4217, crypto currencies are limited to 8 places.
+// They're used only when currencies are added to the catalog, after that minor units are taken from the stored currency.
+var decimalPlacesByCurrencyCode = map[string]int32{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+	"BTC": 8, "ETH": 8,
+}
+
+// symbolBeforeAmountCurrencyCodes contains currencies which symbol is commonly written before the amount.
+var symbolBeforeAmountCurrencyCodes = map[string]struct{}{
+	"USD": {}, "GBP": {}, "AUD": {}, "CAD": {}, "NZD": {}, "HKD": {}, "SGD": {}, "MXN": {},
+	"JPY": {}, "CNY": {}, "INR": {}, "ILS": {}, "KRW": {}, "PHP": {}, "THB": {}, "BRL": {},
+}
+
+// Format represents the rules used to render an amount of the specific currency.
+type Format struct {
+	Symbol        string
+	DecimalPlaces int32
+	// SymbolBefore is true when the symbol should be written before the amount, e.g. $10.00 instead of 10.00$.
+	SymbolBefore bool
+}
+
+// NewFormat returns format of the currency with the given code, symbol and minor units.
+// Symbol placement is resolved by the currency code, unknown codes have symbol after the amount.
+func NewFormat(code, symbol string, decimalPlaces int32) Format {
+	_, symbolBefore := symbolBeforeAmountCurrencyCodes[strings.ToUpper(code)]
+
+	return Format{
+		Symbol:        symbol,
+		DecimalPlaces: decimalPlaces,
+		SymbolBefore:  symbolBefore,
+	}
+}
+
+// GetDecimalPlaces returns the number of places after digit defined by ISO 4217 for the currency with the given code.
+func GetDecimalPlaces(code string) int32 {
+	decimalPlaces, ok := decimalPlacesByCurrencyCode[strings.ToUpper(code)]
+	if !ok {
+		return DefaultDecimalPlaces
+	}
+
+	return decimalPlaces
+}
+
+// Round returns amount rounded to the nearest with the given places after digit.
+func (m Money) Round(places int32) Money {
+	return Money{m.decimal.Round(places)}
+}
+
+// Format returns string representation of amount rounded to the currency minor units with the currency symbol.
+func (m Money) Format(format Format) string {
+	if !format.SymbolBefore {
+		return m.decimal.StringFixed(format.DecimalPlaces) + format.Symbol
+	}
+
+	if m.decimal.IsNegative() {
+		return "-" + format.Symbol + m.decimal.Abs().StringFixed(format.DecimalPlaces)
+	}
+
+	return format.Symbol + m.decimal.StringFixed(format.DecimalPlaces)
+}
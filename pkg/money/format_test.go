@@ -0,0 +1,70 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoney_Format(t *testing.T) {
+	t.Parallel()
+
+	testCases := [...]struct {
+		desc          string
+		initialAmount Money
+		format        Format
+		expected      string
+	}{
+		{
+			desc:          "Should render amount with default places and symbol after amount",
+			initialAmount: NewFromFloat(10.5),
+			format:        NewFormat("UAH", "₴", 2),
+			expected:      "10.50₴",
+		},
+		{
+			desc:          "Should render amount with symbol before amount",
+			initialAmount: NewFromFloat(10.5),
+			format:        NewFormat("usd", "$", 2),
+			expected:      "$10.50",
+		},
+		{
+			desc:          "Should render negative amount with symbol before amount",
+			initialAmount: NewFromFloat(-10.5),
+			format:        NewFormat("USD", "$", 2),
+			expected:      "-$10.50",
+		},
+		{
+			desc:          "Should round amount of currency without minor units",
+			initialAmount: NewFromFloat(1234.56),
+			format:        NewFormat("JPY", "¥", 0),
+			expected:      "¥1235",
+		},
+		{
+			desc:          "Should render amount of currency with 3 minor units",
+			initialAmount: NewFromFloat(1.2345),
+			format:        NewFormat("BHD", "BD", 3),
+			expected:      "1.235BD",
+		},
+		{
+			desc:          "Should render amount with custom places",
+			initialAmount: NewFromFloat(0.00012345),
+			format:        Format{Symbol: "₿", DecimalPlaces: 8},
+			expected:      "0.00012345₿",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.initialAmount.Format(tc.format))
+		})
+	}
+}
+
+func TestGetDecimalPlaces(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, int32(0), GetDecimalPlaces("JPY"))
+	assert.Equal(t, int32(3), GetDecimalPlaces("kwd"))
+	assert.Equal(t, DefaultDecimalPlaces, GetDecimalPlaces("EUR"))
+}
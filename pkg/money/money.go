@@ -82,6 +82,12 @@ func (m Money) StringFixed() string {
 	return m.decimal.StringFixed(2)
 }
 
+// StringFixedPlaces returns string representation of float with the given places after digit.
+// Resulting string will be rounded to nearest.
+func (m Money) StringFixedPlaces(places int32) string {
+	return m.decimal.StringFixed(places)
+}
+
 // String returns string representation of float with unlimited places after digit.
 // Resulting string will be rounded to nearest.
 func (m Money) String() string {
@@ -92,3 +98,16 @@ func (m Money) String() string {
 func (m *Money) Set(value Money) {
 	m.decimal = value.decimal
 }
+
+// StringRounded returns string representation of float rounded to the given places after digit.
+// Unlike StringFixed, trailing zeros are not added.
+func (m Money) StringRounded(places int32) string {
+	return m.decimal.Round(places).String()
+}
+
+// Float64 returns float representation of the amount, it could lose precision,
+// so it should be used only for presentation purposes, e.g. for drawing charts.
+func (m Money) Float64() float64 {
+	f, _ := m.decimal.Float64()
+	return f
+}
@@ -188,6 +188,43 @@ func TestMoney_StringFixed(t *testing.T) {
 	}
 }
 
+func TestMoney_StringFixedPlaces(t *testing.T) {
+	t.Parallel()
+
+	testCases := [...]struct {
+		desc          string
+		initialAmount Money
+		places        int32
+		expected      string
+	}{
+		{
+			desc:          "Should return string representation of float with 3 places after digit",
+			initialAmount: NewFromFloat(1.2345),
+			places:        3,
+			expected:      "1.235",
+		},
+		{
+			desc:          "Should return string representation of float with 8 places after digit with trailing zeros",
+			initialAmount: NewFromFloat(0.001),
+			places:        8,
+			expected:      "0.00100000",
+		},
+		{
+			desc:          "Should return string representation of float without places after digit",
+			initialAmount: NewFromFloat(150.5),
+			places:        0,
+			expected:      "151",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.initialAmount.StringFixedPlaces(tc.places))
+		})
+	}
+}
+
 func TestMoney_String(t *testing.T) {
 	t.Parallel()
 
@@ -215,3 +252,62 @@ func TestMoney_String(t *testing.T) {
 		})
 	}
 }
+
+func TestMoney_StringRounded(t *testing.T) {
+	t.Parallel()
+
+	testCases := [...]struct {
+		desc          string
+		initialAmount Money
+		places        int32
+		expected      string
+	}{
+		{
+			desc:          "Should return string representation of float rounded to 4 places after digit",
+			initialAmount: NewFromFloat(41.234567),
+			places:        4,
+			expected:      "41.2346",
+		},
+		{
+			desc:          "Should return string representation of float without trailing zeros",
+			initialAmount: NewFromFloat(41.2),
+			places:        4,
+			expected:      "41.2",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.initialAmount.StringRounded(tc.places))
+		})
+	}
+}
+
+func TestMoney_Float64(t *testing.T) {
+	t.Parallel()
+
+	testCases := [...]struct {
+		desc          string
+		initialAmount Money
+		expected      float64
+	}{
+		{
+			desc:          "Should return float representation of positive amount",
+			initialAmount: NewFromFloat(41.25),
+			expected:      41.25,
+		},
+		{
+			desc:          "Should return float representation of negative amount",
+			initialAmount: NewFromInt(-10),
+			expected:      -10,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.initialAmount.Float64())
+		})
+	}
+}